@@ -1,7 +1,8 @@
 package ante
 
 import (
-        "cosmossdk.io/math"
+        "fmt"
+
         txsigning "cosmossdk.io/x/tx/signing"
         sdk "github.com/cosmos/cosmos-sdk/types"
         "github.com/cosmos/cosmos-sdk/x/auth/ante"
@@ -13,6 +14,7 @@ import (
 type HandlerOptions struct {
         AccountKeeper   authkeeper.AccountKeeper
         BankKeeper      bankkeeper.Keeper
+        MiningKeeper    MiningKeeper
         FeegrantKeeper  ante.FeegrantKeeper
         SignModeHandler *txsigning.HandlerMap
         SigGasConsumer  ante.SignatureVerificationGasConsumer
@@ -20,10 +22,12 @@ type HandlerOptions struct {
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
-// numbers, checks signatures & account numbers, and burns 50% of fees.
+// numbers, checks signatures & account numbers, enforces the mining module's
+// base fee and burns it.
 func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
-        // 50% burn rate
-        burnRate := math.LegacyNewDecWithPrec(50, 2) // 0.50
+        if options.MiningKeeper == nil {
+                return nil, fmt.Errorf("mining keeper is required for the base fee ante handler")
+        }
 
         sigGasConsumer := options.SigGasConsumer
         if sigGasConsumer == nil {
@@ -38,7 +42,7 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
                 ante.NewValidateMemoDecorator(options.AccountKeeper),
                 ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
                 ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.TxFeeChecker),
-                NewBurnFeeDecorator(options.BankKeeper, burnRate), // Burn 50% of fees AFTER deduction
+                NewBaseFeeDecorator(options.BankKeeper, options.MiningKeeper), // Enforce the base fee and burn it AFTER deduction
                 ante.NewSetPubKeyDecorator(options.AccountKeeper),
                 ante.NewValidateSigCountDecorator(options.AccountKeeper),
                 ante.NewSigGasConsumeDecorator(options.AccountKeeper, sigGasConsumer),
@@ -0,0 +1,113 @@
+package ante
+
+import (
+	"fmt"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	miningtypes "nexus/x/mining/types"
+)
+
+// MiningKeeper is the part of the mining keeper the fee market reads the base
+// fee and params from and records burns in
+type MiningKeeper interface {
+	GetParams(ctx sdk.Context) miningtypes.Params
+	GetBaseFee(ctx sdk.Context) math.LegacyDec
+	RecordBurn(ctx sdk.Context, source string, amount math.Int)
+}
+
+// BaseFeeDecorator enforces the EIP-1559 base fee kept by the mining module
+// and burns its share of the fee. A transaction must pay at least base fee x
+// gas limit in unexus; that part is burned from the fee collector, recorded
+// as the base_fee burn source, and the rest stays there as the validators'
+// tip. While the base fee is zero the flat tx_fee_burn_percent, weighted per
+// message by the overrides, is burned instead as tx_fee. A burn the fee
+// collector cannot cover fails the transaction. Runs after fee deduction.
+type BaseFeeDecorator struct {
+	bankKeeper   bankkeeper.Keeper
+	miningKeeper MiningKeeper
+}
+
+// NewBaseFeeDecorator creates a new BaseFeeDecorator
+func NewBaseFeeDecorator(bk bankkeeper.Keeper, mk MiningKeeper) BaseFeeDecorator {
+	return BaseFeeDecorator{
+		bankKeeper:   bk,
+		miningKeeper: mk,
+	}
+}
+
+// AnteHandle implements the AnteDecorator interface
+func (bfd BaseFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// Skip during simulation and for genesis transactions
+	if simulate || ctx.BlockHeight() == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.ErrTxDecode.Wrap("tx must be a FeeTx")
+	}
+
+	fees := feeTx.GetFee()
+	baseFee := bfd.miningKeeper.GetBaseFee(ctx)
+	required := baseFee.MulInt(math.NewIntFromUint64(feeTx.GetGas())).Ceil().TruncateInt()
+	if paid := fees.AmountOf("unexus"); paid.LT(required) {
+		return ctx, sdkerrors.ErrInsufficientFee.Wrapf(
+			"fee %sunexus is below base fee %s x gas %d = %sunexus", paid, baseFee, feeTx.GetGas(), required,
+		)
+	}
+
+	if fees.IsZero() {
+		return next(ctx, tx, simulate)
+	}
+
+	var burnCoins sdk.Coins
+	burnSource := miningtypes.BurnSourceBaseFee
+	if required.IsPositive() {
+		burnCoins = sdk.NewCoins(sdk.NewCoin("unexus", required))
+	} else {
+		burnSource = miningtypes.BurnSourceTxFee
+		msgTypeUrls := make([]string, 0, len(tx.GetMsgs()))
+		for _, msg := range tx.GetMsgs() {
+			msgTypeUrls = append(msgTypeUrls, sdk.MsgTypeURL(msg))
+		}
+		params := bfd.miningKeeper.GetParams(ctx)
+		for _, fee := range fees {
+			burnAmount := params.TxFeeBurnAmount(fee.Amount, msgTypeUrls)
+			if burnAmount.IsPositive() {
+				burnCoins = burnCoins.Add(sdk.NewCoin(fee.Denom, burnAmount))
+			}
+		}
+	}
+
+	// Burn coins from the fee collector; fee deduction already moved the fee
+	// there, so a failure means the tx cannot pay its burn
+	if !burnCoins.IsZero() {
+		if err := bfd.bankKeeper.BurnCoins(ctx, authtypes.FeeCollectorName, burnCoins); err != nil {
+			return ctx, sdkerrors.ErrInsufficientFunds.Wrapf("failed to burn fee %s: %s", burnCoins, err)
+		}
+		bfd.miningKeeper.RecordBurn(ctx, burnSource, burnCoins.AmountOf("unexus"))
+
+		ctx.Logger().Info("Burned transaction fees",
+			"burned", burnCoins.String(),
+			"source", burnSource,
+			"total_fees", fees.String(),
+		)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"fee_burn",
+				sdk.NewAttribute("amount", burnCoins.String()),
+				sdk.NewAttribute("source", burnSource),
+				sdk.NewAttribute("total_fees", fees.String()),
+				sdk.NewAttribute("base_fee", baseFee.String()),
+				sdk.NewAttribute("gas", fmt.Sprintf("%d", feeTx.GetGas())),
+			),
+		)
+	}
+
+	return next(ctx, tx, simulate)
+}
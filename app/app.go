@@ -35,8 +35,14 @@ import (
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	consensusparamkeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	consensusparamtypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -60,15 +66,20 @@ var (
 		auth.AppModuleBasic{},
 		bank.AppModuleBasic{},
 		staking.AppModuleBasic{},
+		distr.AppModuleBasic{},
 		genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
+		gov.NewAppModuleBasic(nil),
 		miningmodule.AppModuleBasic{},
 	)
 
 	maccPerms = map[string][]string{
-		authtypes.FeeCollectorName:     nil,
+		authtypes.FeeCollectorName:     {authtypes.Burner},
+		distrtypes.ModuleName:          nil,
 		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
+		govtypes.ModuleName:            {authtypes.Burner},
 		miningtypes.ModuleName:         {authtypes.Minter, authtypes.Burner},
+		miningtypes.TreasuryModuleName: nil,
 	}
 )
 
@@ -99,6 +110,8 @@ type App struct {
 	AccountKeeper         authkeeper.AccountKeeper
 	BankKeeper            bankkeeper.Keeper
 	StakingKeeper         *stakingkeeper.Keeper
+	DistrKeeper           distrkeeper.Keeper
+	GovKeeper             *govkeeper.Keeper
 	MiningKeeper          miningkeeper.Keeper
 	ConsensusParamsKeeper consensusparamkeeper.Keeper
 
@@ -133,6 +146,8 @@ func New(
 		authtypes.StoreKey,
 		banktypes.StoreKey,
 		stakingtypes.StoreKey,
+		distrtypes.StoreKey,
+		govtypes.StoreKey,
 		miningtypes.StoreKey,
 		consensusparamtypes.StoreKey,
 	)
@@ -146,6 +161,9 @@ func New(
 		keys:              keys,
 	}
 
+	// Governance executes every privileged message
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+
 	app.AccountKeeper = authkeeper.NewAccountKeeper(
 		cdc,
 		runtime.NewKVStoreService(keys[authtypes.StoreKey]),
@@ -153,7 +171,7 @@ func New(
 		maccPerms,
 		authcodec.NewBech32Codec(AccountAddressPrefix),
 		AccountAddressPrefix,
-		authtypes.NewModuleAddress(authtypes.ModuleName).String(),
+		authority,
 	)
 
 	app.BankKeeper = bankkeeper.NewBaseKeeper(
@@ -161,7 +179,7 @@ func New(
 		runtime.NewKVStoreService(keys[banktypes.StoreKey]),
 		app.AccountKeeper,
 		nil,
-		authtypes.NewModuleAddress(authtypes.ModuleName).String(),
+		authority,
 		logger,
 	)
 
@@ -170,35 +188,65 @@ func New(
 		runtime.NewKVStoreService(keys[stakingtypes.StoreKey]),
 		app.AccountKeeper,
 		app.BankKeeper,
-		authtypes.NewModuleAddress(authtypes.ModuleName).String(),
+		authority,
 		authcodec.NewBech32Codec(AccountAddressPrefix+"valoper"),
 		authcodec.NewBech32Codec(AccountAddressPrefix+"valcons"),
 	)
 
+	app.DistrKeeper = distrkeeper.NewKeeper(
+		cdc,
+		runtime.NewKVStoreService(keys[distrtypes.StoreKey]),
+		app.AccountKeeper,
+		app.BankKeeper,
+		app.StakingKeeper,
+		authtypes.FeeCollectorName,
+		authority,
+	)
+
+	// Distribution tracks delegator rewards through staking hooks
+	app.StakingKeeper.SetHooks(
+		stakingtypes.NewMultiStakingHooks(app.DistrKeeper.Hooks()),
+	)
+
 	app.ConsensusParamsKeeper = consensusparamkeeper.NewKeeper(
 		cdc,
 		runtime.NewKVStoreService(keys[consensusparamtypes.StoreKey]),
-		authtypes.NewModuleAddress(authtypes.ModuleName).String(),
+		authority,
 		runtime.EventService{},
 	)
 
 	// Set consensus params store in baseapp
 	bApp.SetParamStore(app.ConsensusParamsKeeper.ParamsStore)
 
+	app.GovKeeper = govkeeper.NewKeeper(
+		cdc,
+		runtime.NewKVStoreService(keys[govtypes.StoreKey]),
+		app.AccountKeeper,
+		app.BankKeeper,
+		app.StakingKeeper,
+		app.DistrKeeper,
+		bApp.MsgServiceRouter(),
+		govtypes.DefaultConfig(),
+		authority,
+	)
+
 	app.MiningKeeper = miningkeeper.NewKeeper(
 		cdc,
 		keys[miningtypes.StoreKey],
 		nil,
 		app.StakingKeeper,
 		app.BankKeeper,
-		authtypes.NewModuleAddress(authtypes.ModuleName).String(),
+		app.DistrKeeper,
+		authority,
 	)
 
 	app.ModuleManager = module.NewManager(
 		auth.NewAppModule(cdc, app.AccountKeeper, nil, nil),
 		bank.NewAppModule(cdc, app.BankKeeper, app.AccountKeeper, nil),
 		staking.NewAppModule(cdc, app.StakingKeeper, app.AccountKeeper, app.BankKeeper, nil),
+		distr.NewAppModule(cdc, app.DistrKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper, nil),
 		genutil.NewAppModule(app.AccountKeeper, app.StakingKeeper, app, txConfig),
+		gov.NewAppModule(cdc, app.GovKeeper, app.AccountKeeper, app.BankKeeper, nil),
 		miningmodule.NewAppModule(cdc, app.MiningKeeper),
 	)
 
@@ -208,10 +256,12 @@ func New(
 	)
 
 	app.ModuleManager.SetOrderBeginBlockers(
+		distrtypes.ModuleName,
 		stakingtypes.ModuleName,
 		authtypes.ModuleName,
 		banktypes.ModuleName,
 		genutiltypes.ModuleName,
+		govtypes.ModuleName,
 		miningtypes.ModuleName,
 	)
 
@@ -219,15 +269,19 @@ func New(
 		stakingtypes.ModuleName,
 		authtypes.ModuleName,
 		banktypes.ModuleName,
+		distrtypes.ModuleName,
 		genutiltypes.ModuleName,
+		govtypes.ModuleName,
 		miningtypes.ModuleName,
 	)
 
 	genesisModuleOrder := []string{
 		authtypes.ModuleName,
 		banktypes.ModuleName,
+		distrtypes.ModuleName,
 		stakingtypes.ModuleName,
 		genutiltypes.ModuleName,
+		govtypes.ModuleName,
 		miningtypes.ModuleName,
 	}
 	app.ModuleManager.SetOrderInitGenesis(genesisModuleOrder...)
@@ -237,11 +291,12 @@ func New(
 
 	app.MountKVStores(keys)
 
-	// Set up custom AnteHandler with 50% fee burn
+	// Set up custom AnteHandler with the base fee market and fee burn
 	anteHandler, err := nexusante.NewAnteHandler(nexusante.HandlerOptions{
                 SignModeHandler: txConfig.SignModeHandler(),
 		AccountKeeper:  app.AccountKeeper,
 		BankKeeper:     app.BankKeeper,
+		MiningKeeper:   app.MiningKeeper,
 		FeegrantKeeper: nil,
 		SigGasConsumer: nil,
 		TxFeeChecker:   nil,
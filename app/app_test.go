@@ -0,0 +1,323 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/testutil/mock"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	nexusante "nexus/app/ante"
+	miningkeeper "nexus/x/mining/keeper"
+	miningtypes "nexus/x/mining/types"
+)
+
+const testChainID = "nexus-test"
+
+// setupApp starts a chain with one validator, delegated to by the returned
+// account, and a funded mining treasury
+func setupApp(t *testing.T, treasury sdk.Coins) (*App, sdk.Context, sdk.AccAddress) {
+	t.Helper()
+	app := New(log.NewNopLogger(), dbm.NewMemDB(), nil, true, simtestutil.EmptyAppOptions{}, testChainID)
+
+	pubKey, err := mock.NewPV().GetPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	valSet := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(pubKey, 1)})
+
+	delegator := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	balances := []banktypes.Balance{
+		{Address: delegator.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000_000))},
+		{Address: miningkeeper.TreasuryAddress().String(), Coins: treasury},
+	}
+	genesisState, err := simtestutil.GenesisStateWithValSet(app.AppCodec(), app.DefaultGenesis(), valSet,
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccount(delegator, nil, 0, 0)}, balances...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateBytes, err := json.Marshal(genesisState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.InitChain(&abci.RequestInitChain{
+		ChainId:         testChainID,
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	}); err != nil {
+		t.Fatalf("InitChain failed: %v", err)
+	}
+	ctx := app.NewContextLegacy(false, cmtproto.Header{ChainID: testChainID, Height: 1, Time: time.Unix(1_700_000_000, 0)})
+	return app, ctx, delegator
+}
+
+// passProposal submits msgs as a proposal, votes yes with the only
+// delegator and ends the voting period
+func passProposal(t *testing.T, app *App, ctx sdk.Context, proposer sdk.AccAddress, msgs ...sdk.Msg) (sdk.Context, govv1.Proposal) {
+	t.Helper()
+	params, err := app.GovKeeper.Params.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submit, err := govv1.NewMsgSubmitProposal(msgs, params.MinDeposit, proposer.String(), "", "Mining proposal", "Executed by the mining module", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	govServer := govkeeper.NewMsgServerImpl(app.GovKeeper)
+	res, err := govServer.SubmitProposal(ctx, submit)
+	if err != nil {
+		t.Fatalf("SubmitProposal failed: %v", err)
+	}
+	if _, err := govServer.Vote(ctx, govv1.NewMsgVote(proposer, res.ProposalId, govv1.OptionYes, "")); err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(*params.VotingPeriod))
+	if err := gov.EndBlocker(ctx, app.GovKeeper); err != nil {
+		t.Fatalf("gov EndBlocker failed: %v", err)
+	}
+	proposal, err := app.GovKeeper.Proposals.Get(ctx, res.ProposalId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx, proposal
+}
+
+func TestMiningAuthorityIsGov(t *testing.T) {
+	app, _, _ := setupApp(t, sdk.NewCoins())
+
+	if got, want := app.MiningKeeper.GetAuthority(), authtypes.NewModuleAddress(govtypes.ModuleName).String(); got != want {
+		t.Errorf("Expected mining authority %s, got %s", want, got)
+	}
+}
+
+func TestTreasurySpendProposal(t *testing.T) {
+	app, ctx, proposer := setupApp(t, sdk.NewCoins(sdk.NewInt64Coin("unexus", 5000)))
+	recipient := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	ctx, proposal := passProposal(t, app, ctx, proposer, &miningtypes.MsgTreasurySpend{
+		Authority: app.MiningKeeper.GetAuthority(),
+		Recipient: recipient.String(),
+		Amount:    sdk.NewCoins(sdk.NewInt64Coin("unexus", 1200)),
+		Purpose:   "protein folding grant",
+	})
+	if proposal.Status != govv1.StatusPassed {
+		t.Fatalf("Expected proposal to pass, got %s (%s)", proposal.Status, proposal.FailedReason)
+	}
+
+	if balance := app.BankKeeper.GetBalance(ctx, recipient, "unexus"); !balance.Amount.Equal(math.NewInt(1200)) {
+		t.Errorf("Expected recipient to receive 1200unexus, got %s", balance)
+	}
+	if balance := app.BankKeeper.GetBalance(ctx, miningkeeper.TreasuryAddress(), "unexus"); !balance.Amount.Equal(math.NewInt(3800)) {
+		t.Errorf("Expected treasury to keep 3800unexus, got %s", balance)
+	}
+	spend, found := app.MiningKeeper.GetTreasurySpend(ctx, 1)
+	if !found || spend.Recipient != recipient.String() || spend.Purpose != "protein folding grant" {
+		t.Errorf("Expected spend record for %s, got %+v", recipient, spend)
+	}
+}
+
+func TestSetAlgorithmProposal(t *testing.T) {
+	app, ctx, proposer := setupApp(t, sdk.NewCoins())
+
+	algo := miningtypes.DefaultAlgorithms()[0]
+	algo.Status = miningtypes.AlgorithmStatusDisabled
+	ctx, proposal := passProposal(t, app, ctx, proposer, &miningtypes.MsgSetAlgorithm{
+		Authority: app.MiningKeeper.GetAuthority(),
+		Algorithm: algo,
+	})
+	if proposal.Status != govv1.StatusPassed {
+		t.Fatalf("Expected proposal to pass, got %s (%s)", proposal.Status, proposal.FailedReason)
+	}
+
+	if _, err := app.MiningKeeper.GetActiveAlgorithm(ctx, algo.Id); err == nil {
+		t.Errorf("Expected %s to be disabled by governance", algo.Id)
+	}
+}
+
+func TestUpdateParamsProposal(t *testing.T) {
+	app, ctx, proposer := setupApp(t, sdk.NewCoins())
+
+	params := app.MiningKeeper.GetParams(ctx)
+	params.EmissionFloorPermille = 30
+	ctx, proposal := passProposal(t, app, ctx, proposer, &miningtypes.MsgUpdateParams{
+		Authority: app.MiningKeeper.GetAuthority(),
+		Params:    params,
+	})
+	if proposal.Status != govv1.StatusPassed {
+		t.Fatalf("Expected proposal to pass, got %s (%s)", proposal.Status, proposal.FailedReason)
+	}
+
+	if floor := app.MiningKeeper.GetParams(ctx).EmissionFloorPermille; floor != 30 {
+		t.Errorf("Expected emission floor 30 permille after the proposal, got %d", floor)
+	}
+}
+
+func TestEndBlockDetectsBrokenInvariant(t *testing.T) {
+	app, ctx, _ := setupApp(t, sdk.NewCoins())
+	interval := app.MiningKeeper.GetParams(ctx).InvariantCheckInterval
+	ctx = ctx.WithBlockHeight(interval)
+
+	if _, err := app.ModuleManager.EndBlock(ctx); err != nil {
+		t.Fatalf("EndBlock failed on a consistent state: %v", err)
+	}
+
+	// A reward pool the module account cannot pay breaks module-balance
+	app.MiningKeeper.SetValidatorRewardPool(ctx, math.NewInt(1000))
+	brokenEvents := func(events []abci.Event) int {
+		var n int
+		for _, event := range events {
+			if event.Type == "invariant_broken" {
+				n++
+			}
+		}
+		return n
+	}
+
+	res, err := app.ModuleManager.EndBlock(ctx.WithBlockHeight(interval + 1))
+	if err != nil || brokenEvents(res.Events) != 0 {
+		t.Fatalf("Invariants should only be checked on the interval, got %v", err)
+	}
+
+	// By default a broken invariant is reported, not fatal
+	res, err = app.ModuleManager.EndBlock(ctx.WithBlockHeight(2 * interval))
+	if err != nil {
+		t.Fatalf("EndBlock should not halt by default, got %v", err)
+	}
+	if n := brokenEvents(res.Events); n != 1 {
+		t.Errorf("Expected one invariant_broken event, got %d", n)
+	}
+
+	params := app.MiningKeeper.GetParams(ctx)
+	params.InvariantHalt = true
+	if err := app.MiningKeeper.SetParams(ctx, params); err != nil {
+		t.Fatalf("SetParams failed: %v", err)
+	}
+	_, err = app.ModuleManager.EndBlock(ctx.WithBlockHeight(2 * interval))
+	if err == nil || !strings.Contains(err.Error(), miningkeeper.ModuleBalanceInvariantRoute) {
+		t.Fatalf("Expected EndBlock to fail on the broken %s invariant, got %v", miningkeeper.ModuleBalanceInvariantRoute, err)
+	}
+
+	// A zero interval turns the checks off
+	params.InvariantCheckInterval = 0
+	if err := app.MiningKeeper.SetParams(ctx, params); err != nil {
+		t.Fatalf("SetParams failed: %v", err)
+	}
+	if _, err := app.ModuleManager.EndBlock(ctx.WithBlockHeight(2 * interval)); err != nil {
+		t.Fatalf("Expected no checks with a zero interval, got %v", err)
+	}
+}
+
+func TestFeeBurnWeightsMessages(t *testing.T) {
+	app, ctx, sender := setupApp(t, sdk.NewCoins())
+
+	// With no base fee the ante handler burns the per-message rates: a
+	// batch of two MsgSubmitWork at 10% and one message at the default 50%
+	// burns their mean, not the highest rate
+	params := app.MiningKeeper.GetParams(ctx)
+	params.MinBaseFee = math.LegacyZeroDec()
+	params.MsgFeeBurnOverrides = []miningtypes.MsgFeeBurnOverride{
+		{MsgTypeUrl: sdk.MsgTypeURL(&miningtypes.MsgSubmitWork{}), BurnPercent: 10},
+	}
+	if err := app.MiningKeeper.SetParams(ctx, params); err != nil {
+		t.Fatal(err)
+	}
+	app.MiningKeeper.SetBaseFee(ctx, math.LegacyZeroDec())
+
+	fees := sdk.NewCoins(sdk.NewInt64Coin("unexus", 3000))
+	if err := app.BankKeeper.MintCoins(ctx, miningtypes.ModuleName, fees); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.BankKeeper.SendCoinsFromModuleToModule(ctx, miningtypes.ModuleName, authtypes.FeeCollectorName, fees); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := app.TxConfig().NewTxBuilder()
+	if err := builder.SetMsgs(
+		&miningtypes.MsgSubmitWork{Miner: sender.String()},
+		&miningtypes.MsgSubmitWork{Miner: sender.String()},
+		&miningtypes.MsgClaimRewards{Claimer: sender.String()},
+	); err != nil {
+		t.Fatal(err)
+	}
+	builder.SetFeeAmount(fees)
+	builder.SetGasLimit(200_000)
+
+	decorator := nexusante.NewBaseFeeDecorator(app.BankKeeper, app.MiningKeeper)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+	if _, err := decorator.AnteHandle(ctx, builder.GetTx(), false, next); err != nil {
+		t.Fatalf("AnteHandle failed: %v", err)
+	}
+
+	feeCollector := app.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	if left := app.BankKeeper.GetBalance(ctx, feeCollector, "unexus"); !left.Amount.Equal(math.NewInt(2300)) {
+		t.Errorf("Expected 700unexus burned leaving 2300unexus, got %s", left)
+	}
+	if burned := app.MiningKeeper.GetBurned(ctx, miningtypes.BurnSourceTxFee); !burned.Equal(math.NewInt(700)) {
+		t.Errorf("Expected 700unexus recorded as tx fee burn, got %s", burned)
+	}
+}
+
+func TestBaseFeeBurn(t *testing.T) {
+	app, ctx, sender := setupApp(t, sdk.NewCoins())
+	app.MiningKeeper.SetBaseFee(ctx, math.LegacyNewDec(2))
+
+	builder := app.TxConfig().NewTxBuilder()
+	if err := builder.SetMsgs(&miningtypes.MsgClaimRewards{Claimer: sender.String()}); err != nil {
+		t.Fatal(err)
+	}
+	fees := sdk.NewCoins(sdk.NewInt64Coin("unexus", 250_000))
+	builder.SetFeeAmount(fees)
+	builder.SetGasLimit(100_000)
+
+	decorator := nexusante.NewBaseFeeDecorator(app.BankKeeper, app.MiningKeeper)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	// A fee collector that cannot cover the burn fails the transaction
+	if _, err := decorator.AnteHandle(ctx, builder.GetTx(), false, next); err == nil {
+		t.Fatal("Expected AnteHandle to fail when the base fee cannot be burned")
+	}
+	if burned := app.MiningKeeper.GetBurned(ctx, miningtypes.BurnSourceBaseFee); !burned.IsZero() {
+		t.Errorf("Expected no burn recorded after a failed burn, got %s", burned)
+	}
+
+	if err := app.BankKeeper.MintCoins(ctx, miningtypes.ModuleName, fees); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.BankKeeper.SendCoinsFromModuleToModule(ctx, miningtypes.ModuleName, authtypes.FeeCollectorName, fees); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decorator.AnteHandle(ctx, builder.GetTx(), false, next); err != nil {
+		t.Fatalf("AnteHandle failed: %v", err)
+	}
+
+	// Base fee x gas is burned under its own source; the rest is the tip
+	feeCollector := app.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	if left := app.BankKeeper.GetBalance(ctx, feeCollector, "unexus"); !left.Amount.Equal(math.NewInt(50_000)) {
+		t.Errorf("Expected a 50000unexus tip left, got %s", left)
+	}
+	if burned := app.MiningKeeper.GetBurned(ctx, miningtypes.BurnSourceBaseFee); !burned.Equal(math.NewInt(200_000)) {
+		t.Errorf("Expected 200000unexus recorded as base fee burn, got %s", burned)
+	}
+	if burned := app.MiningKeeper.GetBurned(ctx, miningtypes.BurnSourceTxFee); !burned.IsZero() {
+		t.Errorf("Expected no tx fee burn while the base fee is positive, got %s", burned)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"cosmossdk.io/math"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/debug"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/codec/types"
@@ -74,6 +75,7 @@ func NewRootCmd() *cobra.Command {
 		keys.Commands(),
 		VersionCmd(),
                 GenesisCmd(),
+		DebugCmd(),
 	)
 	rootCmd.PersistentFlags().String(flags.FlagHome, app.DefaultNodeHome, "home")
 	return rootCmd
@@ -108,6 +110,13 @@ func QueryCmd() *cobra.Command {
 	return cmd
 }
 
+// DebugCmd extends the SDK debug commands with mining state checks
+func DebugCmd() *cobra.Command {
+	cmd := debug.Cmd()
+	cmd.AddCommand(miningcli.CmdCheckMiningInvariants())
+	return cmd
+}
+
 func InitCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:  "init [moniker]",
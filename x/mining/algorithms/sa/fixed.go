@@ -0,0 +1,115 @@
+package sa
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Temperatures and probabilities are unsigned Q32.32 fixed-point numbers so
+// every implementation (Go, Rust, circuit) rounds identically. All
+// operations truncate toward zero.
+const (
+	FracBits = 32
+	One      = uint64(1) << FracBits
+
+	// expCutoff is where exp(-x) drops below 2^-32 and always rejects
+	expCutoff = 32
+	// expTerms bounds the Taylor series for exp(-f), f in [0, 1]
+	expTerms = 20
+)
+
+// mulQ multiplies two Q32.32 values
+func mulQ(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi<<(64-FracBits) | lo>>FracBits
+}
+
+// ParseFixed parses a non-negative decimal string ("0.99995") into Q32.32
+func ParseFixed(s string) (uint64, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("empty fixed-point value")
+	}
+	if len(fracPart) > 18 {
+		fracPart = fracPart[:18]
+	}
+
+	var whole uint64
+	for _, c := range intPart {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid fixed-point value %q", s)
+		}
+		whole = whole*10 + uint64(c-'0')
+		if whole >= 1<<(64-FracBits) {
+			return 0, fmt.Errorf("fixed-point value %q out of range", s)
+		}
+	}
+
+	var digits, scale uint64 = 0, 1
+	for _, c := range fracPart {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid fixed-point value %q", s)
+		}
+		digits = digits*10 + uint64(c-'0')
+		scale *= 10
+	}
+
+	// frac = digits * 2^32 / 10^len, with digits < scale so the quotient fits
+	hi, lo := bits.Mul64(digits, One)
+	frac, _ := bits.Div64(hi, lo, scale)
+	return whole<<FracBits | frac, nil
+}
+
+// expNegFrac returns exp(-f) for f in [0, 1] (Q32.32) by Taylor series
+func expNegFrac(f uint64) uint64 {
+	pos, neg := One, uint64(0)
+	term := One
+	for k := uint64(1); k <= expTerms; k++ {
+		term = mulQ(term, f) / k
+		if k%2 == 1 {
+			neg += term
+		} else {
+			pos += term
+		}
+	}
+	if neg >= pos {
+		return 0
+	}
+	return pos - neg
+}
+
+// expNegOne is exp(-1) in Q32.32
+var expNegOne = expNegFrac(One)
+
+// ExpNeg returns exp(-x) for a Q32.32 x as a Q32.32 probability
+func ExpNeg(x uint64) uint64 {
+	n := x >> FracBits
+	if n >= expCutoff {
+		return 0
+	}
+	result := expNegFrac(x & (One - 1))
+	for i := uint64(0); i < n; i++ {
+		result = mulQ(result, expNegOne)
+	}
+	return result
+}
+
+// AcceptThreshold returns exp(-delta/temperature) in Q32.32 for an
+// integer energy increase delta > 0
+func AcceptThreshold(delta int64, temperature uint64) uint64 {
+	if delta <= 0 {
+		return One
+	}
+	if temperature == 0 {
+		return 0
+	}
+	// x = delta / T in Q32.32 is (delta << 64) / T. If delta >= T the
+	// quotient overflows, but then x >= 2^32 and exp(-x) is zero anyway.
+	hi := uint64(delta)
+	if hi >= temperature {
+		return 0
+	}
+	x, _ := bits.Div64(hi, 0, temperature)
+	return ExpNeg(x)
+}
@@ -0,0 +1,103 @@
+package sa
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"math/bits"
+
+	"github.com/iden3/go-iden3-crypto/constants"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// Draws in the circuit PRG (docs/architecture/NOVA_CIRCUIT_DESIGN.md) are
+// separated by a domain index hashed with the advanced state
+const (
+	drawPosition = 0
+	drawFlip     = 1
+	drawAccept   = 2
+)
+
+// HashToField maps arbitrary bytes to a field element as SHA-256(data) mod q.
+// Job IDs and miner addresses enter the seed this way.
+func HashToField(data []byte) *big.Int {
+	digest := sha256.Sum256(data)
+	return BytesToField(digest[:])
+}
+
+// BytesToField reads big-endian bytes as an integer reduced mod q
+func BytesToField(data []byte) *big.Int {
+	v := new(big.Int).SetBytes(data)
+	return v.Mod(v, constants.Q)
+}
+
+// DeriveSeed returns a miner's starting seed for one epoch of a job:
+// poseidon([job_hash, epoch, miner_address, vrf_randomness])
+func DeriveSeed(jobId string, epoch uint64, miner string, vrfRandomness []byte) *big.Int {
+	return poseidonHash(
+		HashToField([]byte(jobId)),
+		new(big.Int).SetUint64(epoch),
+		HashToField([]byte(miner)),
+		BytesToField(vrfRandomness),
+	)
+}
+
+// PRG is the Poseidon generator of the nexus_sa_v1 circuit. Each step
+// advances the state as poseidon([state, step]) and draws the position,
+// flip and accept values as poseidon([state, 0|1|2]), so any step of a run
+// can be recomputed from the seed alone.
+type PRG struct {
+	state *big.Int
+}
+
+// NewPRG starts a generator at seed
+func NewPRG(seed *big.Int) *PRG {
+	return &PRG{state: new(big.Int).Set(seed)}
+}
+
+// State returns the current generator state
+func (g *PRG) State() *big.Int {
+	return new(big.Int).Set(g.state)
+}
+
+// NextBit draws one bit for the initial configuration (prg_next_bit): the
+// state becomes poseidon([state]) and the bit is its lowest bit
+func (g *PRG) NextBit() bool {
+	g.state = poseidonHash(g.state)
+	return g.state.Bit(0) == 1
+}
+
+// Step draws the randomness for one annealing step (prg_advance): the site
+// to flip from the position draw (prg_to_index) and a 32-bit uniform value
+// for the Metropolis test from the low bits of the accept draw.
+//
+// The flip draw picks the proposed value of multi-valued sites. An Ising
+// spin has a single alternative, so nexus_sa_v1 never consumes it; it does
+// not feed back into the state, so skipping it leaves later draws unchanged.
+func (g *PRG) Step(step uint64, n int) (site int, accept uint64) {
+	g.state = poseidonHash(g.state, new(big.Int).SetUint64(step))
+	position := poseidonHash(g.state, big.NewInt(drawPosition))
+	acceptRand := poseidonHash(g.state, big.NewInt(drawAccept))
+
+	return ToIndex(position, n), acceptRand.Uint64() & (One - 1)
+}
+
+// ToIndex maps a field element to [0, n) as the circuit's prg_to_index does:
+// the low ceil(log2(n)) bits, reduced modulo n
+func ToIndex(rand *big.Int, n int) int {
+	width := bits.Len(uint(n - 1))
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(width))
+	mask.Sub(mask, big.NewInt(1))
+	low := new(big.Int).And(rand, mask)
+	return int(low.Uint64() % uint64(n))
+}
+
+// poseidonHash hashes field elements with the circomlib Poseidon parameters
+// over the BN254 scalar field. Inputs are always reduced and at most four
+// wide, so the hash cannot fail.
+func poseidonHash(inputs ...*big.Int) *big.Int {
+	h, err := poseidon.Hash(inputs)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
@@ -0,0 +1,81 @@
+package sa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+// Problem is a dense Ising instance: n spins and an n x n coupling matrix of
+// signed bytes. Only the upper triangle (i < j) is used, so the encoding is
+// the same one GenerateIsingProblem writes into Job.ProblemData.
+type Problem struct {
+	N         int
+	Couplings []int8
+}
+
+// ProblemFromDense decodes Job.ProblemData (n*n bytes, row-major)
+func ProblemFromDense(data []byte) (*Problem, error) {
+	n := int(math.Sqrt(float64(len(data))))
+	for n*n > len(data) {
+		n--
+	}
+	for (n+1)*(n+1) <= len(data) {
+		n++
+	}
+	if n < 2 || n*n != len(data) {
+		return nil, fmt.Errorf("problem data length %d is not a square matrix", len(data))
+	}
+
+	couplings := make([]int8, len(data))
+	for i, b := range data {
+		couplings[i] = int8(b)
+	}
+	return &Problem{N: n, Couplings: couplings}, nil
+}
+
+// J returns the coupling between spins i and j (i != j)
+func (p *Problem) J(i, j int) int64 {
+	if i > j {
+		i, j = j, i
+	}
+	return int64(p.Couplings[i*p.N+j])
+}
+
+// Energy returns E = -sum_{i<j} J_ij * s_i * s_j
+func (p *Problem) Energy(spins []int8) int64 {
+	var e int64
+	for i := 0; i < p.N; i++ {
+		row := p.Couplings[i*p.N : (i+1)*p.N]
+		for j := i + 1; j < p.N; j++ {
+			e -= int64(row[j]) * int64(spins[i]) * int64(spins[j])
+		}
+	}
+	return e
+}
+
+// FlipDelta returns the energy change from flipping spin k:
+// dE = 2 * s_k * sum_j J_kj * s_j
+func (p *Problem) FlipDelta(spins []int8, k int) int64 {
+	var sum int64
+	for j := 0; j < p.N; j++ {
+		if j != k {
+			sum += p.J(k, j) * int64(spins[j])
+		}
+	}
+	return 2 * int64(spins[k]) * sum
+}
+
+// ConfigHash is the BestConfigHash reported in MsgSubmitWork: hex SHA-256
+// over one byte per spin (1 for +1, 0 for -1)
+func ConfigHash(spins []int8) string {
+	bz := make([]byte, len(spins))
+	for i, s := range spins {
+		if s > 0 {
+			bz[i] = 1
+		}
+	}
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:])
+}
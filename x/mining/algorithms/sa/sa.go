@@ -0,0 +1,159 @@
+// Package sa is the reference implementation of the nexus_sa_v1
+// collaborative mining algorithm: simulated annealing over Ising spins with
+// PRG-selected flips, fixed-point Metropolis acceptance and a geometric
+// temperature schedule.
+//
+// A run is fully determined by the problem, the seed (DeriveSeed) and the
+// algorithm parameters registered on chain, so its FinalEnergy, BestEnergy
+// and BestConfigHash can be cross-checked against any MsgSubmitWork.
+//
+// The seed and every draw come from the Poseidon PRG of the verifier
+// circuit (docs/architecture/NOVA_CIRCUIT_DESIGN.md), so the circuit can
+// recompute a run step by step.
+//
+// Run, per step i = 0..steps-1:
+//  1. site, r = PRG.Step(i, n)   (position and accept draws)
+//  2. dE = FlipDelta(spins, site)
+//  3. accept if dE <= 0 or r < exp(-dE / T)   (r and exp in Q32.32)
+//  4. on accept: flip the spin, update energy, track the best configuration
+//  5. T = max(T * decay, t_end)
+package sa
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"nexus/x/mining/types"
+)
+
+// AlgorithmID is the registry ID this package implements
+const AlgorithmID = types.DefaultCollaborativeAlgorithm
+
+// Params are the canonical annealing parameters in Q32.32 fixed point
+type Params struct {
+	TStart uint64
+	TEnd   uint64
+	Decay  uint64
+	Steps  uint64
+}
+
+// ParamsFromAlgorithm reads the registered parameters of nexus_sa_v1
+func ParamsFromAlgorithm(algo types.Algorithm) (Params, error) {
+	if algo.Id != AlgorithmID {
+		return Params{}, fmt.Errorf("algorithm %s is not %s", algo.Id, AlgorithmID)
+	}
+
+	var p Params
+	fixed := []struct {
+		key string
+		dst *uint64
+	}{{"t_start", &p.TStart}, {"t_end", &p.TEnd}, {"decay", &p.Decay}}
+	for _, f := range fixed {
+		value, found := algo.Param(f.key)
+		if !found {
+			return Params{}, fmt.Errorf("missing parameter %s", f.key)
+		}
+		v, err := ParseFixed(value)
+		if err != nil {
+			return Params{}, fmt.Errorf("parameter %s: %w", f.key, err)
+		}
+		*f.dst = v
+	}
+
+	steps, found := algo.Param("steps")
+	if !found {
+		return Params{}, fmt.Errorf("missing parameter steps")
+	}
+	n, err := strconv.ParseUint(steps, 10, 64)
+	if err != nil {
+		return Params{}, fmt.Errorf("parameter steps: %w", err)
+	}
+	p.Steps = n
+
+	if p.Decay == 0 || p.Decay > One || p.TEnd > p.TStart {
+		return Params{}, fmt.Errorf("invalid annealing schedule")
+	}
+	return p, nil
+}
+
+// DefaultParams returns the genesis parameters of nexus_sa_v1
+func DefaultParams() Params {
+	p, err := ParamsFromAlgorithm(types.DefaultAlgorithms()[0])
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Result is the outcome of a run, in the units MsgSubmitWork expects
+type Result struct {
+	NumSteps       uint64
+	FinalEnergy    int64
+	BestEnergy     int64
+	BestConfigHash string
+	FinalConfig    []int8
+	BestConfig     []int8
+}
+
+// InitialConfig draws the starting spins from the seed, one PRG bit per spin
+func InitialConfig(g *PRG, n int) []int8 {
+	spins := make([]int8, n)
+	for i := range spins {
+		if g.NextBit() {
+			spins[i] = 1
+		} else {
+			spins[i] = -1
+		}
+	}
+	return spins
+}
+
+// Run anneals problem from seed for steps steps (params.Steps if zero)
+func Run(problem *Problem, seed *big.Int, params Params, steps uint64) Result {
+	if steps == 0 {
+		steps = params.Steps
+	}
+
+	g := NewPRG(seed)
+	spins := InitialConfig(g, problem.N)
+	energy := problem.Energy(spins)
+
+	best := append([]int8(nil), spins...)
+	bestEnergy := energy
+	temperature := params.TStart
+
+	for i := uint64(0); i < steps; i++ {
+		site, r := g.Step(i, problem.N)
+		delta := problem.FlipDelta(spins, site)
+
+		if delta <= 0 || r < AcceptThreshold(delta, temperature) {
+			spins[site] = -spins[site]
+			energy += delta
+			if energy < bestEnergy {
+				bestEnergy = energy
+				copy(best, spins)
+			}
+		}
+
+		temperature = mulQ(temperature, params.Decay)
+		if temperature < params.TEnd {
+			temperature = params.TEnd
+		}
+	}
+
+	return Result{
+		NumSteps:       steps,
+		FinalEnergy:    energy,
+		BestEnergy:     bestEnergy,
+		BestConfigHash: ConfigHash(best),
+		FinalConfig:    spins,
+		BestConfig:     best,
+	}
+}
+
+// RunForJob runs a miner's assigned work unit for one epoch of a job.
+// vrfRandomness is the hex-decoded Job.VrfRandomness.
+func RunForJob(problem *Problem, jobId string, epoch uint64, miner string, vrfRandomness []byte, params Params, steps uint64) Result {
+	return Run(problem, DeriveSeed(jobId, epoch, miner, vrfRandomness), params, steps)
+}
@@ -0,0 +1,184 @@
+package sa
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// testProblem builds a dense n x n coupling matrix from a SHA-256 chain
+func testProblem(t *testing.T, n int) *Problem {
+	data := make([]byte, 0, n*n)
+	h := sha256.Sum256([]byte("nexus_sa_v1 test"))
+	for len(data) < n*n {
+		data = append(data, h[:]...)
+		h = sha256.Sum256(h[:])
+	}
+	problem, err := ProblemFromDense(data[:n*n])
+	if err != nil {
+		t.Fatalf("ProblemFromDense failed: %v", err)
+	}
+	return problem
+}
+
+func TestParseFixed(t *testing.T) {
+	cases := map[string]uint64{
+		"1":       One,
+		"0.5":     One / 2,
+		"10.0":    10 * One,
+		"0.01":    42949672,
+		"0.99995": 4294752547,
+	}
+	for in, want := range cases {
+		got, err := ParseFixed(in)
+		if err != nil {
+			t.Fatalf("ParseFixed(%q) failed: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("ParseFixed(%q) = %d, want %d", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "-1", "1e3", "0.5x"} {
+		if _, err := ParseFixed(in); err == nil {
+			t.Errorf("ParseFixed(%q) should fail", in)
+		}
+	}
+}
+
+func TestExpNeg(t *testing.T) {
+	if got := ExpNeg(0); got != One {
+		t.Errorf("exp(0) = %d, want %d", got, One)
+	}
+	// exp(-1) = 0.3678794412 -> 1580030168 in Q32.32
+	if got := ExpNeg(One); got < 1580030160 || got > 1580030170 {
+		t.Errorf("exp(-1) = %d, want ~1580030168", got)
+	}
+	if got := ExpNeg(expCutoff * One); got != 0 {
+		t.Errorf("exp(-32) = %d, want 0", got)
+	}
+	if got := AcceptThreshold(-5, One); got != One {
+		t.Errorf("Improving moves must always be accepted, got %d", got)
+	}
+	if got := AcceptThreshold(1<<40, One); got != 0 {
+		t.Errorf("Huge uphill moves must be rejected, got %d", got)
+	}
+}
+
+func TestDefaultParams(t *testing.T) {
+	p := DefaultParams()
+	if p.TStart != 10*One || p.TEnd != 42949672 || p.Decay != 4294752547 || p.Steps != 100000 {
+		t.Errorf("Unexpected default params: %+v", p)
+	}
+}
+
+func TestPRGMatchesCircuit(t *testing.T) {
+	hash := func(inputs ...*big.Int) *big.Int {
+		h, err := poseidon.Hash(inputs)
+		if err != nil {
+			t.Fatalf("poseidon failed: %v", err)
+		}
+		return h
+	}
+	vrf := []byte{0xff, 0xee, 0xdd}
+	jobHash := sha256.Sum256([]byte("job"))
+	minerHash := sha256.Sum256([]byte("miner"))
+
+	seed := DeriveSeed("job", 3, "miner", vrf)
+	want := hash(BytesToField(jobHash[:]), big.NewInt(3), BytesToField(minerHash[:]), new(big.Int).SetBytes(vrf))
+	if seed.Cmp(want) != 0 {
+		t.Fatalf("DeriveSeed = %s, want %s", seed, want)
+	}
+
+	// prg_next_bit: state = poseidon([state]), bit = lowest bit
+	g := NewPRG(seed)
+	state := hash(seed)
+	if bit := g.NextBit(); bit != (state.Bit(0) == 1) || g.State().Cmp(state) != 0 {
+		t.Fatal("NextBit does not follow prg_next_bit")
+	}
+
+	// prg_advance: position and accept draws are separate hashes of the new state
+	site, accept := g.Step(7, 12)
+	state = hash(state, big.NewInt(7))
+	position := hash(state, big.NewInt(0))
+	acceptRand := hash(state, big.NewInt(2))
+	if g.State().Cmp(state) != 0 {
+		t.Fatal("Step does not advance the state as poseidon([state, step])")
+	}
+	if want := int(new(big.Int).And(position, big.NewInt(15)).Uint64() % 12); site != want {
+		t.Errorf("site = %d, want %d", site, want)
+	}
+	if want := new(big.Int).And(acceptRand, big.NewInt(int64(One-1))).Uint64(); accept != want {
+		t.Errorf("accept = %d, want %d", accept, want)
+	}
+}
+
+func TestToIndex(t *testing.T) {
+	seen := make(map[int]bool)
+	for v := int64(0); v < 64; v++ {
+		i := ToIndex(big.NewInt(v), 12)
+		if i < 0 || i >= 12 {
+			t.Fatalf("ToIndex(%d, 12) = %d out of range", v, i)
+		}
+		seen[i] = true
+	}
+	if len(seen) != 12 {
+		t.Errorf("Expected every index reachable, got %v", seen)
+	}
+	if got := ToIndex(big.NewInt(12345), 1); got != 0 {
+		t.Errorf("ToIndex(_, 1) = %d, want 0", got)
+	}
+}
+
+func TestFlipDelta(t *testing.T) {
+	problem := testProblem(t, 12)
+	spins := InitialConfig(NewPRG(DeriveSeed("job", 0, "miner", nil)), problem.N)
+	for k := 0; k < problem.N; k++ {
+		before := problem.Energy(spins)
+		delta := problem.FlipDelta(spins, k)
+		spins[k] = -spins[k]
+		if after := problem.Energy(spins); after-before != delta {
+			t.Errorf("FlipDelta(%d) = %d, energy changed by %d", k, delta, after-before)
+		}
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	problem := testProblem(t, 16)
+	params := DefaultParams()
+	vrf := []byte{0x01, 0x02, 0x03}
+
+	a := RunForJob(problem, "paid_1_nexus1ab", 0, "nexus1miner", vrf, params, 5000)
+	b := RunForJob(problem, "paid_1_nexus1ab", 0, "nexus1miner", vrf, params, 5000)
+	if a.FinalEnergy != b.FinalEnergy || a.BestEnergy != b.BestEnergy || a.BestConfigHash != b.BestConfigHash {
+		t.Fatalf("Runs from the same seed diverged: %+v vs %+v", a, b)
+	}
+
+	if a.NumSteps != 5000 {
+		t.Errorf("Expected 5000 steps, got %d", a.NumSteps)
+	}
+	if got := problem.Energy(a.FinalConfig); got != a.FinalEnergy {
+		t.Errorf("FinalEnergy %d does not match final config energy %d", a.FinalEnergy, got)
+	}
+	if got := problem.Energy(a.BestConfig); got != a.BestEnergy {
+		t.Errorf("BestEnergy %d does not match best config energy %d", a.BestEnergy, got)
+	}
+	if a.BestEnergy > a.FinalEnergy {
+		t.Errorf("BestEnergy %d above FinalEnergy %d", a.BestEnergy, a.FinalEnergy)
+	}
+	if ConfigHash(a.BestConfig) != a.BestConfigHash || len(a.BestConfigHash) != 64 {
+		t.Errorf("Unexpected BestConfigHash %q", a.BestConfigHash)
+	}
+
+	initial := problem.Energy(InitialConfig(NewPRG(DeriveSeed("paid_1_nexus1ab", 0, "nexus1miner", vrf)), problem.N))
+	if a.BestEnergy >= initial {
+		t.Errorf("Annealing did not improve on the initial energy %d (best %d)", initial, a.BestEnergy)
+	}
+
+	// A different epoch reseeds the run
+	c := RunForJob(problem, "paid_1_nexus1ab", 1, "nexus1miner", vrf, params, 5000)
+	if c.BestConfigHash == a.BestConfigHash && c.FinalEnergy == a.FinalEnergy {
+		t.Error("Different epochs should produce different runs")
+	}
+}
@@ -3,9 +3,12 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/spf13/cobra"
 
 	"nexus/x/mining/types"
@@ -25,6 +28,24 @@ func GetQueryCmd() *cobra.Command {
 		CmdQueryActiveJob(),
 		CmdQueryEmissionInfo(),
 		CmdQueryJobs(),
+		CmdQueryMinerGroup(),
+		CmdQueryRandomness(),
+		CmdQueryRewardBreakdown(),
+		CmdQueryAlgorithm(),
+		CmdQueryAlgorithms(),
+		CmdQueryJobLandscape(),
+		CmdQuerySupplyProjection(),
+		CmdQueryEmissionAllocation(),
+		CmdQueryVestingGrants(),
+		CmdQueryValidatorMiningRecord(),
+		CmdQueryPool(),
+		CmdQueryPoolEarnings(),
+		CmdQueryTreasury(),
+		CmdQueryTreasurySpends(),
+		CmdQueryBurnStats(),
+		CmdQueryBaseFee(),
+		CmdQueryMinerRegistration(),
+		CmdQueryMinerRegistry(),
 	)
 
 	return cmd
@@ -169,3 +190,615 @@ func CmdQueryJobs() *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
+
+func CmdQueryMinerGroup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-miner-group [name]",
+		Short: "Get a miner group and its members",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			key := append(types.MinerGroupKeyPrefix, []byte(args[0])...)
+			res, _, err := clientCtx.QueryStore(key, types.StoreKey)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return fmt.Errorf("miner group %s not found", args[0])
+			}
+
+			var group types.MinerGroup
+			if err := clientCtx.Codec.Unmarshal(res, &group); err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(group, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryRandomness() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-randomness [height]",
+		Short: "Get the randomness beacon value for a block height",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			height, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			key := append(types.BlockRandomnessKeyPrefix, sdk.Uint64ToBigEndian(height)...)
+			res, _, err := clientCtx.QueryStore(key, types.StoreKey)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return fmt.Errorf("no randomness recorded for height %d", height)
+			}
+
+			var randomness types.BlockRandomness
+			if err := clientCtx.Codec.Unmarshal(res, &randomness); err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(randomness, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryRewardBreakdown() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-reward-breakdown [job-id] [miner]",
+		Short: "Show a miner's work, improvement and validator pool payout for a collaborative job",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.MinerRewardBreakdown(cmd.Context(), &types.QueryMinerRewardBreakdownRequest{
+				JobId: args[0],
+				Miner: args[1],
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryAlgorithm() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-algorithm [algorithm-id]",
+		Short: "Get a registered algorithm and its canonical parameters",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			key := append(types.AlgorithmKeyPrefix, []byte(args[0])...)
+			res, _, err := clientCtx.QueryStore(key, types.StoreKey)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return fmt.Errorf("algorithm %s not registered", args[0])
+			}
+
+			var algo types.Algorithm
+			if err := clientCtx.Codec.Unmarshal(res, &algo); err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(algo, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryAlgorithms() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-algorithms",
+		Short: "List registered collaborative mining algorithms",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			activeOnly, err := cmd.Flags().GetBool("active-only")
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Algorithms(cmd.Context(), &types.QueryAlgorithmsRequest{ActiveOnly: activeOnly})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res.Algorithms, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("active-only", false, "Only list active algorithms")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryJobLandscape() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-job-landscape [job-id]",
+		Short: "Get the solution landscape of a collaborative job: distinct minima and per-epoch statistics",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			epochOffset, err := cmd.Flags().GetUint64("epoch-offset")
+			if err != nil {
+				return err
+			}
+			epochLimit, err := cmd.Flags().GetUint64("epoch-limit")
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.JobLandscape(cmd.Context(), &types.QueryJobLandscapeRequest{
+				JobId:           args[0],
+				Pagination:      pageReq,
+				EpochPagination: &query.PageRequest{Offset: epochOffset, Limit: epochLimit},
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint64("epoch-offset", 0, "Offset into the job's epoch statistics")
+	cmd.Flags().Uint64("epoch-limit", 100, "Epoch statistics to return")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "get-job-landscape")
+	return cmd
+}
+
+func CmdQuerySupplyProjection() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-supply-projection",
+		Short: "Show emission minted to date, the supply cap and the projected total",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			horizon, err := cmd.Flags().GetInt64("horizon-minutes")
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.SupplyProjection(cmd.Context(), &types.QuerySupplyProjectionRequest{
+				HorizonMinutes: horizon,
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64("horizon-minutes", 0, "Minutes to project past the last emission (0 = end of the decay table, at most 1000 years)")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryEmissionAllocation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-emission-allocation",
+		Short: "Show how each minute of emission is split across workloads and their escrows",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.EmissionAllocation(cmd.Context(), &types.QueryEmissionAllocationRequest{})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryVestingGrants() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-vesting-grants [miner]",
+		Short: "Show a miner's vesting rewards, what is withdrawable now and what is still locked",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.VestingGrants(cmd.Context(), &types.QueryVestingGrantsRequest{
+				Miner: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryValidatorMiningRecord() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-validator-record [validator]",
+		Short: "Show the shares, rewards and completed jobs of miners linked to a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ValidatorMiningRecord(cmd.Context(), &types.QueryValidatorMiningRecordRequest{
+				Validator: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdCheckMiningInvariants runs the mining module invariants on a node and
+// fails if any of them is broken
+func CmdQueryPool() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-pool [pool-id]",
+		Short: "Show a mining pool, its members and share-holding address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Pool(cmd.Context(), &types.QueryPoolRequest{PoolId: args[0]})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryPoolEarnings() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-pool-earnings [pool-id]",
+		Short: "Show a mining pool's claimed earnings per member and unclaimed payouts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.PoolEarnings(cmd.Context(), &types.QueryPoolEarningsRequest{PoolId: args[0]})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdCheckMiningInvariants() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-mining-invariants",
+		Short: "Check mining module escrow, pool and share invariants",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Invariants(cmd.Context(), &types.QueryInvariantsRequest{})
+			if err != nil {
+				return err
+			}
+
+			for _, result := range res.Results {
+				status := "ok"
+				if result.Broken {
+					status = "BROKEN"
+				}
+				fmt.Printf("%-16s %s\n%s", result.Route, status, result.Message)
+			}
+			if res.Broken {
+				return fmt.Errorf("mining invariants broken")
+			}
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryTreasury() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-treasury",
+		Short: "Show the mining treasury balance, share and lifetime deposits and spends",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Treasury(cmd.Context(), &types.QueryTreasuryRequest{})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryTreasurySpends() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-treasury-spends",
+		Short: "List every governance spend paid out of the mining treasury",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.TreasurySpends(cmd.Context(), &types.QueryTreasurySpendsRequest{})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryBurnStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-burn-stats",
+		Short: "Show cumulative burned amounts by source (tx, job, priority and posting fees)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.BurnStats(cmd.Context(), &types.QueryBurnStatsRequest{})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryBaseFee() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-base-fee",
+		Short: "Show the current base fee (unexus per gas) and the fee market params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.BaseFee(cmd.Context(), &types.QueryBaseFeeRequest{})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryMinerRegistration() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-miner-registration [miner]",
+		Short: "Show a miner's registration, bond and unbonding status",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.MinerRegistration(cmd.Context(), &types.QueryMinerRegistrationRequest{Miner: args[0]})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryMinerRegistry() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-miners",
+		Short: "List registered miners",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			activeOnly, err := cmd.Flags().GetBool("active-only")
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.MinerRegistry(cmd.Context(), &types.QueryMinerRegistryRequest{
+				ActiveOnly: activeOnly,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			out, _ := json.MarshalIndent(res, "", "  ")
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("active-only", false, "Exclude miners that are unbonding")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list-miners")
+	return cmd
+}
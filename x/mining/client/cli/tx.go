@@ -2,6 +2,7 @@ package cli
 
 import (
 	"encoding/hex"
+	"fmt"
 	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -26,8 +27,22 @@ func GetTxCmd() *cobra.Command {
 		CmdPostJob(),
 		CmdSubmitProof(),
 		CmdClaimRewards(),
+		CmdClaimAllRewards(),
+		CmdWithdrawVested(),
+		CmdLinkValidator(),
+		CmdAcceptMiner(),
 		CmdCancelJob(),
+		CmdExtendJob(),
+		CmdSetMinerGroup(),
+		CmdCreatePool(),
+		CmdJoinPool(),
+		CmdLeavePool(),
+		CmdClaimPoolRewards(),
+		CmdCommitRandomness(),
+		CmdRevealRandomness(),
 		CmdSubmitPublicJob(),
+		CmdRegisterMiner(),
+		CmdUnbondMiner(),
 	)
 
 	return cmd
@@ -49,6 +64,18 @@ Example:
     1000000 \
     --priority-fee 100000 \
     --duration 86400 \
+    --from mykey
+
+Restrict the job to vetted miners with --allowlist and/or --miner-group:
+  nexusd tx mining post-job <problem-hash> -1000 1000000 \
+    --allowlist nexus1abc...,nexus1def... \
+    --miner-group lab-cluster \
+    --from mykey
+
+Post a collaborative job where miners share work instead of competing:
+  nexusd tx mining post-job <problem-hash> -1000 1000000 \
+    --mining-mode collaborative \
+    --algorithm-id nexus_sa_v1 \
     --from mykey`,
 		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -84,6 +111,35 @@ Example:
 				return err
 			}
 
+			allowlist, err := cmd.Flags().GetStringSlice("allowlist")
+			if err != nil {
+				return err
+			}
+
+			minerGroup, err := cmd.Flags().GetString("miner-group")
+			if err != nil {
+				return err
+			}
+
+			modeStr, err := cmd.Flags().GetString("mining-mode")
+			if err != nil {
+				return err
+			}
+			var miningMode types.MiningMode
+			switch modeStr {
+			case "competitive":
+				miningMode = types.MiningModeCompetitive
+			case "collaborative":
+				miningMode = types.MiningModeCollaborative
+			default:
+				return fmt.Errorf("invalid mining mode %q: use competitive or collaborative", modeStr)
+			}
+
+			algorithmId, err := cmd.Flags().GetString("algorithm-id")
+			if err != nil {
+				return err
+			}
+
 			msg := &types.MsgPostJob{
 				Customer:    clientCtx.GetFromAddress().String(),
 				ProblemType: "ising",
@@ -93,6 +149,10 @@ Example:
 				PriorityFee: sdk.NewCoins(sdk.NewInt64Coin("unexus", priorityFeeAmt)),
 				Duration:    duration,
 				QuantumSafe: quantumSafe,
+				Allowlist:   allowlist,
+				MinerGroup:  minerGroup,
+				MiningMode:  miningMode,
+				AlgorithmId: algorithmId,
 			}
 
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
@@ -102,6 +162,10 @@ Example:
 	cmd.Flags().Int64("priority-fee", 0, "Priority fee in unexus (higher = faster activation)")
 	cmd.Flags().Int64("duration", 86400, "Job duration in seconds (default: 24 hours)")
 	cmd.Flags().Bool("quantum-safe", false, "Require quantum-safe STARK proofs")
+	cmd.Flags().StringSlice("allowlist", nil, "Comma-separated miner addresses allowed to submit (default: open to all)")
+	cmd.Flags().String("miner-group", "", "Name of a miner group allowed to submit")
+	cmd.Flags().String("mining-mode", "competitive", "Mining mode: competitive or collaborative")
+	cmd.Flags().String("algorithm-id", "", "Algorithm for collaborative jobs (default: nexus_sa_v1)")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -123,7 +187,9 @@ Example:
     -1500 \
     deadbeef01020304 \
     --proof-type nova \
-    --from mykey`,
+    --from mykey
+
+Use --pool to submit on behalf of a mining pool you belong to.`,
 		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -150,6 +216,11 @@ Example:
 				return err
 			}
 
+			pool, err := cmd.Flags().GetString("pool")
+			if err != nil {
+				return err
+			}
+
 			msg := &types.MsgSubmitProof{
 				Miner:        clientCtx.GetFromAddress().String(),
 				JobId:        jobId,
@@ -157,6 +228,7 @@ Example:
 				Energy:       energy,
 				Proof:        proofBytes,
 				ProofType:    proofType,
+				Pool:         pool,
 			}
 
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
@@ -165,6 +237,7 @@ Example:
 
 	cmd.Flags().String("proof-type", "nova", "Proof type: nova or stark")
 	cmd.Flags().Int64("energy", 0, "Energy value of the solution (can be negative)")
+	cmd.Flags().String("pool", "", "Mining pool to submit on behalf of")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -206,6 +279,151 @@ Example:
 	return cmd
 }
 
+func CmdClaimAllRewards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-all-rewards",
+		Short: "Claim mining rewards for every settled job in one transaction",
+		Long: `Withdraw your settled rewards across jobs in a single transfer.
+
+Jobs that are still running are skipped. At most --limit jobs are withdrawn
+per transaction (default and maximum 100); run the command again if the
+response reports has_more.
+
+Example:
+  nexusd tx mining claim-all-rewards --from mykey
+  nexusd tx mining claim-all-rewards --job-ids paid_1_ab,paid_2_cd --from mykey`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			jobIds, err := cmd.Flags().GetStringSlice("job-ids")
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetUint32("limit")
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgClaimAllRewards{
+				Claimer: clientCtx.GetFromAddress().String(),
+				JobIds:  jobIds,
+				Limit:   limit,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().StringSlice("job-ids", nil, "Comma-separated job IDs to claim (default: all settled jobs)")
+	cmd.Flags().Uint32("limit", 0, "Maximum jobs to withdraw (0 = 100)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdWithdrawVested() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-vested",
+		Short: "Withdraw mining rewards that have finished vesting",
+		Long: `Release the unlocked part of your vesting mining rewards.
+
+When vesting_percent is set, that share of every payout unlocks linearly
+over vesting_duration. Locked rewards are forfeited if you are slashed for
+fraud.
+
+Example:
+  nexusd tx mining withdraw-vested --from mykey`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgWithdrawVested{
+				Miner: clientCtx.GetFromAddress().String(),
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdLinkValidator() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link-validator [validator]",
+		Short: "Attribute your mining work to a validator",
+		Long: `Link your miner to a validator operator address. Once the validator
+accepts you with accept-miner, your accepted submissions, completed jobs and
+reward payouts are recorded on the validator's mining record. Run without an
+address to remove the link.
+
+Example:
+  nexusd tx mining link-validator nexusvaloper1... --from mykey
+  nexusd tx mining link-validator --from mykey`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgLinkValidator{
+				Miner: clientCtx.GetFromAddress().String(),
+			}
+			if len(args) == 1 {
+				msg.Validator = args[0]
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdAcceptMiner() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accept-miner [miner]",
+		Short: "Accept a miner's work onto your validator's mining record",
+		Long: `Accept a miner that links to your validator with link-validator. Only
+accepted miners add to the validator's mining record; accepting before the
+miner links is allowed. Sign with the validator operator's key.
+
+Example:
+  nexusd tx mining accept-miner nexus1... --from validator
+  nexusd tx mining accept-miner nexus1... --revoke --from validator`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			revoke, _ := cmd.Flags().GetBool("revoke")
+
+			msg := &types.MsgAcceptMiner{
+				Validator: clientCtx.GetFromAddress().String(),
+				Miner:     args[0],
+				Revoke:    revoke,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Bool("revoke", false, "Withdraw a previous acceptance")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdCancelJob() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cancel-job [job-id]",
@@ -243,6 +461,289 @@ Example:
 	return cmd
 }
 
+func CmdExtendJob() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "extend-job [job-id] [additional-reward]",
+		Short: "Top up the reward and/or extend the deadline of a paid job",
+		Long: `Add reward to a paid job's escrow and/or push out its deadline.
+
+The same 2% job fee that applies to post-job is burned from the added reward.
+The deadline can only be extended on an active job, and never beyond the
+max job duration measured from the current block time.
+
+Example:
+  nexusd tx mining extend-job paid_12345_abcd1234 500000 \
+    --extension 3600 \
+    --from mykey`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			jobId := args[0]
+
+			rewardAmt, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			extension, err := cmd.Flags().GetInt64("extension")
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgExtendJob{
+				Customer:         clientCtx.GetFromAddress().String(),
+				JobId:            jobId,
+				AdditionalReward: sdk.NewCoins(sdk.NewInt64Coin("unexus", rewardAmt)),
+				Extension:        extension,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Int64("extension", 0, "Seconds to add to the job deadline")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdSetMinerGroup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-miner-group [name] [member-address]...",
+		Short: "Create or replace a named group of vetted miners",
+		Long: `Create a miner group, or replace the members of a group you own.
+
+Permissioned jobs can reference the group by name with post-job --miner-group
+so that only its members may submit proofs or work.
+
+Example:
+  nexusd tx mining set-miner-group lab-cluster nexus1abc... nexus1def... \
+    --from mykey`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgSetMinerGroup{
+				Owner:   clientCtx.GetFromAddress().String(),
+				Name:    args[0],
+				Members: args[1:],
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdCreatePool() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-pool [pool-id] [fee-percent] [member-address]...",
+		Short: "Create a mining pool that splits claims across its members",
+		Long: `Create a mining pool operated by the sender.
+
+Members submit with --pool and their shares are credited to the pool. When
+the pool claims a job, the operator keeps fee-percent and the rest is split
+across members by contribution (steps on collaborative jobs, accepted
+submissions on competitive jobs). With --open anyone may join-pool.
+
+Example:
+  nexusd tx mining create-pool lab-pool 5 nexus1abc... nexus1def... \
+    --from mykey`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			feePercent, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid fee percent: %w", err)
+			}
+
+			openJoin, err := cmd.Flags().GetBool("open")
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgCreatePool{
+				Operator:   clientCtx.GetFromAddress().String(),
+				PoolId:     args[0],
+				FeePercent: feePercent,
+				Members:    args[2:],
+				OpenJoin:   openJoin,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Bool("open", false, "Let anyone join the pool")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdJoinPool() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "join-pool [pool-id]",
+		Short: "Join an open mining pool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgJoinPool{
+				Member: clientCtx.GetFromAddress().String(),
+				PoolId: args[0],
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdLeavePool() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leave-pool [pool-id]",
+		Short: "Leave a mining pool; recorded contributions are still paid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgLeavePool{
+				Member: clientCtx.GetFromAddress().String(),
+				PoolId: args[0],
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdClaimPoolRewards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-pool-rewards [pool-id] [job-id]",
+		Short: "Claim a pool's payout on a job and split it across members",
+		Long: `Withdraw a mining pool's settled payout on a job. The operator receives
+the pool fee and each contributing member is paid their share directly.
+Any member or the operator may claim.
+
+Example:
+  nexusd tx mining claim-pool-rewards lab-pool paid_12345_abcd1234 --from mykey`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgClaimPoolRewards{
+				Claimer: clientCtx.GetFromAddress().String(),
+				PoolId:  args[0],
+				JobId:   args[1],
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdCommitRandomness() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit-randomness [commitment-hex]",
+		Short: "Commit to a randomness beacon secret (bonded validators only)",
+		Long: `Commit to a 32-byte secret for the randomness beacon.
+
+The commitment is the hex sha256 of the secret. Reveal it in a later block
+with reveal-randomness.
+
+Example:
+  SECRET=$(openssl rand -hex 32)
+  nexusd tx mining commit-randomness $(echo -n $SECRET | xxd -r -p | sha256sum | cut -d' ' -f1) \
+    --from validator`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgCommitRandomness{
+				Validator:  clientCtx.GetFromAddress().String(),
+				Commitment: args[0],
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdRevealRandomness() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reveal-randomness [secret-hex]",
+		Short: "Reveal a committed randomness beacon secret",
+		Long: `Reveal the secret behind an earlier commit-randomness.
+
+Use --next-commitment to commit the next secret in the same transaction.
+
+Example:
+  nexusd tx mining reveal-randomness $SECRET \
+    --next-commitment <next-commitment-hex> \
+    --from validator`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			nextCommitment, err := cmd.Flags().GetString("next-commitment")
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgRevealRandomness{
+				Validator:      clientCtx.GetFromAddress().String(),
+				Secret:         args[0],
+				NextCommitment: nextCommitment,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String("next-commitment", "", "Commitment for the next secret (hex sha256)")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
 func CmdSubmitPublicJob() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "submit-public-job [title] [category] [problem-hash] [threshold] [ipfs-cid]",
@@ -320,3 +821,93 @@ Example:
 
 	return cmd
 }
+
+func CmdRegisterMiner() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-miner [bond-amount]",
+		Short: "Register as a miner or top up an existing registration",
+		Long: `Bond unexus to register the sender as a miner.
+
+While registration is required, only miners with an active registration can
+submit proofs, submit work or claim docking jobs. Registering again adds to
+the bond and replaces the metadata, hardware class and payout address.
+Liquid rewards and vested withdrawals go to the payout address if set.
+
+Example:
+  nexusd tx mining register-miner 100000000 \
+    --hardware-class gpu \
+    --metadata "rig-01, 4x RTX 4090" \
+    --payout-address nexus1abc... \
+    --from mykey`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bondAmt, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			metadata, err := cmd.Flags().GetString("metadata")
+			if err != nil {
+				return err
+			}
+
+			hardwareClass, err := cmd.Flags().GetString("hardware-class")
+			if err != nil {
+				return err
+			}
+
+			payoutAddress, err := cmd.Flags().GetString("payout-address")
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgRegisterMiner{
+				Miner:         clientCtx.GetFromAddress().String(),
+				Bond:          sdk.NewCoins(sdk.NewInt64Coin("unexus", bondAmt)),
+				Metadata:      metadata,
+				HardwareClass: hardwareClass,
+				PayoutAddress: payoutAddress,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String("metadata", "", "Free-form description of the miner")
+	cmd.Flags().String("hardware-class", "", "Hardware class: cpu, gpu, fpga or asic")
+	cmd.Flags().String("payout-address", "", "Address that receives rewards (defaults to the miner)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdUnbondMiner() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbond-miner",
+		Short: "Deregister as a miner and start unbonding the bond",
+		Long: `Stop mining immediately and return the bond after the unbonding period.
+
+Example:
+  nexusd tx mining unbond-miner --from mykey`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgUnbondMiner{
+				Miner: clientCtx.GetFromAddress().String(),
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
@@ -5,11 +5,17 @@ import (
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"nexus/x/mining/types"
 )
 
 func (k Keeper) BeginBlocker(ctx sdk.Context) error {
+	// 0. Slash expired beacon commitments, then fix this block's beacon
+	// value before anything consumes randomness
+	k.PenalizeUnrevealedCommitments(ctx)
+	k.RecordBlockRandomness(ctx)
+
 	// 1. Process emissions (accumulate tokens every minute into escrow)
 	if err := k.ProcessEmissions(ctx); err != nil {
 		k.Logger(ctx).Error("Failed to process emissions", "error", err)
@@ -20,6 +26,9 @@ func (k Keeper) BeginBlocker(ctx sdk.Context) error {
 	k.Logger(ctx).Info("BeginBlocker called", "height", ctx.BlockHeight())
 	k.CheckAndGenerateBackgroundJob(ctx)
 
+	// 3. Roll the active collaborative job to a new epoch when due
+	k.AdvanceCollaborativeEpoch(ctx)
+
 	return nil
 }
 
@@ -27,12 +36,28 @@ func (k Keeper) EndBlocker(ctx sdk.Context) error {
 	params := k.GetParams(ctx)
 	height := ctx.BlockHeight()
 
+	// Close the docking epoch and pay its miners from the emission escrow when due
+	if err := k.ProcessDockingEpoch(ctx); err != nil {
+		k.Logger(ctx).Error("Failed to process docking epoch", "error", err)
+	}
+
+	// Return the bonds of miners whose unbonding period has ended
+	k.ProcessMinerUnbonding(ctx)
+
+	// Move the base fee toward the gas target for the next block
+	var gasUsed uint64
+	if meter := ctx.BlockGasMeter(); meter != nil {
+		gasUsed = meter.GasConsumed()
+	}
+	k.UpdateBaseFee(ctx, gasUsed)
+
 	// Create checkpoint and distribute validator rewards every CheckpointInterval blocks
 	if height > 0 && height%params.CheckpointInterval == 0 {
 		k.createCheckpointAndDistribute(ctx, height, params)
 	}
 
-	return nil
+	// Report broken invariants, halting only if governance asked for it
+	return k.AssertInvariants(ctx, params)
 }
 
 func (k Keeper) createCheckpointAndDistribute(ctx sdk.Context, height int64, params types.Params) {
@@ -73,7 +98,7 @@ func (k Keeper) createCheckpointAndDistribute(ctx sdk.Context, height int64, par
 	)
 
 	// Distribute validator rewards if there's anything to distribute
-	if rewardPool > 0 {
+	if rewardPool.IsPositive() {
 		distributed, err := k.distributeValidatorRewardsInternal(ctx, rewardPool)
 		if err != nil {
 			k.Logger(ctx).Error("Failed to distribute validator rewards", "error", err)
@@ -90,104 +115,107 @@ func (k Keeper) createCheckpointAndDistribute(ctx sdk.Context, height int64, par
 			"checkpoint_created",
 			sdk.NewAttribute("checkpoint_id", fmt.Sprintf("%d", newID)),
 			sdk.NewAttribute("height", fmt.Sprintf("%d", height)),
-			sdk.NewAttribute("validator_rewards_distributed", fmt.Sprintf("%d", rewardPool)),
-			sdk.NewAttribute("emission_escrow", fmt.Sprintf("%d", emissionEscrow)),
+			sdk.NewAttribute("validator_rewards_distributed", rewardPool.String()),
+			sdk.NewAttribute("emission_escrow", emissionEscrow.String()),
 		),
 	)
 }
 
-// distributeValidatorRewardsInternal distributes the reward pool to bonded validators
-// proportional to their stake. Returns total amount distributed.
-func (k Keeper) distributeValidatorRewardsInternal(ctx sdk.Context, rewardPool int64) (int64, error) {
-	if k.stakingKeeper == nil || k.bankKeeper == nil {
-		k.Logger(ctx).Info("Staking/bank keeper not available, skipping validator distribution")
-		k.SetValidatorRewardPool(ctx, 0)
-		return 0, nil
+// distributeValidatorRewardsInternal allocates the reward pool to bonded
+// validators through x/distribution, proportional to their stake. Each share
+// is split between commission and delegators like block fees. Undistributed
+// dust stays in the pool for the next checkpoint. Returns total distributed.
+func (k Keeper) distributeValidatorRewardsInternal(ctx sdk.Context, rewardPool math.Int) (math.Int, error) {
+	if k.stakingKeeper == nil || k.bankKeeper == nil || k.distrKeeper == nil {
+		k.Logger(ctx).Info("Staking/bank/distribution keeper not available, skipping validator distribution")
+		k.SetValidatorRewardPool(ctx, math.ZeroInt())
+		return math.ZeroInt(), nil
 	}
 
 	totalBonded, err := k.stakingKeeper.TotalBondedTokens(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get total bonded tokens: %w", err)
+		return math.ZeroInt(), fmt.Errorf("failed to get total bonded tokens: %w", err)
 	}
 
 	if totalBonded.IsZero() {
-		k.Logger(ctx).Info("No bonded tokens, clearing validator reward pool")
-		k.SetValidatorRewardPool(ctx, 0)
-		return 0, nil
+		k.Logger(ctx).Info("No bonded tokens, keeping validator reward pool")
+		return math.ZeroInt(), nil
 	}
 
-	var totalDistributed int64
-	rewardPoolInt := math.NewInt(rewardPool)
+	totalDistributed := math.ZeroInt()
 
-	// Iterate through all bonded validators and distribute proportionally
+	// Iterate through all bonded validators and allocate proportionally
 	err = k.stakingKeeper.IterateBondedValidatorsByPower(ctx, func(index int64, validator stakingtypes.ValidatorI) (stop bool) {
 		valTokens := validator.GetBondedTokens()
 		if valTokens.IsZero() {
 			return false
 		}
 
-		share := valTokens.Mul(rewardPoolInt).Quo(totalBonded)
+		share := valTokens.Mul(rewardPool).Quo(totalBonded)
 		if share.IsZero() {
 			return false
 		}
 
-		shareAmount := share.Int64()
 		valAddrStr := validator.GetOperator()
+		rewardCoins := sdk.NewCoins(sdk.NewCoin("unexus", share))
 
-		valAddr, addrErr := sdk.ValAddressFromBech32(valAddrStr)
-		if addrErr != nil {
-			k.Logger(ctx).Error("Invalid validator address", "address", valAddrStr, "error", addrErr)
+		// Move the coins and record the allocation together or not at all
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(cacheCtx, types.ModuleName, distrtypes.ModuleName, rewardCoins); err != nil {
+			k.Logger(ctx).Error("Failed to send validator reward",
+				"validator", valAddrStr,
+				"amount", share.String(),
+				"error", err,
+			)
 			return false
 		}
-		accAddr := sdk.AccAddress(valAddr)
-
-		rewardCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", shareAmount))
-		sendErr := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, accAddr, rewardCoins)
-		if sendErr != nil {
-			k.Logger(ctx).Error("Failed to send validator reward",
+		if err := k.distrKeeper.AllocateTokensToValidator(cacheCtx, validator, sdk.NewDecCoinsFromCoins(rewardCoins...)); err != nil {
+			k.Logger(ctx).Error("Failed to allocate validator reward",
 				"validator", valAddrStr,
-				"amount", shareAmount,
-				"error", sendErr,
+				"amount", share.String(),
+				"error", err,
 			)
 			return false
 		}
+		write()
 
-		totalDistributed += shareAmount
+		totalDistributed = totalDistributed.Add(share)
 
-		k.Logger(ctx).Debug("Distributed validator reward",
+		k.Logger(ctx).Debug("Allocated validator reward",
 			"validator", valAddrStr,
 			"tokens", valTokens.String(),
-			"share", shareAmount,
+			"share", share.String(),
 		)
 
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
 				"validator_reward_paid",
 				sdk.NewAttribute("validator", valAddrStr),
-				sdk.NewAttribute("amount", fmt.Sprintf("%d", shareAmount)),
+				sdk.NewAttribute("amount", share.String()),
 			),
 		)
 
 		return false
 	})
 
+	// Whatever was not allocated (rounding dust, failed sends) carries over
+	remainder := rewardPool.Sub(totalDistributed)
+	k.SetValidatorRewardPool(ctx, remainder)
+
 	if err != nil {
 		return totalDistributed, fmt.Errorf("error iterating validators: %w", err)
 	}
 
-	k.SetValidatorRewardPool(ctx, 0)
-
-	remainder := rewardPool - totalDistributed
-	if remainder > 0 {
-		k.Logger(ctx).Debug("Reward distribution remainder", "remainder", remainder)
+	if remainder.IsPositive() {
+		k.Logger(ctx).Debug("Reward distribution remainder", "remainder", remainder.String())
 	}
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			"validator_rewards_distributed",
-			sdk.NewAttribute("total_pool", fmt.Sprintf("%d", rewardPool)),
-			sdk.NewAttribute("total_distributed", fmt.Sprintf("%d", totalDistributed)),
-			sdk.NewAttribute("remainder", fmt.Sprintf("%d", remainder)),
+			sdk.NewAttribute("total_pool", rewardPool.String()),
+			sdk.NewAttribute("total_distributed", totalDistributed.String()),
+			sdk.NewAttribute("remainder", remainder.String()),
 		),
 	)
 
@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// GetAuthority returns the address allowed to execute governance messages
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetAlgorithm returns a registered algorithm
+func (k Keeper) GetAlgorithm(ctx sdk.Context, id string) (types.Algorithm, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.AlgorithmKeyPrefix, []byte(id)...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.Algorithm{}, false
+	}
+	var algo types.Algorithm
+	k.cdc.MustUnmarshal(bz, &algo)
+	return algo, true
+}
+
+// SetAlgorithm stores an algorithm under its ID
+func (k Keeper) SetAlgorithm(ctx sdk.Context, algo types.Algorithm) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.AlgorithmKeyPrefix, []byte(algo.Id)...)
+	bz := k.cdc.MustMarshal(&algo)
+	store.Set(key, bz)
+}
+
+// IterateAlgorithms iterates over all registered algorithms
+func (k Keeper) IterateAlgorithms(ctx sdk.Context, fn func(algo types.Algorithm) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.AlgorithmKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var algo types.Algorithm
+		k.cdc.MustUnmarshal(iterator.Value(), &algo)
+		if fn(algo) {
+			break
+		}
+	}
+}
+
+// GetActiveAlgorithm returns a registered algorithm that is currently active
+func (k Keeper) GetActiveAlgorithm(ctx sdk.Context, id string) (types.Algorithm, error) {
+	algo, found := k.GetAlgorithm(ctx, id)
+	if !found {
+		return types.Algorithm{}, errorsmod.Wrapf(types.ErrAlgorithmNotFound, "%q", id)
+	}
+	if !algo.IsActive() {
+		return types.Algorithm{}, errorsmod.Wrapf(types.ErrAlgorithmInactive, "%q", id)
+	}
+	return algo, nil
+}
+
+// SetAlgorithm registers or updates an algorithm (governance only)
+func (k msgServer) SetAlgorithm(goCtx context.Context, msg *types.MsgSetAlgorithm) (*types.MsgSetAlgorithmResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Authority != k.authority {
+		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	algo := msg.Algorithm
+	algo.UpdatedAt = ctx.BlockTime().Unix()
+	k.Keeper.SetAlgorithm(ctx, algo)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"algorithm_set",
+			sdk.NewAttribute("algorithm_id", algo.Id),
+			sdk.NewAttribute("status", fmt.Sprintf("%d", algo.Status)),
+			sdk.NewAttribute("verifier_key_hash", algo.VerifierKeyHash),
+		),
+	)
+
+	return &types.MsgSetAlgorithmResponse{}, nil
+}
+
+// Algorithm returns a registered algorithm with its canonical parameters
+func (q queryServer) Algorithm(goCtx context.Context, req *types.QueryAlgorithmRequest) (*types.QueryAlgorithmResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	algo, found := q.Keeper.GetAlgorithm(ctx, req.Id)
+	if !found {
+		return nil, types.ErrAlgorithmNotFound
+	}
+	return &types.QueryAlgorithmResponse{Algorithm: algo}, nil
+}
+
+// Algorithms lists registered algorithms
+func (q queryServer) Algorithms(goCtx context.Context, req *types.QueryAlgorithmsRequest) (*types.QueryAlgorithmsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	algos := []types.Algorithm{}
+	q.Keeper.IterateAlgorithms(ctx, func(algo types.Algorithm) bool {
+		if !req.ActiveOnly || algo.IsActive() {
+			algos = append(algos, algo)
+		}
+		return false
+	})
+	return &types.QueryAlgorithmsResponse{Algorithms: algos}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"nexus/x/mining/keeper"
+	"nexus/x/mining/types"
 )
 
 // ============================================
@@ -70,7 +71,7 @@ func TestAuditProblemSizeBounds(t *testing.T) {
 func TestAuditEmissionRatesByEpoch(t *testing.T) {
 	t.Log("=== ECONOMICS: Emission Rates By Epoch ===")
 
-	epochs := keeper.GetEmissionEpochs()
+	epochs := keeper.GetEmissionEpochs(types.DefaultParams())
 
 	// Check we have all epochs
 	if len(epochs) < 7 {
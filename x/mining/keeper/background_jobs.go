@@ -4,8 +4,11 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
 )
@@ -292,19 +295,22 @@ func (k Keeper) AddToPublicJobQueue(ctx sdk.Context, jobID string) {
 	k.SetPublicJobQueue(ctx, queue)
 }
 
-// SelectRandomFromQueue selects a random job from queue using block hash as randomness
-// Returns job ID and removes it from queue
+// SelectRandomFromQueue selects a random job from queue using the randomness beacon
+// Returns job ID and removes it from queue, or "" when the queue is empty or
+// the block's beacon value is not usable
 func (k Keeper) SelectRandomFromQueue(ctx sdk.Context) string {
 	queue := k.GetPublicJobQueue(ctx)
 	if len(queue) == 0 {
 		return ""
 	}
 
-	// Use block height + timestamp as deterministic randomness source
-	// This is predictable but fair - no one can game it easily
-	seed := fmt.Sprintf("%d:%d", ctx.BlockHeight(), ctx.BlockTime().UnixNano())
-	hash := sha256.Sum256([]byte(seed))
-	
+	// Use the beacon value fixed at the start of this block; the proposer
+	// cannot grind it the way it could block time
+	hash, usable := k.CurrentRandomness(ctx)
+	if !usable {
+		return ""
+	}
+
 	// Convert first 8 bytes of hash to uint64 for random index
 	randomValue := binary.BigEndian.Uint64(hash[:8])
 	randomIndex := int(randomValue % uint64(len(queue)))
@@ -422,13 +428,14 @@ func (k Keeper) GenerateSyntheticBackgroundJob(ctx sdk.Context) (*types.Job, err
 		ProblemData:  problemData,
 		ProblemHash:  problemHash,
 		Threshold:    threshold,
-		Reward:       0,
+		Reward:       math.ZeroInt(),
 		Status:       types.JobStatusActive,
 		BestEnergy:   0,
 		TotalShares:  0,
 		CreatedAt:    timestamp,
 		Deadline:     timestamp + DefaultBackgroundJobDuration,
 		IsBackground: true,
+		MiningMode:   types.MiningModeCompetitive,
 	}
 
 	k.SetJob(ctx, job)
@@ -454,6 +461,13 @@ func (k Keeper) GenerateSyntheticBackgroundJob(ctx sdk.Context) (*types.Job, err
 }
 
 func (k Keeper) ActivateRandomPublicJob(ctx sdk.Context) (*types.Job, error) {
+	if k.GetPublicJobQueueLength(ctx) == 0 {
+		return nil, nil
+	}
+	if _, usable := k.CurrentRandomness(ctx); !usable {
+		return nil, errorsmod.Wrap(types.ErrRandomnessNotFound, "public job selection waits for a usable beacon value")
+	}
+
 	jobID := k.SelectRandomFromQueue(ctx)
 	if jobID == "" {
 		return nil, nil
@@ -467,6 +481,11 @@ func (k Keeper) ActivateRandomPublicJob(ctx sdk.Context) (*types.Job, error) {
 	job.Status = types.JobStatusActive
 	job.CreatedAt = ctx.BlockTime().Unix()
 	job.Deadline = ctx.BlockTime().Unix() + DefaultBackgroundJobDuration
+	if job.MiningMode == types.MiningModeCollaborative {
+		if err := k.StartCollaborativeEpoch(ctx, &job, 0); err != nil {
+			return nil, err
+		}
+	}
 	k.SetJob(ctx, job)
 	k.SetCurrentJobID(ctx, jobID)
 	k.IncrementActiveJobCount(ctx)
@@ -512,8 +531,15 @@ func (k Keeper) CheckAndGenerateBackgroundJob(ctx sdk.Context) {
 		}
 	}
 
+	// Queued jobs that need randomness wait for a usable beacon value rather
+	// than being displaced by a synthetic job
+
 	// Priority 1: Try to activate highest priority PAID job
 	paidJob, err := k.ActivateNextPaidJob(ctx)
+	if errors.Is(err, types.ErrRandomnessNotFound) {
+		k.Logger(ctx).Info("Paid job waiting for randomness", "reason", err)
+		return
+	}
 	if err != nil {
 		k.Logger(ctx).Error("Failed to activate paid job", "error", err)
 	}
@@ -523,6 +549,10 @@ func (k Keeper) CheckAndGenerateBackgroundJob(ctx sdk.Context) {
 
 	// Priority 2: Try to activate random PUBLIC job
 	publicJob, err := k.ActivateRandomPublicJob(ctx)
+	if errors.Is(err, types.ErrRandomnessNotFound) {
+		k.Logger(ctx).Info("Public job waiting for randomness", "reason", err)
+		return
+	}
 	if err != nil {
 		k.Logger(ctx).Error("Failed to activate public job", "error", err)
 	}
@@ -537,21 +567,30 @@ func (k Keeper) CheckAndGenerateBackgroundJob(ctx sdk.Context) {
 	}
 }
 
-// ActivateNextPaidJob activates the highest priority paid job from queue
+// ActivateNextPaidJob activates the highest priority paid job from queue.
+// A collaborative job stays queued while the beacon value is not usable.
 func (k Keeper) ActivateNextPaidJob(ctx sdk.Context) (*types.Job, error) {
-	jobID := k.PopFromPaidJobQueue(ctx)
-	if jobID == "" {
+	queue := k.GetPaidJobQueue(ctx)
+	if len(queue) == 0 {
 		return nil, nil
 	}
 
+	jobID := queue[0].JobID
 	job, found := k.GetJob(ctx, jobID)
 	if !found {
+		k.PopFromPaidJobQueue(ctx)
 		return nil, fmt.Errorf("paid job not found: %s", jobID)
 	}
 
 	job.Status = types.JobStatusActive
 	job.CreatedAt = ctx.BlockTime().Unix()
 	job.Deadline = ctx.BlockTime().Unix() + DefaultBackgroundJobDuration
+	if job.MiningMode == types.MiningModeCollaborative {
+		if err := k.StartCollaborativeEpoch(ctx, &job, 0); err != nil {
+			return nil, err
+		}
+	}
+	k.PopFromPaidJobQueue(ctx)
 	k.SetJob(ctx, job)
 	k.SetCurrentJobID(ctx, jobID)
 	k.IncrementActiveJobCount(ctx)
@@ -568,7 +607,7 @@ func (k Keeper) ActivateNextPaidJob(ctx sdk.Context) (*types.Job, error) {
 			"paid_job_activated",
 			sdk.NewAttribute("job_id", jobID),
 			sdk.NewAttribute("customer", job.Customer),
-			sdk.NewAttribute("priority_fee", fmt.Sprintf("%d", job.PriorityFee)),
+			sdk.NewAttribute("priority_fee", job.PriorityFee.String()),
 		),
 	)
 
@@ -581,9 +620,14 @@ func (k Keeper) ExpireJob(ctx sdk.Context, jobID string) {
 	}
 
 	job.Status = types.JobStatusExpired
+	job.FinishedAt = ctx.BlockTime().Unix()
 	k.SetJob(ctx, job)
 	k.DecrementActiveJobCount(ctx)
 
+	if err := k.SettleJob(ctx, &job); err != nil {
+		k.Logger(ctx).Error("Failed to settle expired job", "job_id", jobID, "error", err)
+	}
+
 	if k.GetCurrentJobID(ctx) == jobID {
 		k.SetCurrentJobID(ctx, "")
 	}
@@ -607,9 +651,14 @@ func (k Keeper) OnJobSolved(ctx sdk.Context, jobID string, solverAddr string, so
 	solveTime := ctx.BlockTime().Unix() - job.CreatedAt
 
 	job.Status = types.JobStatusCompleted
+	job.FinishedAt = ctx.BlockTime().Unix()
 	k.SetJob(ctx, job)
 	k.DecrementActiveJobCount(ctx)
 
+	if err := k.SettleJob(ctx, &job); err != nil {
+		k.Logger(ctx).Error("Failed to settle solved job", "job_id", jobID, "error", err)
+	}
+
 	if k.GetCurrentJobID(ctx) == jobID {
 		k.SetCurrentJobID(ctx, "")
 	}
@@ -663,7 +712,7 @@ var PaidJobQueueKey = []byte("paid_job_queue")
 // PaidJobEntry stores job ID with its priority fee for sorting
 type PaidJobEntry struct {
 	JobID       string
-	PriorityFee int64
+	PriorityFee math.Int
 	SubmitTime  int64
 }
 
@@ -675,15 +724,24 @@ func (k Keeper) GetPaidJobQueue(ctx sdk.Context) []PaidJobEntry {
 		return []PaidJobEntry{}
 	}
 
-	// Decode: each entry is 8 bytes priorityFee + 8 bytes submitTime + 4 bytes jobID length + jobID
+	// Decode: each entry is 4 bytes priorityFee length + priorityFee (math.Int) +
+	// 8 bytes submitTime + 4 bytes jobID length + jobID
 	queue := []PaidJobEntry{}
 	offset := 0
 	for offset < len(bz) {
-		if offset+20 > len(bz) {
+		if offset+4 > len(bz) {
 			break
 		}
-		priorityFee := int64(binary.BigEndian.Uint64(bz[offset : offset+8]))
-		offset += 8
+		feeLen := int(binary.BigEndian.Uint32(bz[offset : offset+4]))
+		offset += 4
+		if offset+feeLen+12 > len(bz) {
+			break
+		}
+		var priorityFee math.Int
+		if err := priorityFee.Unmarshal(bz[offset : offset+feeLen]); err != nil {
+			break
+		}
+		offset += feeLen
 		submitTime := int64(binary.BigEndian.Uint64(bz[offset : offset+8]))
 		offset += 8
 		idLen := int(binary.BigEndian.Uint32(bz[offset : offset+4]))
@@ -702,16 +760,24 @@ func (k Keeper) GetPaidJobQueue(ctx sdk.Context) []PaidJobEntry {
 func (k Keeper) SetPaidJobQueue(ctx sdk.Context, queue []PaidJobEntry) {
 	store := ctx.KVStore(k.storeKey)
 
+	fees := make([][]byte, len(queue))
 	totalSize := 0
-	for _, entry := range queue {
-		totalSize += 8 + 8 + 4 + len(entry.JobID)
+	for i, entry := range queue {
+		fee, err := entry.PriorityFee.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		fees[i] = fee
+		totalSize += 4 + len(fee) + 8 + 4 + len(entry.JobID)
 	}
 
 	bz := make([]byte, totalSize)
 	offset := 0
-	for _, entry := range queue {
-		binary.BigEndian.PutUint64(bz[offset:offset+8], uint64(entry.PriorityFee))
-		offset += 8
+	for i, entry := range queue {
+		binary.BigEndian.PutUint32(bz[offset:offset+4], uint32(len(fees[i])))
+		offset += 4
+		copy(bz[offset:], fees[i])
+		offset += len(fees[i])
 		binary.BigEndian.PutUint64(bz[offset:offset+8], uint64(entry.SubmitTime))
 		offset += 8
 		binary.BigEndian.PutUint32(bz[offset:offset+4], uint32(len(entry.JobID)))
@@ -724,7 +790,7 @@ func (k Keeper) SetPaidJobQueue(ctx sdk.Context, queue []PaidJobEntry) {
 }
 
 // AddToPaidJobQueue adds a job to the paid queue in sorted position
-func (k Keeper) AddToPaidJobQueue(ctx sdk.Context, jobID string, priorityFee int64) int64 {
+func (k Keeper) AddToPaidJobQueue(ctx sdk.Context, jobID string, priorityFee math.Int) int64 {
 	queue := k.GetPaidJobQueue(ctx)
 	submitTime := ctx.BlockTime().Unix()
 
@@ -733,10 +799,10 @@ func (k Keeper) AddToPaidJobQueue(ctx sdk.Context, jobID string, priorityFee int
 	// Find insertion point (sorted by priority fee desc, then submit time asc)
 	insertIdx := len(queue)
 	for i, entry := range queue {
-		if priorityFee > entry.PriorityFee {
+		if priorityFee.GT(entry.PriorityFee) {
 			insertIdx = i
 			break
-		} else if priorityFee == entry.PriorityFee && submitTime < entry.SubmitTime {
+		} else if priorityFee.Equal(entry.PriorityFee) && submitTime < entry.SubmitTime {
 			insertIdx = i
 			break
 		}
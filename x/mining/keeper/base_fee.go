@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// BASE FEE MARKET
+// ============================================
+//
+// An EIP-1559 style base fee, in unexus per gas, is kept in state. Every
+// transaction must pay at least base fee x gas limit; the ante handler burns
+// that part and leaves the rest (the tip) with the fee collector. At the end
+// of each block the base fee moves toward the gas target:
+//
+//   next = base + base * (gas used - target) / target / change denominator
+//
+// with gas used capped at twice the target, never dropping below
+// min_base_fee. A zero target or denominator freezes it.
+
+// BaseFeeKey holds the current base fee
+var BaseFeeKey = []byte("base_fee")
+
+// GetBaseFee returns the current base fee, the params floor if never set
+func (k Keeper) GetBaseFee(ctx sdk.Context) math.LegacyDec {
+	bz := ctx.KVStore(k.storeKey).Get(BaseFeeKey)
+	if bz == nil {
+		return k.GetParams(ctx).BaseFeeFloor()
+	}
+	var baseFee math.LegacyDec
+	if err := baseFee.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return baseFee
+}
+
+// SetBaseFee stores the current base fee
+func (k Keeper) SetBaseFee(ctx sdk.Context, baseFee math.LegacyDec) {
+	bz, err := baseFee.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(BaseFeeKey, bz)
+}
+
+// NextBaseFee returns the base fee following a block that used gasUsed
+func NextBaseFee(params types.Params, baseFee math.LegacyDec, gasUsed uint64) math.LegacyDec {
+	floor := params.BaseFeeFloor()
+	if params.BaseFeeTargetGas == 0 || params.BaseFeeChangeDenominator == 0 {
+		return math.LegacyMaxDec(baseFee, floor)
+	}
+
+	// Gas beyond twice the target counts as twice the target, capping each
+	// block's change at 1/denominator either way
+	target := math.NewIntFromUint64(params.BaseFeeTargetGas)
+	used := math.MinInt(math.NewIntFromUint64(gasUsed), target.MulRaw(2))
+	delta := used.Sub(target)
+	change := baseFee.MulInt(delta).QuoInt(target).QuoInt64(int64(params.BaseFeeChangeDenominator))
+	return math.LegacyMaxDec(baseFee.Add(change), floor)
+}
+
+// UpdateBaseFee adjusts the base fee for the next block from this block's
+// gas usage
+func (k Keeper) UpdateBaseFee(ctx sdk.Context, gasUsed uint64) {
+	baseFee := NextBaseFee(k.GetParams(ctx), k.GetBaseFee(ctx), gasUsed)
+	k.SetBaseFee(ctx, baseFee)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"base_fee",
+			sdk.NewAttribute("base_fee", baseFee.String()),
+			sdk.NewAttribute("gas_used", fmt.Sprintf("%d", gasUsed)),
+		),
+	)
+}
+
+// BaseFee reports the current base fee and the market's params
+func (q queryServer) BaseFee(goCtx context.Context, req *types.QueryBaseFeeRequest) (*types.QueryBaseFeeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := q.Keeper.GetParams(ctx)
+
+	return &types.QueryBaseFeeResponse{
+		BaseFee:           q.Keeper.GetBaseFee(ctx),
+		MinBaseFee:        params.BaseFeeFloor(),
+		TargetGas:         params.BaseFeeTargetGas,
+		ChangeDenominator: params.BaseFeeChangeDenominator,
+	}, nil
+}
@@ -0,0 +1,359 @@
+package keeper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// RANDOMNESS BEACON (validator commit-reveal)
+// ============================================
+//
+// Bonded validators commit to sha256(secret) and reveal the secret in a
+// later block. Each reveal is folded into a running seed. At the start of
+// every block the seed is hashed with the height to produce that block's
+// value, so it only depends on secrets revealed in earlier blocks.
+//
+// A block whose seed did not change since the previous block would have a
+// value anyone can compute ahead of time, so it is recorded as not usable
+// and consumers wait for a block that is. Withholding a reveal to steer the
+// value is costly: a commitment not revealed within BeaconRevealWindow
+// blocks is slashed by BeaconMissSlashFraction of the validator's stake.
+
+var (
+	BeaconSeedKey        = []byte("beacon_seed")
+	BeaconRevealCountKey = []byte("beacon_reveal_count")
+)
+
+// GetBeaconSeed returns the running seed all reveals are folded into
+func (k Keeper) GetBeaconSeed(ctx sdk.Context) []byte {
+	store := ctx.KVStore(k.storeKey)
+	return store.Get(BeaconSeedKey)
+}
+
+// SetBeaconSeed sets the running beacon seed
+func (k Keeper) SetBeaconSeed(ctx sdk.Context, seed []byte) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(BeaconSeedKey, seed)
+}
+
+// getPendingRevealCount returns reveals folded in since the last block value
+func (k Keeper) getPendingRevealCount(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(BeaconRevealCountKey)
+	if bz == nil {
+		return 0
+	}
+	return int64(bytesToUint64(bz))
+}
+
+func (k Keeper) setPendingRevealCount(ctx sdk.Context, count int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(BeaconRevealCountKey, uint64ToBytes(uint64(count)))
+}
+
+// GetBeaconCommitment returns a validator's outstanding commitment
+func (k Keeper) GetBeaconCommitment(ctx sdk.Context, validator string) (types.BeaconCommitment, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.BeaconCommitmentKeyPrefix, []byte(validator)...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.BeaconCommitment{}, false
+	}
+	var commitment types.BeaconCommitment
+	k.cdc.MustUnmarshal(bz, &commitment)
+	return commitment, true
+}
+
+// SetBeaconCommitment stores a validator's commitment
+func (k Keeper) SetBeaconCommitment(ctx sdk.Context, commitment types.BeaconCommitment) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.BeaconCommitmentKeyPrefix, []byte(commitment.Validator)...)
+	bz := k.cdc.MustMarshal(&commitment)
+	store.Set(key, bz)
+}
+
+// DeleteBeaconCommitment removes a validator's commitment
+func (k Keeper) DeleteBeaconCommitment(ctx sdk.Context, validator string) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.BeaconCommitmentKeyPrefix, []byte(validator)...)
+	store.Delete(key)
+}
+
+// IterateBeaconCommitments iterates over all outstanding commitments
+func (k Keeper) IterateBeaconCommitments(ctx sdk.Context, fn func(commitment types.BeaconCommitment) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.BeaconCommitmentKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var commitment types.BeaconCommitment
+		k.cdc.MustUnmarshal(iterator.Value(), &commitment)
+		if fn(commitment) {
+			break
+		}
+	}
+}
+
+// GetBlockRandomness returns the beacon value recorded for a height
+func (k Keeper) GetBlockRandomness(ctx sdk.Context, height int64) (types.BlockRandomness, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.BlockRandomnessKeyPrefix, uint64ToBytes(uint64(height))...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.BlockRandomness{}, false
+	}
+	var randomness types.BlockRandomness
+	k.cdc.MustUnmarshal(bz, &randomness)
+	return randomness, true
+}
+
+// SetBlockRandomness stores the beacon value for a height
+func (k Keeper) SetBlockRandomness(ctx sdk.Context, randomness types.BlockRandomness) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.BlockRandomnessKeyPrefix, uint64ToBytes(uint64(randomness.Height))...)
+	bz := k.cdc.MustMarshal(&randomness)
+	store.Set(key, bz)
+}
+
+// blockRandomnessValue hashes the current seed with the height
+func (k Keeper) blockRandomnessValue(ctx sdk.Context) []byte {
+	h := sha256.New()
+	h.Write(k.GetBeaconSeed(ctx))
+	h.Write(uint64ToBytes(uint64(ctx.BlockHeight())))
+	return h.Sum(nil)
+}
+
+// RecordBlockRandomness fixes this block's beacon value. Called first in
+// BeginBlocker, before any transaction in the block can reveal. Without
+// enough fresh reveals the value is predictable, so it is recorded as not
+// usable and left empty.
+func (k Keeper) RecordBlockRandomness(ctx sdk.Context) {
+	height := ctx.BlockHeight()
+	randomness := types.BlockRandomness{
+		Height:  height,
+		Reveals: k.getPendingRevealCount(ctx),
+	}
+	if randomness.Reveals >= types.BeaconMinReveals {
+		randomness.Value = hex.EncodeToString(k.blockRandomnessValue(ctx))
+		randomness.Usable = true
+	}
+
+	k.SetBlockRandomness(ctx, randomness)
+	k.setPendingRevealCount(ctx, 0)
+
+	// Prune history beyond the query window
+	if old := height - types.BeaconHistoryBlocks; old > 0 {
+		store := ctx.KVStore(k.storeKey)
+		store.Delete(append(types.BlockRandomnessKeyPrefix, uint64ToBytes(uint64(old))...))
+	}
+}
+
+// CurrentRandomness returns the beacon value for the current block and
+// whether it is usable. It is not usable until BeginBlocker has recorded it,
+// or when too few reveals arrived before the block.
+func (k Keeper) CurrentRandomness(ctx sdk.Context) ([]byte, bool) {
+	randomness, found := k.GetBlockRandomness(ctx, ctx.BlockHeight())
+	if !found || !randomness.Usable {
+		return nil, false
+	}
+	bz, err := hex.DecodeString(randomness.Value)
+	if err != nil || len(bz) == 0 {
+		return nil, false
+	}
+	return bz, true
+}
+
+// PenalizeUnrevealedCommitments removes commitments whose reveal window has
+// passed and slashes their validators. Called in BeginBlocker before the
+// block's value is recorded.
+func (k Keeper) PenalizeUnrevealedCommitments(ctx sdk.Context) {
+	var expired []types.BeaconCommitment
+	k.IterateBeaconCommitments(ctx, func(commitment types.BeaconCommitment) bool {
+		if ctx.BlockHeight()-commitment.Height >= types.BeaconRevealWindow {
+			expired = append(expired, commitment)
+		}
+		return false
+	})
+
+	for _, commitment := range expired {
+		k.DeleteBeaconCommitment(ctx, commitment.Validator)
+
+		// Slash atomically so a failure leaves the validator untouched
+		cacheCtx, write := ctx.CacheContext()
+		slashed, err := k.slashUnrevealedCommitment(cacheCtx, commitment)
+		if err != nil {
+			k.Logger(ctx).Error("Failed to slash unrevealed beacon commitment",
+				"validator", commitment.Validator,
+				"commit_height", commitment.Height,
+				"error", err,
+			)
+			slashed = math.ZeroInt()
+		} else {
+			write()
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"beacon_commitment_missed",
+				sdk.NewAttribute("validator", commitment.Validator),
+				sdk.NewAttribute("commit_height", fmt.Sprintf("%d", commitment.Height)),
+				sdk.NewAttribute("slashed", slashed.String()),
+			),
+		)
+	}
+}
+
+// slashUnrevealedCommitment slashes the validator behind an expired
+// commitment for the block it committed in
+func (k Keeper) slashUnrevealedCommitment(ctx sdk.Context, commitment types.BeaconCommitment) (math.Int, error) {
+	if k.stakingKeeper == nil {
+		return math.ZeroInt(), nil
+	}
+	accAddr, err := sdk.AccAddressFromBech32(commitment.Validator)
+	if err != nil {
+		return math.ZeroInt(), err
+	}
+	validator, err := k.stakingKeeper.GetValidator(ctx, sdk.ValAddress(accAddr))
+	if err != nil {
+		return math.ZeroInt(), err
+	}
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return math.ZeroInt(), err
+	}
+	power := validator.GetConsensusPower(k.stakingKeeper.PowerReduction(ctx))
+	return k.stakingKeeper.Slash(ctx, consAddr, commitment.Height, power, types.BeaconMissSlashFraction)
+}
+
+// requireBondedValidator checks that the signer is the operator of a bonded
+// validator. Without a staking keeper nobody qualifies.
+func (k Keeper) requireBondedValidator(ctx sdk.Context, signer string) error {
+	accAddr, err := sdk.AccAddressFromBech32(signer)
+	if err != nil {
+		return types.ErrUnauthorized
+	}
+	if k.stakingKeeper == nil {
+		return errorsmod.Wrap(types.ErrUnauthorized, "staking keeper not available")
+	}
+	validator, err := k.stakingKeeper.GetValidator(ctx, sdk.ValAddress(accAddr))
+	if err != nil {
+		return types.ErrValidatorNotFound
+	}
+	if !validator.IsBonded() {
+		return errorsmod.Wrap(types.ErrUnauthorized, "validator is not bonded")
+	}
+	return nil
+}
+
+// CommitRandomness records a validator's commitment for a later reveal
+func (k msgServer) CommitRandomness(goCtx context.Context, msg *types.MsgCommitRandomness) (*types.MsgCommitRandomnessResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.requireBondedValidator(ctx, msg.Validator); err != nil {
+		return nil, err
+	}
+
+	// An unrevealed commitment cannot be replaced, otherwise a validator
+	// could swap secrets after seeing other reveals. Expired commitments are
+	// slashed and removed at the start of the block.
+	if _, found := k.GetBeaconCommitment(ctx, msg.Validator); found {
+		return nil, errorsmod.Wrap(types.ErrInvalidCommitment, "outstanding commitment must be revealed first")
+	}
+
+	k.SetBeaconCommitment(ctx, types.BeaconCommitment{
+		Validator:  msg.Validator,
+		Commitment: msg.Commitment,
+		Height:     ctx.BlockHeight(),
+	})
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"beacon_committed",
+			sdk.NewAttribute("validator", msg.Validator),
+			sdk.NewAttribute("height", fmt.Sprintf("%d", ctx.BlockHeight())),
+		),
+	)
+
+	return &types.MsgCommitRandomnessResponse{}, nil
+}
+
+// RevealRandomness verifies a secret against its commitment and folds it into the seed
+func (k msgServer) RevealRandomness(goCtx context.Context, msg *types.MsgRevealRandomness) (*types.MsgRevealRandomnessResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.requireBondedValidator(ctx, msg.Validator); err != nil {
+		return nil, err
+	}
+
+	commitment, found := k.GetBeaconCommitment(ctx, msg.Validator)
+	if !found {
+		return nil, errorsmod.Wrap(types.ErrInvalidCommitment, "no outstanding commitment")
+	}
+	if commitment.Height >= ctx.BlockHeight() {
+		return nil, errorsmod.Wrap(types.ErrInvalidCommitment, "cannot reveal in the commit block")
+	}
+	if ctx.BlockHeight()-commitment.Height >= types.BeaconRevealWindow {
+		return nil, errorsmod.Wrap(types.ErrInvalidCommitment, "commitment expired")
+	}
+
+	secret, _ := hex.DecodeString(msg.Secret)
+	digest := sha256.Sum256(secret)
+	if hex.EncodeToString(digest[:]) != commitment.Commitment {
+		return nil, errorsmod.Wrap(types.ErrInvalidCommitment, "secret does not match commitment")
+	}
+
+	// Fold the secret into the running seed
+	h := sha256.New()
+	h.Write(k.GetBeaconSeed(ctx))
+	h.Write(secret)
+	k.SetBeaconSeed(ctx, h.Sum(nil))
+	k.setPendingRevealCount(ctx, k.getPendingRevealCount(ctx)+1)
+
+	// A reveal is the validator's own attestation and marks it active
+	accAddr, _ := sdk.AccAddressFromBech32(msg.Validator)
+	k.updateValidatorRecord(ctx, sdk.ValAddress(accAddr), func(record *types.ValidatorMiningRecord) {})
+
+	if msg.NextCommitment != "" {
+		k.SetBeaconCommitment(ctx, types.BeaconCommitment{
+			Validator:  msg.Validator,
+			Commitment: msg.NextCommitment,
+			Height:     ctx.BlockHeight(),
+		})
+	} else {
+		k.DeleteBeaconCommitment(ctx, msg.Validator)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"beacon_revealed",
+			sdk.NewAttribute("validator", msg.Validator),
+			sdk.NewAttribute("height", fmt.Sprintf("%d", ctx.BlockHeight())),
+		),
+	)
+
+	return &types.MsgRevealRandomnessResponse{Height: ctx.BlockHeight()}, nil
+}
+
+// Randomness returns the beacon value for a height (0 = current block)
+func (q queryServer) Randomness(goCtx context.Context, req *types.QueryRandomnessRequest) (*types.QueryRandomnessResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	height := req.Height
+	if height == 0 {
+		height = ctx.BlockHeight()
+	}
+	randomness, found := q.Keeper.GetBlockRandomness(ctx, height)
+	if !found {
+		return nil, types.ErrRandomnessNotFound
+	}
+	return &types.QueryRandomnessResponse{Randomness: randomness}, nil
+}
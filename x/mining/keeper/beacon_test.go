@@ -0,0 +1,186 @@
+package keeper_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/keeper"
+	"nexus/x/mining/types"
+)
+
+func beaconCommit(secret []byte) string {
+	digest := sha256.Sum256(secret)
+	return hex.EncodeToString(digest[:])
+}
+
+// setUsableRandomness records a usable beacon value for the current block,
+// as if validators had revealed before it
+func setUsableRandomness(k keeper.Keeper, ctx sdk.Context) {
+	value := sha256.Sum256(sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	k.SetBlockRandomness(ctx, types.BlockRandomness{
+		Height:  ctx.BlockHeight(),
+		Value:   hex.EncodeToString(value[:]),
+		Reveals: 1,
+		Usable:  true,
+	})
+}
+
+// setupBeaconKeeper returns a keeper whose staking keeper knows testMiner's
+// operator address as a bonded validator
+func setupBeaconKeeper(t *testing.T) (keeper.Keeper, sdk.Context, *MockStakingKeeper) {
+	stakingKeeper := NewMockStakingKeeper()
+	minerAddr, _ := sdk.AccAddressFromBech32(testMiner)
+	stakingKeeper.AddValidator(sdk.ValAddress(minerAddr).String(), 1_000_000)
+	k, ctx, _ := setupKeeperWithKeepers(t, stakingKeeper, nil, nil)
+	return k, ctx, stakingKeeper
+}
+
+func TestRandomnessBeaconCommitReveal(t *testing.T) {
+	k, ctx, _ := setupBeaconKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	queryServer := keeper.NewQueryServerImpl(k)
+	secret := sha256.Sum256([]byte("validator secret"))
+
+	// Without fresh reveals the value is predictable and not handed out
+	ctx = ctx.WithBlockHeight(10)
+	k.RecordBlockRandomness(ctx)
+	before, found := k.GetBlockRandomness(ctx, 10)
+	if !found {
+		t.Fatal("Block randomness not recorded")
+	}
+	if before.Usable || before.Value != "" {
+		t.Errorf("Expected unusable empty value without reveals, got %+v", before)
+	}
+	if _, usable := k.CurrentRandomness(ctx); usable {
+		t.Error("CurrentRandomness should not be usable without reveals")
+	}
+
+	if _, err := msgServer.CommitRandomness(sdk.WrapSDKContext(ctx), &types.MsgCommitRandomness{
+		Validator: testMiner, Commitment: beaconCommit(secret[:]),
+	}); err != nil {
+		t.Fatalf("CommitRandomness failed: %v", err)
+	}
+	// Non-validators cannot feed the seed
+	if _, err := msgServer.CommitRandomness(sdk.WrapSDKContext(ctx), &types.MsgCommitRandomness{
+		Validator: testCustomer, Commitment: beaconCommit(secret[:]),
+	}); err == nil {
+		t.Error("Should have rejected commitment from a non-validator")
+	}
+
+	// Same-block reveal is rejected
+	reveal := &types.MsgRevealRandomness{Validator: testMiner, Secret: hex.EncodeToString(secret[:])}
+	if _, err := msgServer.RevealRandomness(sdk.WrapSDKContext(ctx), reveal); err == nil {
+		t.Error("Should have rejected reveal in the commit block")
+	}
+
+	// Wrong secret is rejected
+	ctx = ctx.WithBlockHeight(11)
+	k.RecordBlockRandomness(ctx)
+	wrong := sha256.Sum256([]byte("other secret"))
+	if _, err := msgServer.RevealRandomness(sdk.WrapSDKContext(ctx), &types.MsgRevealRandomness{
+		Validator: testMiner, Secret: hex.EncodeToString(wrong[:]),
+	}); err == nil {
+		t.Error("Should have rejected secret that does not match commitment")
+	}
+
+	if _, err := msgServer.RevealRandomness(sdk.WrapSDKContext(ctx), reveal); err != nil {
+		t.Fatalf("RevealRandomness failed: %v", err)
+	}
+	if _, found := k.GetBeaconCommitment(ctx, testMiner); found {
+		t.Error("Commitment should be cleared after reveal without next commitment")
+	}
+
+	// The reveal only affects the next block's value
+	if _, usable := k.CurrentRandomness(ctx); usable {
+		t.Error("Current block value became usable after a reveal in the same block")
+	}
+
+	ctx = ctx.WithBlockHeight(12)
+	k.RecordBlockRandomness(ctx)
+	resp, err := queryServer.Randomness(sdk.WrapSDKContext(ctx), &types.QueryRandomnessRequest{Height: 12})
+	if err != nil {
+		t.Fatalf("Randomness query failed: %v", err)
+	}
+	if resp.Randomness.Reveals != 1 || !resp.Randomness.Usable {
+		t.Errorf("Expected 1 reveal and a usable value at block 12, got %+v", resp.Randomness)
+	}
+	if value, usable := k.CurrentRandomness(ctx); !usable || hex.EncodeToString(value) != resp.Randomness.Value {
+		t.Error("CurrentRandomness does not match the recorded usable value")
+	}
+
+	// The next block has no fresh reveals and is unusable again
+	ctx = ctx.WithBlockHeight(13)
+	k.RecordBlockRandomness(ctx)
+	if _, usable := k.CurrentRandomness(ctx); usable {
+		t.Error("Block without fresh reveals should not be usable")
+	}
+
+	if _, err := queryServer.Randomness(sdk.WrapSDKContext(ctx), &types.QueryRandomnessRequest{Height: 500}); err == nil {
+		t.Error("Expected error for height with no recorded randomness")
+	}
+}
+
+func TestRandomnessBeaconRequiresStakingKeeper(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	secret := sha256.Sum256([]byte("validator secret"))
+
+	_, err := msgServer.CommitRandomness(sdk.WrapSDKContext(ctx), &types.MsgCommitRandomness{
+		Validator: testMiner, Commitment: beaconCommit(secret[:]),
+	})
+	if !errors.Is(err, types.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized without a staking keeper, got %v", err)
+	}
+}
+
+func TestRandomnessBeaconSlashesUnrevealedCommitment(t *testing.T) {
+	k, ctx, stakingKeeper := setupBeaconKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	secret := sha256.Sum256([]byte("withheld secret"))
+
+	ctx = ctx.WithBlockHeight(20)
+	if _, err := msgServer.CommitRandomness(sdk.WrapSDKContext(ctx), &types.MsgCommitRandomness{
+		Validator: testMiner, Commitment: beaconCommit(secret[:]),
+	}); err != nil {
+		t.Fatalf("CommitRandomness failed: %v", err)
+	}
+
+	// Still inside the reveal window: nothing happens
+	ctx = ctx.WithBlockHeight(20 + types.BeaconRevealWindow - 1)
+	k.PenalizeUnrevealedCommitments(ctx)
+	if _, found := k.GetBeaconCommitment(ctx, testMiner); !found || len(stakingKeeper.Slashes) != 0 {
+		t.Fatal("Commitment inside the reveal window should not be penalized")
+	}
+
+	ctx = ctx.WithBlockHeight(20 + types.BeaconRevealWindow).WithEventManager(sdk.NewEventManager())
+	k.PenalizeUnrevealedCommitments(ctx)
+	if _, found := k.GetBeaconCommitment(ctx, testMiner); found {
+		t.Error("Expired commitment should be removed")
+	}
+	if len(stakingKeeper.Slashes) != 1 {
+		t.Fatalf("Expected one slash, got %d", len(stakingKeeper.Slashes))
+	}
+	slash := stakingKeeper.Slashes[0]
+	if slash.InfractionHeight != 20 || !slash.SlashFactor.Equal(types.BeaconMissSlashFraction) {
+		t.Errorf("Unexpected slash %+v", slash)
+	}
+	missed := false
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == "beacon_commitment_missed" {
+			missed = true
+		}
+	}
+	if !missed {
+		t.Error("Expected beacon_commitment_missed event")
+	}
+
+	// Once penalized the validator can commit again
+	if _, err := msgServer.CommitRandomness(sdk.WrapSDKContext(ctx), &types.MsgCommitRandomness{
+		Validator: testMiner, Commitment: beaconCommit(secret[:]),
+	}); err != nil {
+		t.Errorf("CommitRandomness after penalty failed: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// BurnedKeyPrefix prefixes the cumulative amount burned from each source,
+// keyed by source name
+var BurnedKeyPrefix = []byte("burned/")
+
+// GetBurned returns the cumulative amount burned from a source
+func (k Keeper) GetBurned(ctx sdk.Context, source string) math.Int {
+	return k.getInt(ctx, append(BurnedKeyPrefix, []byte(source)...))
+}
+
+// RecordBurn adds amount to a source's cumulative burn. Callers record only
+// after the burn succeeded.
+func (k Keeper) RecordBurn(ctx sdk.Context, source string, amount math.Int) {
+	if amount.IsNil() || !amount.IsPositive() {
+		return
+	}
+	k.setInt(ctx, append(BurnedKeyPrefix, []byte(source)...), k.GetBurned(ctx, source).Add(amount))
+}
+
+// BurnStats reports the cumulative amount burned from each source
+func (q queryServer) BurnStats(goCtx context.Context, req *types.QueryBurnStatsRequest) (*types.QueryBurnStatsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	resp := &types.QueryBurnStatsResponse{
+		Sources: make([]types.BurnedAmount, 0, len(types.BurnSources)),
+		Total:   math.ZeroInt(),
+	}
+	for _, source := range types.BurnSources {
+		burned := q.Keeper.GetBurned(ctx, source)
+		resp.Sources = append(resp.Sources, types.BurnedAmount{Source: source, Amount: burned})
+		resp.Total = resp.Total.Add(burned)
+	}
+	return resp, nil
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -8,25 +9,43 @@ import (
 var ValidatorRewardPoolKey = []byte("validator_reward_pool")
 
 // GetValidatorRewardPool returns the accumulated validator rewards waiting for distribution
-func (k Keeper) GetValidatorRewardPool(ctx sdk.Context) int64 {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(ValidatorRewardPoolKey)
-	if bz == nil {
-		return 0
-	}
-	return int64(bytesToUint64(bz))
+func (k Keeper) GetValidatorRewardPool(ctx sdk.Context) math.Int {
+	return k.getInt(ctx, ValidatorRewardPoolKey)
 }
 
 // SetValidatorRewardPool sets the accumulated validator rewards
-func (k Keeper) SetValidatorRewardPool(ctx sdk.Context, amount int64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Set(ValidatorRewardPoolKey, uint64ToBytes(uint64(amount)))
+func (k Keeper) SetValidatorRewardPool(ctx sdk.Context, amount math.Int) {
+	k.setInt(ctx, ValidatorRewardPoolKey, amount)
 }
 
 // AddToValidatorRewardPool adds to the accumulated validator rewards
-func (k Keeper) AddToValidatorRewardPool(ctx sdk.Context, amount int64) {
+func (k Keeper) AddToValidatorRewardPool(ctx sdk.Context, amount math.Int) {
 	current := k.GetValidatorRewardPool(ctx)
-	k.SetValidatorRewardPool(ctx, current+amount)
+	k.SetValidatorRewardPool(ctx, current.Add(amount))
+}
+
+// getInt reads an arbitrary-precision amount stored under key
+func (k Keeper) getInt(ctx sdk.Context, key []byte) math.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(key)
+	if bz == nil {
+		return math.ZeroInt()
+	}
+	var amount math.Int
+	if err := amount.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// setInt stores an arbitrary-precision amount under key
+func (k Keeper) setInt(ctx sdk.Context, key []byte, amount math.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz, err := amount.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	store.Set(key, bz)
 }
 
 // bytesToUint64 converts bytes to uint64
@@ -40,6 +59,6 @@ func bytesToUint64(bz []byte) uint64 {
 
 // DistributeValidatorRewards is an exported wrapper for distributeValidatorRewardsInternal
 // Used for testing
-func (k Keeper) DistributeValidatorRewards(ctx sdk.Context, rewardPool int64) (int64, error) {
+func (k Keeper) DistributeValidatorRewards(ctx sdk.Context, rewardPool math.Int) (math.Int, error) {
 	return k.distributeValidatorRewardsInternal(ctx, rewardPool)
 }
@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// StartCollaborativeEpoch moves a collaborative job to the given epoch and
+// derives the randomness miners use to seed their runs for that epoch.
+// Fails without changing the job when the block's beacon value is not
+// usable. The caller is responsible for persisting the job.
+func (k Keeper) StartCollaborativeEpoch(ctx sdk.Context, job *types.Job, epoch uint64) error {
+	beacon, usable := k.CurrentRandomness(ctx)
+	if !usable {
+		return errorsmod.Wrapf(types.ErrRandomnessNotFound, "beacon value for height %d is not usable", ctx.BlockHeight())
+	}
+
+	job.VrfRandomness = deriveEpochRandomness(*job, epoch, beacon)
+	job.CurrentEpoch = epoch
+	job.EpochStartHeight = ctx.BlockHeight()
+	job.EpochStartTime = ctx.BlockTime().Unix()
+	k.SetJobEpoch(ctx, job.Id, epoch)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"collab_epoch_started",
+			sdk.NewAttribute("job_id", job.Id),
+			sdk.NewAttribute("epoch", fmt.Sprintf("%d", epoch)),
+			sdk.NewAttribute("start_height", fmt.Sprintf("%d", job.EpochStartHeight)),
+			sdk.NewAttribute("vrf_randomness", job.VrfRandomness),
+		),
+	)
+	return nil
+}
+
+// deriveEpochRandomness chains the previous epoch's randomness with the
+// block's beacon value so every epoch gets fresh, unpredictable seed inputs
+func deriveEpochRandomness(job types.Job, epoch uint64, beacon []byte) string {
+	h := sha256.New()
+	h.Write([]byte(job.Id))
+	h.Write(uint64ToBytes(epoch))
+	h.Write([]byte(job.VrfRandomness))
+	h.Write(beacon)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IsCollaborativeEpochDue reports whether the job's current epoch has run
+// for CollabEpochBlocks blocks or CollabEpochDuration, whichever comes first
+func IsCollaborativeEpochDue(ctx sdk.Context, job types.Job, params types.Params) bool {
+	if params.CollabEpochBlocks > 0 && ctx.BlockHeight()-job.EpochStartHeight >= params.CollabEpochBlocks {
+		return true
+	}
+	if params.CollabEpochDuration > 0 {
+		elapsed := time.Duration(ctx.BlockTime().Unix()-job.EpochStartTime) * time.Second
+		if elapsed >= params.CollabEpochDuration {
+			return true
+		}
+	}
+	return false
+}
+
+// AdvanceCollaborativeEpoch rolls the current job to its next epoch when due
+func (k Keeper) AdvanceCollaborativeEpoch(ctx sdk.Context) {
+	jobID := k.GetCurrentJobID(ctx)
+	if jobID == "" {
+		return
+	}
+
+	job, found := k.GetJob(ctx, jobID)
+	if !found || job.Status != types.JobStatusActive || job.MiningMode != types.MiningModeCollaborative {
+		return
+	}
+
+	if !IsCollaborativeEpochDue(ctx, job, k.GetParams(ctx)) {
+		return
+	}
+
+	// The epoch keeps running until a block has a usable beacon value
+	if err := k.StartCollaborativeEpoch(ctx, &job, job.CurrentEpoch+1); err != nil {
+		k.Logger(ctx).Info("Delaying collaborative epoch", "job_id", jobID, "reason", err)
+		return
+	}
+	k.SetJob(ctx, job)
+
+	k.Logger(ctx).Info("Advanced collaborative epoch",
+		"job_id", jobID,
+		"epoch", job.CurrentEpoch,
+		"total_steps", job.TotalSteps,
+	)
+}
+
+// minerEpochUsageKey keys a miner's usage by job, miner and epoch
+func minerEpochUsageKey(jobId, miner string, epoch uint64) []byte {
+	key := append(types.MinerEpochUsageKeyPrefix, []byte(jobId+"/"+miner+"/")...)
+	return append(key, uint64ToBytes(epoch)...)
+}
+
+// GetMinerEpochUsage returns how many submissions and steps a miner has
+// made in one epoch of a collaborative job
+func (k Keeper) GetMinerEpochUsage(ctx sdk.Context, jobId, miner string, epoch uint64) types.MinerEpochUsage {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(minerEpochUsageKey(jobId, miner, epoch))
+	if bz == nil {
+		return types.MinerEpochUsage{}
+	}
+	var usage types.MinerEpochUsage
+	k.cdc.MustUnmarshal(bz, &usage)
+	return usage
+}
+
+// SetMinerEpochUsage stores a miner's per-epoch usage
+func (k Keeper) SetMinerEpochUsage(ctx sdk.Context, jobId, miner string, epoch uint64, usage types.MinerEpochUsage) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&usage)
+	store.Set(minerEpochUsageKey(jobId, miner, epoch), bz)
+}
+
+// checkEpochLimits rejects a submission that would exceed the per-miner
+// submission or step limits for the epoch
+func checkEpochLimits(params types.Params, usage types.MinerEpochUsage, numSteps uint64) error {
+	if params.MaxSubmissionsPerEpoch > 0 && usage.Submissions >= params.MaxSubmissionsPerEpoch {
+		return errorsmod.Wrapf(types.ErrEpochLimitExceeded, "%d submissions already made this epoch (max %d)",
+			usage.Submissions, params.MaxSubmissionsPerEpoch)
+	}
+	if params.MaxStepsPerEpoch > 0 && usage.Steps+numSteps > params.MaxStepsPerEpoch {
+		return errorsmod.Wrapf(types.ErrEpochLimitExceeded, "%d steps would exceed %d steps per epoch",
+			usage.Steps+numSteps, params.MaxStepsPerEpoch)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// COLLABORATIVE PAYOUT POOLS
+// ============================================
+//
+// A collaborative job's reward is split into three pools:
+//   work pool (70%)        - pro-rata by steps (WorkPoolShares)
+//   improvement pool (20%) - pro-rata by energy improvements (BonusPoolShares)
+//   validator pool (10%)   - credited to the validator reward pool at settlement
+// Steps and energy deltas are different units, so each pool is divided
+// against its own total rather than a combined share count.
+
+// CollaborativePoolSplit projects a miner's cut of the work and improvement
+// pools of amount, plus its step-weighted part of the validator pool, for
+// MinerRewardBreakdown on unsettled jobs. Payouts are not computed here:
+// settleCollaborative applies the same split once when the job settles and
+// credits the whole validator pool to the validator reward pool then.
+func CollaborativePoolSplit(params types.Params, amount math.Int, minerSteps, totalSteps, minerBonus, totalBonus int64) (work, improvement, validator math.Int) {
+	work, improvement, validator = math.ZeroInt(), math.ZeroInt(), math.ZeroInt()
+	if !amount.IsPositive() {
+		return work, improvement, validator
+	}
+
+	workPool := amount.MulRaw(int64(params.WorkPoolPercent)).QuoRaw(100)
+	improvementPool := amount.MulRaw(int64(params.ImprovementPoolPercent)).QuoRaw(100)
+	validatorPool := amount.Sub(workPool).Sub(improvementPool)
+
+	if totalSteps > 0 {
+		work = mulDiv(workPool, minerSteps, totalSteps)
+		validator = mulDiv(validatorPool, minerSteps, totalSteps)
+	}
+	if totalBonus > 0 {
+		improvement = mulDiv(improvementPool, minerBonus, totalBonus)
+	}
+	return work, improvement, validator
+}
+
+// mulDiv computes a*b/c, truncating
+func mulDiv(a math.Int, b, c int64) math.Int {
+	return a.MulRaw(b).QuoRaw(c)
+}
+
+// availableEmission returns the job's emission reward capped at its
+// workload's escrow balance
+func (k Keeper) availableEmission(ctx sdk.Context, job types.Job) math.Int {
+	emission := k.CalculateEmissionReward(ctx, job)
+	if escrow := k.GetWorkloadEscrow(ctx, JobWorkload(job)); emission.GT(escrow) {
+		emission = escrow
+	}
+	return emission
+}
+
+// GetMinerRewardBreakdown projects what a miner would be paid from each
+// collaborative pool if the job settled in the current block
+func (k Keeper) GetMinerRewardBreakdown(ctx sdk.Context, job types.Job, miner sdk.AccAddress) types.QueryMinerRewardBreakdownResponse {
+	params := k.GetParams(ctx)
+	minerSteps := k.GetWorkShares(ctx, miner, job.Id)
+	minerBonus := k.GetBonusShares(ctx, miner, job.Id)
+	emission := k.availableEmission(ctx, job)
+
+	cWork, cImprovement, cValidator := CollaborativePoolSplit(params, job.Reward, minerSteps, job.WorkPoolShares, minerBonus, job.BonusPoolShares)
+	eWork, eImprovement, eValidator := CollaborativePoolSplit(params, emission, minerSteps, job.WorkPoolShares, minerBonus, job.BonusPoolShares)
+
+	breakdown := types.QueryMinerRewardBreakdownResponse{
+		JobId:             job.Id,
+		Miner:             miner.String(),
+		MinerSteps:        minerSteps,
+		TotalSteps:        job.WorkPoolShares,
+		MinerBonus:        minerBonus,
+		TotalBonus:        job.BonusPoolShares,
+		WorkReward:        cWork.Add(eWork),
+		ImprovementReward: cImprovement.Add(eImprovement),
+		ValidatorShare:    cValidator.Add(eValidator),
+	}
+	breakdown.TotalMinerReward = breakdown.WorkReward.Add(breakdown.ImprovementReward)
+	return breakdown
+}
+
+// settledRewardBreakdown reports a miner's recorded payout on a settled
+// job; it is zero once the payout has been claimed
+func (k Keeper) settledRewardBreakdown(ctx sdk.Context, job types.Job, miner sdk.AccAddress) types.QueryMinerRewardBreakdownResponse {
+	breakdown := types.QueryMinerRewardBreakdownResponse{
+		JobId:             job.Id,
+		Miner:             miner.String(),
+		MinerSteps:        k.GetWorkShares(ctx, miner, job.Id),
+		TotalSteps:        job.WorkPoolShares,
+		MinerBonus:        k.GetBonusShares(ctx, miner, job.Id),
+		TotalBonus:        job.BonusPoolShares,
+		WorkReward:        math.ZeroInt(),
+		ImprovementReward: math.ZeroInt(),
+		ValidatorShare:    math.ZeroInt(),
+		TotalMinerReward:  math.ZeroInt(),
+	}
+	if claim, found := k.GetClaimableReward(ctx, job.Id, miner.String()); found {
+		breakdown.WorkReward = claim.WorkReward
+		breakdown.ImprovementReward = claim.ImprovementReward
+		breakdown.TotalMinerReward = claim.Total()
+	}
+	return breakdown
+}
+
+// MinerRewardBreakdown returns a miner's per-pool payout for a collaborative job
+func (q queryServer) MinerRewardBreakdown(goCtx context.Context, req *types.QueryMinerRewardBreakdownRequest) (*types.QueryMinerRewardBreakdownResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	job, found := q.Keeper.GetJob(ctx, req.JobId)
+	if !found {
+		return nil, types.ErrJobNotFound
+	}
+	if job.MiningMode != types.MiningModeCollaborative {
+		return nil, types.ErrWrongMiningMode
+	}
+	miner, err := sdk.AccAddressFromBech32(req.Miner)
+	if err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+	if job.IsSettled() {
+		breakdown := q.Keeper.settledRewardBreakdown(ctx, job, miner)
+		return &breakdown, nil
+	}
+	breakdown := q.Keeper.GetMinerRewardBreakdown(ctx, job, miner)
+	return &breakdown, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"cosmossdk.io/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
 )
@@ -96,19 +97,18 @@ func (k Keeper) IncrementDockingJobCount(ctx sdk.Context) uint64 {
 	return count
 }
 
-func (k Keeper) GetMinerDockingRewards(ctx sdk.Context, miner sdk.AccAddress) int64 {
+// IterateDockingJobs iterates over all docking jobs
+func (k Keeper) IterateDockingJobs(ctx sdk.Context, fn func(job types.DockingJob) bool) {
 	store := ctx.KVStore(k.storeKey)
-	key := append([]byte("miner_dock_rewards_"), miner.Bytes()...)
-	bz := store.Get(key)
-	if bz == nil {
-		return 0
-	}
-	return int64(binary.BigEndian.Uint64(bz))
-}
+	prefixStore := prefix.NewStore(store, types.DockingJobKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
 
-func (k Keeper) AddMinerDockingRewards(ctx sdk.Context, miner sdk.AccAddress, amount int64) {
-	current := k.GetMinerDockingRewards(ctx, miner)
-	store := ctx.KVStore(k.storeKey)
-	key := append([]byte("miner_dock_rewards_"), miner.Bytes()...)
-	store.Set(key, uint64ToBytes(uint64(current+amount)))
+	for ; iterator.Valid(); iterator.Next() {
+		var job types.DockingJob
+		k.cdc.MustUnmarshal(iterator.Value(), &job)
+		if fn(job) {
+			break
+		}
+	}
 }
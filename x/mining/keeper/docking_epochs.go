@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
 	storetypes "cosmossdk.io/store/types"
@@ -129,7 +130,11 @@ func (k Keeper) ProcessDockingEpoch(ctx sdk.Context) error {
 	return k.EndDockingEpochAndDistribute(ctx)
 }
 
-// EndDockingEpochAndDistribute distributes epoch emission to miners
+// EndDockingEpochAndDistribute distributes epoch emission to miners. The
+// epoch's emission (DockingEpochMinutes at the docking workload's rate) is
+// drawn from the docking escrow, capped at what the escrow holds. The
+// treasury's share is paid first; truncation dust and failed payouts stay in
+// escrow.
 func (k Keeper) EndDockingEpochAndDistribute(ctx sdk.Context) error {
 	epochNumber := k.GetDockingEpochNumber(ctx)
 	totalShares := k.GetDockingTotalShares(ctx)
@@ -140,19 +145,30 @@ func (k Keeper) EndDockingEpochAndDistribute(ctx sdk.Context) error {
 		return nil
 	}
 
-	// Calculate emission for this epoch (10 minutes worth)
-	emissionRate := k.GetCurrentEmissionRate(ctx) // unex per minute
-	epochEmission := emissionRate * DockingEpochMinutes
+	// Calculate emission for this epoch (10 minutes worth), limited to minted escrow
+	epochEmission := k.WorkloadEmissionRate(ctx, types.WorkloadDocking).MulRaw(DockingEpochMinutes)
+	escrow := k.GetWorkloadEscrow(ctx, types.WorkloadDocking)
+	if epochEmission.GT(escrow) {
+		epochEmission = escrow
+	}
 
-	// Distribute proportionally to all miners who contributed
-	k.distributeDockingRewards(ctx, epochEmission, totalShares)
+	// The treasury takes its share first; the rest is distributed
+	// proportionally to all miners who contributed
+	treasuryShare := TreasuryCut(k.GetParams(ctx), epochEmission)
+	if err := k.depositToTreasury(ctx, treasuryShare, fmt.Sprintf("docking_epoch/%d", epochNumber)); err != nil {
+		return err
+	}
+	distributed := k.distributeDockingRewards(ctx, epochEmission.Sub(treasuryShare), totalShares)
+	k.SetWorkloadEscrow(ctx, types.WorkloadDocking, escrow.Sub(treasuryShare).Sub(distributed))
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			"docking_epoch_end",
 			sdk.NewAttribute("epoch", fmt.Sprintf("%d", epochNumber)),
 			sdk.NewAttribute("total_shares", fmt.Sprintf("%d", totalShares)),
-			sdk.NewAttribute("emission_distributed", fmt.Sprintf("%d", epochEmission)),
+			sdk.NewAttribute("epoch_emission", epochEmission.String()),
+			sdk.NewAttribute("emission_distributed", distributed.String()),
+			sdk.NewAttribute("treasury_share", treasuryShare.String()),
 		),
 	)
 
@@ -160,6 +176,7 @@ func (k Keeper) EndDockingEpochAndDistribute(ctx sdk.Context) error {
 		"epoch", epochNumber,
 		"total_shares", totalShares,
 		"emission", epochEmission,
+		"distributed", distributed,
 	)
 
 	// Advance to next epoch
@@ -168,67 +185,76 @@ func (k Keeper) EndDockingEpochAndDistribute(ctx sdk.Context) error {
 	return nil
 }
 
-// distributeDockingRewards distributes emission proportionally
-func (k Keeper) distributeDockingRewards(ctx sdk.Context, totalEmission int64, totalShares int64) {
+// distributeDockingRewards distributes emission proportionally and returns
+// the total paid out
+func (k Keeper) distributeDockingRewards(ctx sdk.Context, totalEmission math.Int, totalShares int64) math.Int {
 	store := ctx.KVStore(k.storeKey)
-	
-	// Iterate through all miners with shares
-	iterator := storetypes.KVStorePrefixIterator(store, DockingEpochSharesKey)
-	defer iterator.Close()
 
+	type minerEpochShares struct {
+		miner  sdk.AccAddress
+		shares int64
+	}
+	var miners []minerEpochShares
+
+	// Collect first: vesting grants are written to the store being iterated
+	iterator := storetypes.KVStorePrefixIterator(store, DockingEpochSharesKey)
 	for ; iterator.Valid(); iterator.Next() {
 		minerShares := int64(binary.BigEndian.Uint64(iterator.Value()))
 		if minerShares == 0 {
 			continue
 		}
+		// Extract miner address from key
+		minerBytes := iterator.Key()[len(DockingEpochSharesKey):]
+		miners = append(miners, minerEpochShares{miner: sdk.AccAddress(minerBytes), shares: minerShares})
+	}
+	iterator.Close()
 
+	distributed := math.ZeroInt()
+	source := fmt.Sprintf("docking_epoch:%d", k.GetDockingEpochNumber(ctx))
+	for _, m := range miners {
 		// Calculate proportional reward: (minerShares / totalShares) * totalEmission
-		reward := (minerShares * totalEmission) / totalShares
-
-		if reward > 0 {
-			// Extract miner address from key
-			minerBytes := iterator.Key()[len(DockingEpochSharesKey):]
-			minerAddr := sdk.AccAddress(minerBytes)
-
-			// Transfer reward from module to miner
-			if k.bankKeeper != nil {
-				rewardCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", reward))
-				err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, minerAddr, rewardCoins)
-				if err != nil {
-					k.Logger(ctx).Error("Failed to send docking reward", 
-						"miner", minerAddr.String(), 
-						"reward", reward,
-						"error", err,
-					)
-					continue
-				}
-			}
-
-			ctx.EventManager().EmitEvent(
-				sdk.NewEvent(
-					"docking_reward_paid",
-					sdk.NewAttribute("miner", minerAddr.String()),
-					sdk.NewAttribute("shares", fmt.Sprintf("%d", minerShares)),
-					sdk.NewAttribute("reward", fmt.Sprintf("%d", reward)),
-					sdk.NewAttribute("share_percent", fmt.Sprintf("%.2f%%", float64(minerShares)*100/float64(totalShares))),
-				),
+		reward := totalEmission.MulRaw(m.shares).QuoRaw(totalShares)
+		if !reward.IsPositive() {
+			continue
+		}
+
+		// Transfer reward from module to miner, vesting part of it
+		_, vesting, err := k.payMinerReward(ctx, m.miner, reward, source)
+		if err != nil {
+			k.Logger(ctx).Error("Failed to send docking reward",
+				"miner", m.miner.String(),
+				"reward", reward,
+				"error", err,
 			)
+			continue
 		}
+		distributed = distributed.Add(reward)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"docking_reward_paid",
+				sdk.NewAttribute("miner", m.miner.String()),
+				sdk.NewAttribute("shares", fmt.Sprintf("%d", m.shares)),
+				sdk.NewAttribute("reward", reward.String()),
+				sdk.NewAttribute("vesting", vesting.String()),
+				sdk.NewAttribute("share_percent", fmt.Sprintf("%.2f%%", float64(m.shares)*100/float64(totalShares))),
+			),
+		)
 	}
+	return distributed
 }
 
 // advanceDockingEpoch resets for new epoch
 func (k Keeper) advanceDockingEpoch(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
 
-	// Clear all miner shares
+	// Clear all miner shares, collecting keys before deleting
 	iterator := storetypes.KVStorePrefixIterator(store, DockingEpochSharesKey)
-	defer iterator.Close()
-	
 	keysToDelete := [][]byte{}
 	for ; iterator.Valid(); iterator.Next() {
 		keysToDelete = append(keysToDelete, iterator.Key())
 	}
+	iterator.Close()
 	for _, key := range keysToDelete {
 		store.Delete(key)
 	}
@@ -1,9 +1,12 @@
 package keeper_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/keeper"
 	"nexus/x/mining/types"
@@ -67,7 +70,7 @@ func TestDockingEpochRewards(t *testing.T) {
 	}
 
 	// Simulate epoch emission calculation
-	emissionRate := k.GetCurrentEmissionRate(ctx)
+	emissionRate := k.GetCurrentEmissionRate(ctx).Int64()
 	epochEmission := emissionRate * keeper.DockingEpochMinutes
 
 	t.Logf("\n--- Epoch emission calculation ---")
@@ -173,3 +176,78 @@ func TestBondMultiplierWeighting(t *testing.T) {
 
 	t.Log("\n✓ Complex ligands reward more per submission!")
 }
+
+// dockingMsgServer exposes the docking handlers, which are not part of the
+// registered Msg service
+type dockingMsgServer interface {
+	CreateDockingJob(context.Context, *types.MsgCreateDockingJob) (*types.MsgCreateDockingJobResponse, error)
+	SubmitDockingResult(context.Context, *types.MsgSubmitDockingResult) (*types.MsgSubmitDockingResultResponse, error)
+}
+
+func TestDockingEpochPaysFromEscrow(t *testing.T) {
+	bankKeeper := NewMockBankKeeper()
+	k, ctx := setupKeeperWithBank(t, bankKeeper)
+	msgServer := keeper.NewMsgServerImpl(k).(dockingMsgServer)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0))
+
+	miner1 := sdk.AccAddress([]byte("miner1_address_____"))
+	miner2 := sdk.AccAddress([]byte("miner2_address_____"))
+
+	// Less is minted than an epoch's emission, so the escrow caps the payout
+	k.SetWorkloadEscrow(ctx, types.WorkloadDocking, math.NewInt(1001))
+	bankKeeper.SetModuleBalance(types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin("unexus", 1001)))
+	if epochEmission := k.GetCurrentEmissionRate(ctx).MulRaw(keeper.DockingEpochMinutes); epochEmission.LT(math.NewInt(1001)) {
+		t.Fatalf("Test assumes epoch emission above escrow, got %s", epochEmission)
+	}
+
+	// The first block's EndBlocker opens the epoch
+	if err := k.EndBlocker(ctx); err != nil {
+		t.Fatalf("EndBlocker failed: %v", err)
+	}
+
+	jobResp, err := msgServer.CreateDockingJob(ctx, &types.MsgCreateDockingJob{
+		Creator: miner1.String(), TargetHash: "target", TotalLigands: 10, IsBackground: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateDockingJob failed: %v", err)
+	}
+
+	// Miner1 docks two baseline ligands, miner2 one
+	for i, miner := range []sdk.AccAddress{miner1, miner1, miner2} {
+		resp, err := msgServer.SubmitDockingResult(ctx, &types.MsgSubmitDockingResult{
+			Miner: miner.String(), JobId: jobResp.JobId, LigandId: fmt.Sprintf("lig_%d", i),
+			BindingScore: -5.0, RotatableBonds: 5,
+		})
+		if err != nil {
+			t.Fatalf("SubmitDockingResult failed: %v", err)
+		}
+		if resp.Shares != 1000 {
+			t.Fatalf("Expected 1000 shares per baseline ligand, got %d", resp.Shares)
+		}
+	}
+	if total := k.GetDockingTotalShares(ctx); total != 3000 {
+		t.Fatalf("Expected 3000 epoch shares, got %d", total)
+	}
+
+	// EndBlocker closes the epoch once DockingEpochMinutes have passed
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(11 * time.Minute))
+	if err := k.EndBlocker(ctx); err != nil {
+		t.Fatalf("EndBlocker failed: %v", err)
+	}
+
+	if got := bankKeeper.Balances[miner1.String()].AmountOf("unexus"); !got.Equal(math.NewInt(667)) {
+		t.Errorf("Expected miner1 paid 667, got %s", got)
+	}
+	if got := bankKeeper.Balances[miner2.String()].AmountOf("unexus"); !got.Equal(math.NewInt(333)) {
+		t.Errorf("Expected miner2 paid 333, got %s", got)
+	}
+	if escrow := k.GetEmissionEscrow(ctx); !escrow.Equal(math.NewInt(1)) {
+		t.Errorf("Expected 1 left in emission escrow, got %s", escrow)
+	}
+	if epoch := k.GetDockingEpochNumber(ctx); epoch != 2 {
+		t.Errorf("Expected docking epoch 2, got %d", epoch)
+	}
+	if _, broken := keeper.ModuleBalanceInvariant(k)(ctx); broken {
+		t.Error("Module balance invariant broken after docking epoch")
+	}
+}
@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// EMISSION ALLOCATION
+// ============================================
+//
+// Each minute of emission is one budget, split across workloads by the
+// governance weights in Params. Minted emission goes straight into a
+// per-workload escrow and every payout draws only from its own workload's
+// escrow, at the workload's share of the current rate:
+//
+//   synthetic: network-generated Ising background jobs
+//   public:    public submissions and paid jobs
+//   docking:   docking epochs
+//   protein:   protein folding jobs (public or paid)
+//
+// A minute of emission can therefore only be paid once, whichever workloads
+// claim it.
+
+// WorkloadEscrowKeyPrefix prefixes each workload's escrow, keyed by name
+var WorkloadEscrowKeyPrefix = []byte("emission_escrow/")
+
+// GetWorkloadEscrow returns the emission escrowed for a workload
+func (k Keeper) GetWorkloadEscrow(ctx sdk.Context, workload string) math.Int {
+	return k.getInt(ctx, append(WorkloadEscrowKeyPrefix, []byte(workload)...))
+}
+
+// SetWorkloadEscrow sets the emission escrowed for a workload
+func (k Keeper) SetWorkloadEscrow(ctx sdk.Context, workload string, amount math.Int) {
+	k.setInt(ctx, append(WorkloadEscrowKeyPrefix, []byte(workload)...), amount)
+}
+
+// SplitEmission divides amount across workloads by weight. Truncation dust
+// goes to the first workload with a positive weight.
+func SplitEmission(params types.Params, amount math.Int) map[string]math.Int {
+	split := make(map[string]math.Int, len(types.EmissionWorkloads))
+	total := params.TotalEmissionWeight()
+	if total == 0 {
+		return split
+	}
+
+	allocated := math.ZeroInt()
+	first := ""
+	for _, workload := range types.EmissionWorkloads {
+		weight := params.EmissionWeight(workload)
+		if weight == 0 {
+			continue
+		}
+		if first == "" {
+			first = workload
+		}
+		share := amount.Mul(math.NewIntFromUint64(weight)).Quo(math.NewIntFromUint64(total))
+		split[workload] = share
+		allocated = allocated.Add(share)
+	}
+	split[first] = split[first].Add(amount.Sub(allocated))
+	return split
+}
+
+// AllocateEmission adds newly escrowed emission to the workload escrows
+func (k Keeper) AllocateEmission(ctx sdk.Context, amount math.Int) {
+	split := SplitEmission(k.GetParams(ctx), amount)
+	for _, workload := range types.EmissionWorkloads {
+		if share, ok := split[workload]; ok && share.IsPositive() {
+			k.SetWorkloadEscrow(ctx, workload, k.GetWorkloadEscrow(ctx, workload).Add(share))
+		}
+	}
+}
+
+// WorkloadEmissionRate returns a workload's per-minute share of the current
+// emission rate
+func (k Keeper) WorkloadEmissionRate(ctx sdk.Context, workload string) math.Int {
+	params := k.GetParams(ctx)
+	total := params.TotalEmissionWeight()
+	if total == 0 {
+		return math.ZeroInt()
+	}
+	weight := math.NewIntFromUint64(params.EmissionWeight(workload))
+	return k.GetCurrentEmissionRate(ctx).Mul(weight).Quo(math.NewIntFromUint64(total))
+}
+
+// JobWorkload returns the workload whose escrow funds a job's emission
+func JobWorkload(job types.Job) string {
+	switch {
+	case job.ProblemType == "protein_folding":
+		return types.WorkloadProtein
+	case job.IsBackground && job.Customer == BackgroundJobCustomer:
+		return types.WorkloadSynthetic
+	default:
+		return types.WorkloadPublic
+	}
+}
+
+// EmissionAllocation reports each workload's weight, budget and escrow
+func (q queryServer) EmissionAllocation(goCtx context.Context, req *types.QueryEmissionAllocationRequest) (*types.QueryEmissionAllocationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := q.Keeper.GetParams(ctx)
+
+	resp := &types.QueryEmissionAllocationResponse{
+		EmissionRate: q.Keeper.GetCurrentEmissionRate(ctx),
+		TotalWeight:  params.TotalEmissionWeight(),
+		Workloads:    make([]types.WorkloadAllocation, 0, len(types.EmissionWorkloads)),
+		Unallocated:  q.Keeper.GetUnallocatedEscrow(ctx),
+	}
+	for _, workload := range types.EmissionWorkloads {
+		resp.Workloads = append(resp.Workloads, types.WorkloadAllocation{
+			Workload: workload,
+			Weight:   params.EmissionWeight(workload),
+			Rate:     q.Keeper.WorkloadEmissionRate(ctx, workload),
+			Escrow:   q.Keeper.GetWorkloadEscrow(ctx, workload),
+		})
+	}
+	return resp, nil
+}
@@ -1,28 +1,23 @@
 package keeper
 
 import (
-	"fmt"
+	"context"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"nexus/x/mining/types"
 )
 
-// Emission schedule constants
-const (
-	// BaseEmissionPerMinute is the starting emission rate (Epoch 1)
-	// 35,950 NEX per minute = 35,950,000,000 unex per minute
-	BaseEmissionPerMinute = 35_950_000_000 // in unex (smallest unit)
+// BlocksPerMinute with 2-second block time
+const BlocksPerMinute = 30
 
-	// EpochDurationMinutes is 2 years in minutes
-	// 2 years * 365.25 days * 24 hours * 60 minutes
-	EpochDurationMinutes = 2 * 365.25 * 24 * 60 // ~1,051,200 minutes
+// MaxProjectionHorizonMinutes bounds SupplyProjection's horizon (1000 years)
+const MaxProjectionHorizonMinutes = 1000 * 525960
 
-	// MinEmissionPercent is the perpetual floor (1.5% of base = epoch 8+)
-	MinEmissionPercent = 15 // 1.5% represented as 15/1000
-
-	// BlocksPerMinute with 2-second block time
-	BlocksPerMinute = 30
-)
+// maxMinute is the latest minute an int64 can hold
+const maxMinute = 1<<63 - 1
 
 // EmissionEpoch defines the emission rate for each epoch
 type EmissionEpoch struct {
@@ -31,43 +26,111 @@ type EmissionEpoch struct {
 	RatePercent int64 // Percent of base rate (1000 = 100%)
 }
 
-// GetEmissionEpochs returns the emission schedule
-func GetEmissionEpochs() []EmissionEpoch {
-	epochMins := int64(EpochDurationMinutes)
-	return []EmissionEpoch{
-		{StartMinute: 0, EndMinute: epochMins, RatePercent: 1000},                     // Epoch 1: 100%
-		{StartMinute: epochMins, EndMinute: epochMins * 2, RatePercent: 500},          // Epoch 2: 50%
-		{StartMinute: epochMins * 2, EndMinute: epochMins * 3, RatePercent: 250},      // Epoch 3: 25%
-		{StartMinute: epochMins * 3, EndMinute: epochMins * 4, RatePercent: 125},      // Epoch 4: 12.5%
-		{StartMinute: epochMins * 4, EndMinute: epochMins * 5, RatePercent: 62},       // Epoch 5: 6.25%
-		{StartMinute: epochMins * 5, EndMinute: epochMins * 6, RatePercent: 31},       // Epoch 6: 3.125%
-		{StartMinute: epochMins * 6, EndMinute: epochMins * 7, RatePercent: 15},       // Epoch 7: 1.5625%
-		{StartMinute: epochMins * 7, EndMinute: -1, RatePercent: MinEmissionPercent},  // Epoch 8+: 1.5% perpetual
+// GetEmissionEpochs expands the governance schedule in params: one epoch
+// per decay table entry, followed by the perpetual floor
+func GetEmissionEpochs(params types.Params) []EmissionEpoch {
+	epochMins := params.EmissionEpochMinutes
+	epochs := make([]EmissionEpoch, 0, len(params.EmissionDecayPermille)+1)
+	for i, rate := range params.EmissionDecayPermille {
+		epochs = append(epochs, EmissionEpoch{
+			StartMinute: epochMins * int64(i),
+			EndMinute:   epochMins * int64(i+1),
+			RatePercent: int64(rate),
+		})
+	}
+	return append(epochs, EmissionEpoch{
+		StartMinute: epochMins * int64(len(params.EmissionDecayPermille)),
+		EndMinute:   -1,
+		RatePercent: int64(params.EmissionFloorPermille),
+	})
+}
+
+// emissionEpochIndex returns the index into GetEmissionEpochs of the epoch
+// containing minutesSinceGenesis
+func emissionEpochIndex(epochs []EmissionEpoch, minutesSinceGenesis int64) int {
+	for i, epoch := range epochs {
+		if epoch.EndMinute == -1 || minutesSinceGenesis < epoch.EndMinute {
+			return i
+		}
+	}
+	return len(epochs) - 1
+}
+
+// EmissionRate returns the per-minute emission of an epoch in unexus
+func EmissionRate(params types.Params, epoch EmissionEpoch) math.Int {
+	return params.EmissionBaseRate.MulRaw(epoch.RatePercent).QuoRaw(1000)
+}
+
+// ScheduledEmission returns what the schedule emits over [from, to), in
+// minutes since genesis, ignoring the supply cap
+func ScheduledEmission(params types.Params, from, to int64) math.Int {
+	total := math.ZeroInt()
+	if from < 0 {
+		from = 0
+	}
+	for _, epoch := range GetEmissionEpochs(params) {
+		if from >= to {
+			break
+		}
+		if epoch.EndMinute != -1 && from >= epoch.EndMinute {
+			continue
+		}
+		end := to
+		if epoch.EndMinute != -1 && epoch.EndMinute < end {
+			end = epoch.EndMinute
+		}
+		total = total.Add(EmissionRate(params, epoch).MulRaw(end - from))
+		from = end
 	}
+	return total
 }
 
 // Storage keys
 var (
-	EmissionEscrowKey       = []byte("emission_escrow")
-	LastEmissionMinuteKey   = []byte("last_emission_minute")
-	GenesisMinuteKey        = []byte("genesis_minute")
+	EmissionEscrowKey        = []byte("emission_escrow") // emission not yet split across workloads
+	LastEmissionMinuteKey    = []byte("last_emission_minute")
+	GenesisMinuteKey         = []byte("genesis_minute")
 	CurrentJobStartMinuteKey = []byte("current_job_start_minute")
+	TotalEmissionMintedKey   = []byte("total_emission_minted")
 )
 
-// GetEmissionEscrow returns the accumulated emission escrow
-func (k Keeper) GetEmissionEscrow(ctx sdk.Context) int64 {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(EmissionEscrowKey)
-	if bz == nil {
-		return 0
+// GetEmissionEscrow returns the total emission escrow: every workload's
+// escrow plus any emission not yet allocated
+func (k Keeper) GetEmissionEscrow(ctx sdk.Context) math.Int {
+	total := k.GetUnallocatedEscrow(ctx)
+	for _, workload := range types.EmissionWorkloads {
+		total = total.Add(k.GetWorkloadEscrow(ctx, workload))
 	}
-	return int64(bytesToUint64(bz))
+	return total
 }
 
-// SetEmissionEscrow sets the emission escrow amount
-func (k Keeper) SetEmissionEscrow(ctx sdk.Context, amount int64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Set(EmissionEscrowKey, uint64ToBytes(uint64(amount)))
+// GetUnallocatedEscrow returns emission escrowed before the workload split
+func (k Keeper) GetUnallocatedEscrow(ctx sdk.Context) math.Int {
+	return k.getInt(ctx, EmissionEscrowKey)
+}
+
+// GetTotalEmissionMinted returns the cumulative emission minted since genesis
+func (k Keeper) GetTotalEmissionMinted(ctx sdk.Context) math.Int {
+	return k.getInt(ctx, TotalEmissionMintedKey)
+}
+
+// SetTotalEmissionMinted sets the cumulative emission minted since genesis
+func (k Keeper) SetTotalEmissionMinted(ctx sdk.Context, amount math.Int) {
+	k.setInt(ctx, TotalEmissionMintedKey, amount)
+}
+
+// RemainingEmissionSupply returns how much may still be minted before the
+// supply cap, and false if the cap is disabled
+func (k Keeper) RemainingEmissionSupply(ctx sdk.Context) (math.Int, bool) {
+	supplyCap := k.GetParams(ctx).EmissionSupplyCap
+	if supplyCap.IsZero() {
+		return math.Int{}, false
+	}
+	remaining := supplyCap.Sub(k.GetTotalEmissionMinted(ctx))
+	if remaining.IsNegative() {
+		return math.ZeroInt(), true
+	}
+	return remaining, true
 }
 
 // GetLastEmissionMinute returns the last minute at which emissions were added
@@ -121,37 +184,53 @@ func (k Keeper) SetCurrentJobStartMinute(ctx sdk.Context, minute int64) {
 	store.Set(CurrentJobStartMinuteKey, uint64ToBytes(uint64(minute)))
 }
 
-// GetCurrentEmissionRate returns the emission rate for the current epoch
-func (k Keeper) GetCurrentEmissionRate(ctx sdk.Context) int64 {
-	genesisMinute := k.GetGenesisMinute(ctx)
-	currentMinute := ctx.BlockTime().Unix() / 60
-	minutesSinceGenesis := currentMinute - genesisMinute
-
-	epochs := GetEmissionEpochs()
-	for _, epoch := range epochs {
-		if epoch.EndMinute == -1 || minutesSinceGenesis < epoch.EndMinute {
-			// Found the current epoch
-			return (BaseEmissionPerMinute * epoch.RatePercent) / 1000
-		}
-	}
+// minutesSinceGenesis returns the current block time in minutes since genesis
+func (k Keeper) minutesSinceGenesis(ctx sdk.Context) int64 {
+	return ctx.BlockTime().Unix()/60 - k.GetGenesisMinute(ctx)
+}
 
-	// Fallback to perpetual rate
-	return (BaseEmissionPerMinute * MinEmissionPercent) / 1000
+// GetCurrentEmissionRate returns the emission rate for the current epoch
+func (k Keeper) GetCurrentEmissionRate(ctx sdk.Context) math.Int {
+	params := k.GetParams(ctx)
+	epochs := GetEmissionEpochs(params)
+	return EmissionRate(params, epochs[emissionEpochIndex(epochs, k.minutesSinceGenesis(ctx))])
 }
 
-// GetCurrentEpoch returns the current epoch number (1-8+)
+// GetCurrentEpoch returns the current epoch number, starting at 1; the
+// perpetual epoch is len(decay table) + 1
 func (k Keeper) GetCurrentEpoch(ctx sdk.Context) int {
+	epochs := GetEmissionEpochs(k.GetParams(ctx))
+	return emissionEpochIndex(epochs, k.minutesSinceGenesis(ctx)) + 1
+}
+
+// GetEmissionInfo summarizes the current position in the emission schedule
+func (k Keeper) GetEmissionInfo(ctx sdk.Context) types.QueryEmissionInfoResponse {
+	params := k.GetParams(ctx)
+	epochs := GetEmissionEpochs(params)
 	genesisMinute := k.GetGenesisMinute(ctx)
-	currentMinute := ctx.BlockTime().Unix() / 60
-	minutesSinceGenesis := currentMinute - genesisMinute
+	minutesSinceGenesis := k.minutesSinceGenesis(ctx)
 
-	epochs := GetEmissionEpochs()
-	for i, epoch := range epochs {
-		if epoch.EndMinute == -1 || minutesSinceGenesis < epoch.EndMinute {
-			return i + 1
-		}
+	index := emissionEpochIndex(epochs, minutesSinceGenesis)
+	epoch := epochs[index]
+	emissionRate := EmissionRate(params, epoch)
+
+	minutesUntilNext := int64(-1) // Perpetual epoch has no next epoch
+	nextEpochRate := emissionRate // Same rate perpetually
+	if epoch.EndMinute != -1 {
+		minutesUntilNext = epoch.EndMinute - minutesSinceGenesis
+		nextEpochRate = EmissionRate(params, epochs[index+1])
+	}
+
+	return types.QueryEmissionInfoResponse{
+		CurrentEpoch:     int32(index + 1),
+		EmissionRate:     emissionRate,
+		EmissionEscrow:   k.GetEmissionEscrow(ctx),
+		GenesisTime:      genesisMinute * 60,
+		MinutesIntoEpoch: minutesSinceGenesis - epoch.StartMinute,
+		MinutesUntilNext: minutesUntilNext,
+		EpochDuration:    params.EmissionEpochMinutes,
+		NextEpochRate:    nextEpochRate,
 	}
-	return 8 // Perpetual epoch
 }
 
 // ProcessEmissions is called every block to accumulate emissions into escrow
@@ -174,32 +253,48 @@ func (k Keeper) ProcessEmissions(ctx sdk.Context) error {
 	// Calculate minutes elapsed (handle multiple minutes if blocks were slow)
 	minutesElapsed := currentMinute - lastMinute
 
-	// Get current emission rate
-	emissionRate := k.GetCurrentEmissionRate(ctx)
+	// Emissions follow the schedule across epoch boundaries
+	params := k.GetParams(ctx)
+	genesisMinute := k.GetGenesisMinute(ctx)
+	emissionsToAdd := ScheduledEmission(params, lastMinute-genesisMinute, currentMinute-genesisMinute)
 
-	// Calculate total emissions to add
-	emissionsToAdd := emissionRate * minutesElapsed
+	// Never mint past the supply cap
+	capped := false
+	if remaining, ok := k.RemainingEmissionSupply(ctx); ok && emissionsToAdd.GT(remaining) {
+		emissionsToAdd = remaining
+		capped = true
+	}
 
 	// Mint new tokens to module account
-	if k.bankKeeper != nil && emissionsToAdd > 0 {
-		emissionCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", emissionsToAdd))
+	if k.bankKeeper != nil && emissionsToAdd.IsPositive() {
+		emissionCoins := sdk.NewCoins(sdk.NewCoin("unexus", emissionsToAdd))
 		err := k.bankKeeper.MintCoins(ctx, types.ModuleName, emissionCoins)
 		if err != nil {
 			k.Logger(ctx).Error("Failed to mint emission coins", "error", err)
 			return err
 		}
 
-		// Add to escrow
-		currentEscrow := k.GetEmissionEscrow(ctx)
-		k.SetEmissionEscrow(ctx, currentEscrow+emissionsToAdd)
+		// Split across workload escrows and add to the minted total
+		k.AllocateEmission(ctx, emissionsToAdd)
+		k.SetTotalEmissionMinted(ctx, k.GetTotalEmissionMinted(ctx).Add(emissionsToAdd))
 
 		k.Logger(ctx).Debug("Emissions accumulated",
 			"minutes_elapsed", minutesElapsed,
-			"rate_per_minute", emissionRate,
 			"emissions_added", emissionsToAdd,
 			"total_escrow", k.GetEmissionEscrow(ctx),
+			"total_minted", k.GetTotalEmissionMinted(ctx),
 			"epoch", k.GetCurrentEpoch(ctx),
 		)
+
+		if capped {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					"emission_supply_cap_reached",
+					sdk.NewAttribute("supply_cap", params.EmissionSupplyCap.String()),
+					sdk.NewAttribute("total_minted", k.GetTotalEmissionMinted(ctx).String()),
+				),
+			)
+		}
 	}
 
 	k.SetLastEmissionMinute(ctx, currentMinute)
@@ -208,45 +303,110 @@ func (k Keeper) ProcessEmissions(ctx sdk.Context) error {
 }
 
 // CalculateEmissionReward calculates the emission reward for solving a job
-// Based on minutes elapsed since job was posted
-func (k Keeper) CalculateEmissionReward(ctx sdk.Context, job types.Job) int64 {
-	currentMinute := ctx.BlockTime().Unix() / 60
-	jobStartMinute := job.CreatedAt / 60
+// Based on the minutes the job was open, at the job's workload rate: from
+// activation (CreatedAt is reset when a queued job activates) until it
+// finished or reached its deadline, whichever came first. A job settled
+// lazily long after it finished earns nothing for the time in between.
+func (k Keeper) CalculateEmissionReward(ctx sdk.Context, job types.Job) math.Int {
+	end := ctx.BlockTime().Unix()
+	if job.FinishedAt != 0 {
+		end = job.FinishedAt
+	}
+	if job.Deadline != 0 && job.Deadline < end {
+		end = job.Deadline
+	}
 
-	minutesElapsed := currentMinute - jobStartMinute
+	minutesElapsed := end/60 - job.CreatedAt/60
 	if minutesElapsed < 1 {
 		minutesElapsed = 1 // Minimum 1 minute
 	}
 
 	// Get the average emission rate over the job duration
 	// For simplicity, use current rate (jobs typically don't span epochs)
-	emissionRate := k.GetCurrentEmissionRate(ctx)
+	emissionRate := k.WorkloadEmissionRate(ctx, JobWorkload(job))
 
-	return emissionRate * minutesElapsed
+	return emissionRate.MulRaw(minutesElapsed)
 }
 
-// ClaimEmissionReward releases emission escrow to the miner for solving a job
-func (k Keeper) ClaimEmissionReward(ctx sdk.Context, job types.Job) int64 {
-	emissionReward := k.CalculateEmissionReward(ctx, job)
-	currentEscrow := k.GetEmissionEscrow(ctx)
+// CapReachedMinute returns the minute since genesis at which minting from
+// `from` onwards exhausts remaining supply, or -1 if it never does
+func CapReachedMinute(params types.Params, from int64, remaining math.Int) int64 {
+	if !remaining.IsPositive() {
+		return from
+	}
+	for _, epoch := range GetEmissionEpochs(params) {
+		if epoch.EndMinute != -1 && from >= epoch.EndMinute {
+			continue
+		}
+		rate := EmissionRate(params, epoch)
+		if epoch.EndMinute == -1 {
+			if !rate.IsPositive() {
+				return -1
+			}
+		} else {
+			segment := rate.MulRaw(epoch.EndMinute - from)
+			if segment.LT(remaining) {
+				remaining = remaining.Sub(segment)
+				from = epoch.EndMinute
+				continue
+			}
+		}
+		// Round up: the cap is hit during the last partial minute. A minute
+		// past int64 is clamped rather than wrapped.
+		minutes := remaining.Add(rate).SubRaw(1).Quo(rate)
+		if !minutes.IsInt64() || minutes.Int64() > maxMinute-from {
+			return maxMinute
+		}
+		return from + minutes.Int64()
+	}
+	return -1
+}
+
+// SupplyProjection reports emission minted to date against the supply cap
+// and projects the minted total forward along the schedule
+func (q queryServer) SupplyProjection(goCtx context.Context, req *types.QuerySupplyProjectionRequest) (*types.QuerySupplyProjectionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if req.HorizonMinutes < 0 || req.HorizonMinutes > MaxProjectionHorizonMinutes {
+		return nil, status.Errorf(codes.InvalidArgument, "horizon must be between 0 and %d minutes", MaxProjectionHorizonMinutes)
+	}
+
+	params := q.Keeper.GetParams(ctx)
+	genesisMinute := q.Keeper.GetGenesisMinute(ctx)
+	minted := q.Keeper.GetTotalEmissionMinted(ctx)
 
-	// Cap at available escrow
-	if emissionReward > currentEscrow {
-		emissionReward = currentEscrow
+	// Minting has caught up to the last processed minute
+	from := ctx.BlockTime().Unix()/60 - genesisMinute
+	if last := q.Keeper.GetLastEmissionMinute(ctx); last != 0 {
+		from = last - genesisMinute
 	}
 
-	// Deduct from escrow (tokens already in module from minting)
-	k.SetEmissionEscrow(ctx, currentEscrow-emissionReward)
+	to := from + req.HorizonMinutes
+	if req.HorizonMinutes == 0 {
+		to = params.EmissionEpochMinutes * int64(len(params.EmissionDecayPermille))
+		if to < from {
+			to = from
+		}
+	}
 
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			"emission_reward_claimed",
-			sdk.NewAttribute("job_id", job.Id),
-			sdk.NewAttribute("minutes_to_solve", fmt.Sprintf("%d", (ctx.BlockTime().Unix()-job.CreatedAt)/60)),
-			sdk.NewAttribute("emission_reward", fmt.Sprintf("%d", emissionReward)),
-			sdk.NewAttribute("remaining_escrow", fmt.Sprintf("%d", k.GetEmissionEscrow(ctx))),
-		),
-	)
+	projected := minted.Add(ScheduledEmission(params, from, to))
+	remaining, capped := q.Keeper.RemainingEmissionSupply(ctx)
+	capMinute := int64(-1)
+	if capped {
+		if projected.GT(params.EmissionSupplyCap) {
+			projected = params.EmissionSupplyCap
+		}
+		capMinute = CapReachedMinute(params, from, remaining)
+	} else {
+		remaining = math.ZeroInt()
+	}
 
-	return emissionReward
+	return &types.QuerySupplyProjectionResponse{
+		MintedToDate:     minted,
+		SupplyCap:        params.EmissionSupplyCap,
+		RemainingSupply:  remaining,
+		ProjectedTotal:   projected,
+		ProjectedMinute:  to,
+		CurrentMinute:    from,
+		CapReachedMinute: capMinute,
+	}, nil
 }
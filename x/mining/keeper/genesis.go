@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"encoding/hex"
+
+	"cosmossdk.io/math"
 	"cosmossdk.io/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
@@ -22,11 +25,71 @@ func (k Keeper) InitGenesis(ctx sdk.Context, gs types.GenesisState) {
 	}
 	k.SetLastCheckpointID(ctx, gs.LastCheckpointID)
 
+	// Set miner groups
+	for _, group := range gs.MinerGroups {
+		k.SetMinerGroup(ctx, group)
+	}
+
+	// Set validator mining records and miner attribution links
+	for _, record := range gs.ValidatorRecords {
+		if record.TotalRewards.IsNil() {
+			record.TotalRewards = math.ZeroInt()
+		}
+		k.SetValidatorMiningRecord(ctx, record)
+	}
+	for _, link := range gs.MinerValidatorLinks {
+		k.SetMinerValidator(ctx, link.Miner, link.Validator)
+	}
+	for _, link := range gs.AcceptedMiners {
+		k.SetMinerAccepted(ctx, link.Validator, link.Miner)
+	}
+
+	// Set mining pools
+	for _, pool := range gs.Pools {
+		if pool.TotalEarned.IsNil() {
+			pool.TotalEarned = math.ZeroInt()
+		}
+		if pool.TotalFees.IsNil() {
+			pool.TotalFees = math.ZeroInt()
+		}
+		k.SetPool(ctx, pool)
+	}
+
+	// Set the miner registry; unbonding miners are queued for bond return
+	for _, reg := range gs.MinerRegistrations {
+		k.SetMinerRegistration(ctx, reg)
+	}
+
 	// Set validator reward pool
 	k.SetValidatorRewardPool(ctx, gs.ValidatorRewardPool)
 
-	// Set emission escrow
-	k.SetEmissionEscrow(ctx, gs.EmissionEscrow)
+	// Set workload emission escrows, then split any unallocated escrow
+	for _, escrow := range gs.WorkloadEscrows {
+		k.SetWorkloadEscrow(ctx, escrow.Workload, escrow.Amount)
+	}
+	if !gs.EmissionEscrow.IsNil() && gs.EmissionEscrow.IsPositive() {
+		k.AllocateEmission(ctx, gs.EmissionEscrow)
+	}
+
+	// Set the base fee, starting at the params floor when unset
+	if gs.BaseFee.IsNil() {
+		k.SetBaseFee(ctx, gs.Params.BaseFeeFloor())
+	} else {
+		k.SetBaseFee(ctx, math.LegacyMaxDec(gs.BaseFee, gs.Params.BaseFeeFloor()))
+	}
+
+	// Set cumulative emission minted against the supply cap
+	k.SetTotalEmissionMinted(ctx, gs.TotalEmissionMinted)
+
+	// Set algorithm registry
+	for _, algo := range gs.Algorithms {
+		k.SetAlgorithm(ctx, algo)
+	}
+
+	// Set randomness beacon seed
+	if seed, err := hex.DecodeString(gs.BeaconSeed); err == nil && len(seed) > 0 {
+		k.SetBeaconSeed(ctx, seed)
+	}
 
 	// Set difficulty/problem size
 	k.SetCurrentProblemSize(ctx, gs.CurrentProblemSize)
@@ -59,15 +122,80 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 		}
 	}
 
+	// Collect all miner groups
+	minerGroups := []types.MinerGroup{}
+	k.IterateMinerGroups(ctx, func(group types.MinerGroup) bool {
+		minerGroups = append(minerGroups, group)
+		return false
+	})
+
+	// Collect all algorithms
+	algorithms := []types.Algorithm{}
+	k.IterateAlgorithms(ctx, func(algo types.Algorithm) bool {
+		algorithms = append(algorithms, algo)
+		return false
+	})
+
+	// Collect validator mining records and miner attribution links
+	validatorRecords := []types.ValidatorMiningRecord{}
+	k.IterateValidatorMiningRecords(ctx, func(record types.ValidatorMiningRecord) bool {
+		validatorRecords = append(validatorRecords, record)
+		return false
+	})
+	minerValidatorLinks := []types.MinerValidatorLink{}
+	k.IterateMinerValidators(ctx, func(link types.MinerValidatorLink) bool {
+		minerValidatorLinks = append(minerValidatorLinks, link)
+		return false
+	})
+	acceptedMiners := []types.MinerValidatorLink{}
+	k.IterateAcceptedMiners(ctx, func(link types.MinerValidatorLink) bool {
+		acceptedMiners = append(acceptedMiners, link)
+		return false
+	})
+
+	// Collect mining pools
+	pools := []types.MiningPool{}
+	k.IteratePools(ctx, func(pool types.MiningPool) bool {
+		pools = append(pools, pool)
+		return false
+	})
+
+	// Collect the miner registry
+	minerRegistrations := []types.MinerRegistration{}
+	k.IterateMinerRegistrations(ctx, func(reg types.MinerRegistration) bool {
+		minerRegistrations = append(minerRegistrations, reg)
+		return false
+	})
+
+	// Collect workload emission escrows
+	workloadEscrows := []types.WorkloadEscrow{}
+	for _, workload := range types.EmissionWorkloads {
+		workloadEscrows = append(workloadEscrows, types.WorkloadEscrow{
+			Workload: workload,
+			Amount:   k.GetWorkloadEscrow(ctx, workload),
+		})
+	}
+
 	return &types.GenesisState{
 		Params:              k.GetParams(ctx),
 		Jobs:                jobs,
 		Checkpoints:         checkpoints,
 		ValidatorRewardPool: k.GetValidatorRewardPool(ctx),
-		EmissionEscrow:      k.GetEmissionEscrow(ctx),
+		EmissionEscrow:      k.GetUnallocatedEscrow(ctx),
 		LastCheckpointID:    lastCpID,
 		CurrentProblemSize:  k.GetCurrentProblemSize(ctx),
 		BackgroundJobCount:  k.GetBackgroundJobCount(ctx),
+		MinerGroups:         minerGroups,
+		BeaconSeed:          hex.EncodeToString(k.GetBeaconSeed(ctx)),
+		Algorithms:          algorithms,
+		TotalEmissionMinted: k.GetTotalEmissionMinted(ctx),
+		ValidatorRecords:    validatorRecords,
+		MinerValidatorLinks: minerValidatorLinks,
+		WorkloadEscrows:     workloadEscrows,
+		Pools:               pools,
+		BaseFee:             k.GetBaseFee(ctx),
+		MinerRegistrations:  minerRegistrations,
+		AcceptedMiners:      acceptedMiners,
 	}
 }
 
@@ -0,0 +1,132 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining"
+	"nexus/x/mining/keeper"
+	"nexus/x/mining/types"
+)
+
+// TestModuleGenesisRoundTrip exports through the app module's JSON genesis
+// and imports into a fresh store, as a chain export/restart would
+func TestModuleGenesisRoundTrip(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	valAddr := sdk.ValAddress([]byte("validator_operator__"))
+	// Lifetime rewards beyond int64 survive the round trip
+	totalRewards := math.NewIntFromUint64(1 << 63).MulRaw(4)
+	k.SetValidatorMiningRecord(ctx, types.ValidatorMiningRecord{
+		ValidatorAddr: valAddr.String(), TotalShares: 500, TotalRewards: totalRewards, JobsCompleted: 1, LastActiveTime: 1_700_000_100,
+	})
+	k.SetMinerValidator(ctx, testMiner, valAddr.String())
+	k.SetMinerAccepted(ctx, valAddr.String(), testMiner)
+
+	exported := mining.NewAppModule(cdc, k).ExportGenesis(ctx, cdc)
+	var gs types.GenesisState
+	cdc.MustUnmarshalJSON(exported, &gs)
+	if err := gs.Validate(); err != nil {
+		t.Fatalf("Exported genesis invalid: %v", err)
+	}
+
+	k2, ctx2 := setupKeeper(t)
+	mining.NewAppModule(cdc, k2).InitGenesis(ctx2, cdc, exported)
+
+	if record, found := k2.GetValidatorMiningRecord(ctx2, valAddr); !found || !record.TotalRewards.Equal(totalRewards) || record.JobsCompleted != 1 {
+		t.Errorf("Expected validator record restored, got %+v (found %t)", record, found)
+	}
+	if validator, found := k2.GetLinkedValidator(ctx2, testMiner); !found || validator != valAddr.String() {
+		t.Errorf("Expected miner link restored, got %q (found %t)", validator, found)
+	}
+
+	if reexported := mining.NewAppModule(cdc, k2).ExportGenesis(ctx2, cdc); !bytes.Equal(exported, reexported) {
+		t.Errorf("Re-exported genesis differs:\n%s\n%s", exported, reexported)
+	}
+}
+
+// TestGenesisRestoresMiningState covers every genesis field that carries
+// state beyond params: miner groups, beacon seed, workload escrows, pools,
+// base fee and the miner registry with its unbonding queue
+func TestGenesisRestoresMiningState(t *testing.T) {
+	bankKeeper := NewMockBankKeeper()
+	k, ctx := setupKeeperWithBank(t, bankKeeper)
+	msgServer := keeper.NewMsgServerImpl(k)
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	start := time.Unix(1_700_000_000, 0)
+	ctx = ctx.WithBlockTime(start)
+
+	operator := sdk.AccAddress([]byte("pool_operator______")).String()
+	unbonder := sdk.AccAddress([]byte("unbonding_miner____"))
+	minerAddr, _ := sdk.AccAddressFromBech32(testMiner)
+	bond := sdk.NewCoins(sdk.NewCoin("unexus", types.DefaultMinMinerBond))
+	bankKeeper.SetBalance(minerAddr, bond)
+	bankKeeper.SetBalance(unbonder, bond)
+
+	k.SetMinerGroup(ctx, types.MinerGroup{Name: "lab", Owner: testCustomer, Members: []string{testMiner}})
+	k.SetBeaconSeed(ctx, []byte("beacon seed"))
+	bankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin("unexus", 7000)))
+	k.SetWorkloadEscrow(ctx, types.WorkloadDocking, math.NewInt(7000))
+	k.SetPool(ctx, types.MiningPool{
+		Id: "lab-pool", Operator: operator, FeePercent: 5, Members: []string{testMiner}, OpenJoin: true,
+		CreatedAt: start.Unix(), TotalEarned: math.NewInt(900), TotalFees: math.NewInt(45),
+	})
+	k.SetBaseFee(ctx, math.LegacyNewDecWithPrec(25, 4))
+	for _, miner := range []string{testMiner, unbonder.String()} {
+		if _, err := msgServer.RegisterMiner(ctx, &types.MsgRegisterMiner{Miner: miner, Bond: bond, HardwareClass: types.HardwareClassGPU}); err != nil {
+			t.Fatalf("RegisterMiner failed: %v", err)
+		}
+	}
+	unbond, err := msgServer.UnbondMiner(ctx, &types.MsgUnbondMiner{Miner: unbonder.String()})
+	if err != nil {
+		t.Fatalf("UnbondMiner failed: %v", err)
+	}
+
+	exported := mining.NewAppModule(cdc, k).ExportGenesis(ctx, cdc)
+
+	// The bank module carries the balances; the mining store starts empty
+	k2, ctx2 := setupKeeperWithBank(t, bankKeeper)
+	ctx2 = ctx2.WithBlockTime(start)
+	mining.NewAppModule(cdc, k2).InitGenesis(ctx2, cdc, exported)
+
+	if group, found := k2.GetMinerGroup(ctx2, "lab"); !found || group.Owner != testCustomer || len(group.Members) != 1 {
+		t.Errorf("Expected miner group restored, got %+v (found %t)", group, found)
+	}
+	if seed := k2.GetBeaconSeed(ctx2); string(seed) != "beacon seed" {
+		t.Errorf("Expected beacon seed restored, got %q", seed)
+	}
+	if escrow := k2.GetWorkloadEscrow(ctx2, types.WorkloadDocking); escrow.Int64() != 7000 {
+		t.Errorf("Expected docking escrow 7000, got %s", escrow)
+	}
+	if pool, found := k2.GetPool(ctx2, "lab-pool"); !found || pool.Operator != operator || pool.TotalEarned.Int64() != 900 || pool.TotalFees.Int64() != 45 {
+		t.Errorf("Expected pool restored, got %+v (found %t)", pool, found)
+	}
+	if fee := k2.GetBaseFee(ctx2); !fee.Equal(math.LegacyNewDecWithPrec(25, 4)) {
+		t.Errorf("Expected base fee 0.0025, got %s", fee)
+	}
+	if reg, found := k2.GetMinerRegistration(ctx2, testMiner); !found || !reg.IsActive() || !reg.Bond.Equal(types.DefaultMinMinerBond) {
+		t.Errorf("Expected active registration restored, got %+v (found %t)", reg, found)
+	}
+	if msg, broken := keeper.ModuleBalanceInvariant(k2)(ctx2); broken {
+		t.Errorf("Module balance invariant broken after import: %s", msg)
+	}
+	if reexported := mining.NewAppModule(cdc, k2).ExportGenesis(ctx2, cdc); !bytes.Equal(exported, reexported) {
+		t.Errorf("Re-exported genesis differs:\n%s\n%s", exported, reexported)
+	}
+
+	// The unbonding miner is still queued and gets its bond back on time
+	k2.ProcessMinerUnbonding(ctx2.WithBlockTime(time.Unix(unbond.UnbondingEndsAt, 0)))
+	if _, found := k2.GetMinerRegistration(ctx2, unbonder.String()); found {
+		t.Error("Expected the imported unbonding registration to mature")
+	}
+	if balance := bankKeeper.Balances[unbonder.String()]; !balance.Equal(bond) {
+		t.Errorf("Expected the bond returned after import, got %s", balance)
+	}
+
+}
@@ -0,0 +1,213 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// INVARIANTS
+// ============================================
+//
+// module-balance: the mining module account holds at least everything it
+// owes - emission escrow, validator reward pool, customer rewards escrowed
+// on unsettled jobs, settled but unclaimed payouts, docking reward pools,
+// unwithdrawn vesting grants and registered miners' bonds.
+//
+// job-shares: every job's share totals equal the sum of its per-miner share
+// entries (TotalShares for all jobs; WorkPoolShares and BonusPoolShares for
+// collaborative jobs). Jobs that finished before settlement existed are
+// skipped.
+
+const (
+	ModuleBalanceInvariantRoute = "module-balance"
+	JobSharesInvariantRoute     = "job-shares"
+)
+
+// namedInvariant pairs an invariant with its registry route
+type namedInvariant struct {
+	route     string
+	invariant sdk.Invariant
+}
+
+func invariants(k Keeper) []namedInvariant {
+	return []namedInvariant{
+		{route: ModuleBalanceInvariantRoute, invariant: ModuleBalanceInvariant(k)},
+		{route: JobSharesInvariantRoute, invariant: JobSharesInvariant(k)},
+	}
+}
+
+// RegisterInvariants registers all mining invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	for _, inv := range invariants(k) {
+		ir.RegisterRoute(types.ModuleName, inv.route, inv.invariant)
+	}
+}
+
+// AllInvariants runs every mining invariant and stops at the first broken one
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		for _, inv := range invariants(k) {
+			if msg, broken := inv.invariant(ctx); broken {
+				return msg, true
+			}
+		}
+		return "", false
+	}
+}
+
+// AssertInvariants runs every mining invariant from EndBlocker each
+// invariant_check_interval blocks; the app has no x/crisis, so the
+// registered routes alone are never run on chain. A broken invariant is
+// logged and emitted as an event, and fails the block only when
+// invariant_halt is set.
+func (k Keeper) AssertInvariants(ctx sdk.Context, params types.Params) error {
+	height := ctx.BlockHeight()
+	if params.InvariantCheckInterval <= 0 || height <= 0 || height%params.InvariantCheckInterval != 0 {
+		return nil
+	}
+	for _, inv := range invariants(k) {
+		msg, broken := inv.invariant(ctx)
+		if !broken {
+			continue
+		}
+		k.Logger(ctx).Error("Mining invariant broken", "route", inv.route, "height", height, "msg", msg)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"invariant_broken",
+				sdk.NewAttribute("route", inv.route),
+				sdk.NewAttribute("height", fmt.Sprintf("%d", height)),
+				sdk.NewAttribute("message", msg),
+			),
+		)
+		if params.InvariantHalt {
+			return fmt.Errorf("mining invariant broken at height %d: %s", height, msg)
+		}
+	}
+	return nil
+}
+
+// CheckInvariants runs every mining invariant and reports each result
+func (k Keeper) CheckInvariants(ctx sdk.Context) []types.InvariantResult {
+	var results []types.InvariantResult
+	for _, inv := range invariants(k) {
+		msg, broken := inv.invariant(ctx)
+		results = append(results, types.InvariantResult{
+			Route:   inv.route,
+			Broken:  broken,
+			Message: msg,
+		})
+	}
+	return results
+}
+
+// ModuleBalanceInvariant checks that the module account covers its liabilities
+func ModuleBalanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		if k.bankKeeper == nil {
+			return sdk.FormatInvariant(types.ModuleName, ModuleBalanceInvariantRoute, "no bank keeper, skipped"), false
+		}
+
+		escrow := k.GetEmissionEscrow(ctx)
+		validatorPool := k.GetValidatorRewardPool(ctx)
+
+		jobEscrow := math.ZeroInt()
+		k.IterateJobs(ctx, func(job types.Job) bool {
+			if job.Status == types.JobStatusCancelled || job.IsSettled() || job.Reward.IsNil() {
+				return false
+			}
+			jobEscrow = jobEscrow.Add(job.Reward)
+			return false
+		})
+
+		claimable := math.ZeroInt()
+		k.IterateClaimableRewards(ctx, func(reward types.ClaimableReward) bool {
+			claimable = claimable.Add(reward.Total())
+			return false
+		})
+
+		dockingPools := math.ZeroInt()
+		k.IterateDockingJobs(ctx, func(job types.DockingJob) bool {
+			dockingPools = dockingPools.AddRaw(job.RewardPool)
+			return false
+		})
+
+		vesting := math.ZeroInt()
+		k.IterateVestingGrants(ctx, func(grant types.VestingGrant) bool {
+			vesting = vesting.Add(grant.Outstanding())
+			return false
+		})
+
+		minerBonds := math.ZeroInt()
+		k.IterateMinerRegistrations(ctx, func(reg types.MinerRegistration) bool {
+			minerBonds = minerBonds.Add(reg.Bond)
+			return false
+		})
+
+		required := escrow.Add(validatorPool).Add(jobEscrow).Add(claimable).Add(dockingPools).Add(vesting).Add(minerBonds)
+		balance := k.bankKeeper.GetBalance(ctx, authtypes.NewModuleAddress(types.ModuleName), "unexus").Amount
+		broken := balance.LT(required)
+
+		return sdk.FormatInvariant(types.ModuleName, ModuleBalanceInvariantRoute, fmt.Sprintf(
+			"\tmodule balance: %s\n\trequired: %s (emission escrow %s, validator pool %s, job escrow %s, claimable %s, docking pools %s, vesting %s, miner bonds %s)\n",
+			balance, required, escrow, validatorPool, jobEscrow, claimable, dockingPools, vesting, minerBonds,
+		)), broken
+	}
+}
+
+// JobSharesInvariant checks each job's share totals against per-miner entries
+func JobSharesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var mismatches []string
+		k.IterateJobs(ctx, func(job types.Job) bool {
+			// Claims before consensus version 4 zeroed the claimer's shares;
+			// such jobs finished without being settled
+			if job.IsFinished() && !job.IsSettled() {
+				return false
+			}
+
+			var shares, work, bonus int64
+			k.IterateJobMiners(ctx, job.Id, func(miner sdk.AccAddress) bool {
+				shares += k.GetShares(ctx, miner, job.Id)
+				work += k.GetWorkShares(ctx, miner, job.Id)
+				bonus += k.GetBonusShares(ctx, miner, job.Id)
+				return false
+			})
+
+			if shares != job.TotalShares {
+				mismatches = append(mismatches, fmt.Sprintf("\tjob %s: total shares %d, miner shares sum %d\n", job.Id, job.TotalShares, shares))
+			}
+			if job.MiningMode == types.MiningModeCollaborative {
+				if work != job.WorkPoolShares {
+					mismatches = append(mismatches, fmt.Sprintf("\tjob %s: work pool shares %d, miner work shares sum %d\n", job.Id, job.WorkPoolShares, work))
+				}
+				if bonus != job.BonusPoolShares {
+					mismatches = append(mismatches, fmt.Sprintf("\tjob %s: bonus pool shares %d, miner bonus shares sum %d\n", job.Id, job.BonusPoolShares, bonus))
+				}
+			}
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, JobSharesInvariantRoute, fmt.Sprintf(
+			"%d job share mismatches\n%s", len(mismatches), strings.Join(mismatches, ""),
+		)), len(mismatches) > 0
+	}
+}
+
+// Invariants runs every mining invariant and reports the results
+func (q queryServer) Invariants(goCtx context.Context, req *types.QueryInvariantsRequest) (*types.QueryInvariantsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	results := q.Keeper.CheckInvariants(ctx)
+
+	broken := false
+	for _, result := range results {
+		broken = broken || result.Broken
+	}
+	return &types.QueryInvariantsResponse{Results: results, Broken: broken}, nil
+}
@@ -18,6 +18,7 @@ type Keeper struct {
 	memKey        storetypes.StoreKey
 	stakingKeeper types.StakingKeeper
 	bankKeeper    types.BankKeeper
+	distrKeeper   types.DistributionKeeper
 	authority     string
 }
 
@@ -27,6 +28,7 @@ func NewKeeper(
 	memKey storetypes.StoreKey,
 	stakingKeeper types.StakingKeeper,
 	bankKeeper types.BankKeeper,
+	distrKeeper types.DistributionKeeper,
 	authority string,
 ) Keeper {
 	return Keeper{
@@ -35,6 +37,7 @@ func NewKeeper(
 		memKey:        memKey,
 		stakingKeeper: stakingKeeper,
 		bankKeeper:    bankKeeper,
+		distrKeeper:   distrKeeper,
 		authority:     authority,
 	}
 }
@@ -127,6 +130,11 @@ func (k Keeper) SetShares(ctx sdk.Context, miner sdk.AccAddress, jobId string, s
 	store := ctx.KVStore(k.storeKey)
 	key := append(types.ShareKeyPrefix, append(miner.Bytes(), []byte(jobId)...)...)
 	store.Set(key, uint64ToBytes(uint64(shares)))
+
+	// Index the miner under the job so settlement can find every earner
+	if shares > 0 {
+		k.setJobMiner(ctx, jobId, miner)
+	}
 }
 
 func (k Keeper) GetValidatorMiningRecord(ctx sdk.Context, valAddr sdk.ValAddress) (types.ValidatorMiningRecord, bool) {
@@ -0,0 +1,202 @@
+package keeper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// SOLUTION LANDSCAPE
+// ============================================
+//
+// Every collaborative submission is folded into per-job aggregates:
+//   - one LandscapeMinimum per distinct BestConfigHash (local minimum),
+//     with how many miners and submissions reached it
+//   - one EpochLandscape per epoch with step totals and best/median energy;
+//     the median comes from a bounded reservoir sample of the epoch's energies
+// Together these are the scientific result a customer downloads.
+
+// GetLandscapeMinimum returns the aggregate for one configuration hash
+func (k Keeper) GetLandscapeMinimum(ctx sdk.Context, jobId, configHash string) (types.LandscapeMinimum, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.LandscapeMinimumKeyPrefix, types.LandscapeKey(jobId, []byte(configHash))...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.LandscapeMinimum{}, false
+	}
+	var minimum types.LandscapeMinimum
+	k.cdc.MustUnmarshal(bz, &minimum)
+	return minimum, true
+}
+
+// SetLandscapeMinimum stores the aggregate for one configuration hash
+func (k Keeper) SetLandscapeMinimum(ctx sdk.Context, minimum types.LandscapeMinimum) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.LandscapeMinimumKeyPrefix, types.LandscapeKey(minimum.JobId, []byte(minimum.ConfigHash))...)
+	bz := k.cdc.MustMarshal(&minimum)
+	store.Set(key, bz)
+}
+
+// GetEpochLandscape returns the statistics for one epoch of a job
+func (k Keeper) GetEpochLandscape(ctx sdk.Context, jobId string, epoch uint64) (types.EpochLandscape, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.EpochLandscapeKeyPrefix, types.LandscapeKey(jobId, uint64ToBytes(epoch))...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.EpochLandscape{}, false
+	}
+	var stats types.EpochLandscape
+	k.cdc.MustUnmarshal(bz, &stats)
+	return stats, true
+}
+
+// SetEpochLandscape stores the statistics for one epoch of a job
+func (k Keeper) SetEpochLandscape(ctx sdk.Context, stats types.EpochLandscape) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.EpochLandscapeKeyPrefix, types.LandscapeKey(stats.JobId, uint64ToBytes(stats.Epoch))...)
+	bz := k.cdc.MustMarshal(&stats)
+	store.Set(key, bz)
+}
+
+// IterateEpochLandscapes iterates over a job's epochs in order
+func (k Keeper) IterateEpochLandscapes(ctx sdk.Context, jobId string, fn func(stats types.EpochLandscape) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.EpochLandscapeKeyPrefix, types.LandscapeKey(jobId, nil)...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var stats types.EpochLandscape
+		k.cdc.MustUnmarshal(iterator.Value(), &stats)
+		if fn(stats) {
+			break
+		}
+	}
+}
+
+// RecordLandscapeSubmission folds a work submission into the job's landscape.
+// Returns true if the submission found a configuration nobody reported before.
+func (k Keeper) RecordLandscapeSubmission(ctx sdk.Context, jobId, miner string, epoch, numSteps uint64, bestEnergy int64, configHash string) bool {
+	// Per-epoch statistics
+	stats, found := k.GetEpochLandscape(ctx, jobId, epoch)
+	if !found {
+		stats = types.EpochLandscape{JobId: jobId, Epoch: epoch, BestEnergy: bestEnergy}
+	}
+	stats.Submissions++
+	stats.TotalSteps += numSteps
+	if bestEnergy < stats.BestEnergy {
+		stats.BestEnergy = bestEnergy
+	}
+	sampleEpochEnergy(&stats, bestEnergy)
+	stats.MedianEnergy = stats.Energies[len(stats.Energies)/2]
+	k.SetEpochLandscape(ctx, stats)
+
+	if configHash == "" {
+		return false
+	}
+
+	// Distinct minima
+	minimum, found := k.GetLandscapeMinimum(ctx, jobId, configHash)
+	isNew := !found
+	if isNew {
+		minimum = types.LandscapeMinimum{
+			JobId:          jobId,
+			ConfigHash:     configHash,
+			Energy:         bestEnergy,
+			FirstFinder:    miner,
+			FirstSeenEpoch: epoch,
+		}
+	}
+	minimum.HitCount++
+
+	store := ctx.KVStore(k.storeKey)
+	finderKey := append(types.LandscapeFinderKeyPrefix, types.LandscapeKey(jobId, []byte(configHash+"/"+miner))...)
+	if !store.Has(finderKey) {
+		store.Set(finderKey, []byte{1})
+		minimum.MinerCount++
+	}
+	k.SetLandscapeMinimum(ctx, minimum)
+
+	return isNew
+}
+
+// sampleEpochEnergy adds a submission's energy to the epoch's sorted sample.
+// Once the sample is full it is a reservoir: the n-th submission replaces a
+// sampled energy with probability MaxEpochLandscapeEnergies/n, drawn from a
+// hash of the job, epoch and n so every node picks the same one.
+func sampleEpochEnergy(stats *types.EpochLandscape, energy int64) {
+	if len(stats.Energies) >= types.MaxEpochLandscapeEnergies {
+		h := sha256.New()
+		h.Write([]byte(stats.JobId))
+		h.Write(uint64ToBytes(stats.Epoch))
+		h.Write(uint64ToBytes(uint64(stats.Submissions)))
+		j := binary.BigEndian.Uint64(h.Sum(nil)) % uint64(stats.Submissions)
+		if j >= uint64(len(stats.Energies)) {
+			return
+		}
+		stats.Energies = append(stats.Energies[:j], stats.Energies[j+1:]...)
+	}
+
+	i := sort.Search(len(stats.Energies), func(i int) bool { return stats.Energies[i] >= energy })
+	stats.Energies = append(stats.Energies, 0)
+	copy(stats.Energies[i+1:], stats.Energies[i:])
+	stats.Energies[i] = energy
+}
+
+// JobLandscape returns a job's solution landscape with paginated minima and
+// separately paginated epoch statistics
+func (q queryServer) JobLandscape(goCtx context.Context, req *types.QueryJobLandscapeRequest) (*types.QueryJobLandscapeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	job, found := q.Keeper.GetJob(ctx, req.JobId)
+	if !found {
+		return nil, types.ErrJobNotFound
+	}
+
+	store := ctx.KVStore(q.storeKey)
+	minimaStore := prefix.NewStore(store, append(types.LandscapeMinimumKeyPrefix, types.LandscapeKey(job.Id, nil)...))
+
+	minima := []types.LandscapeMinimum{}
+	pageRes, err := query.Paginate(minimaStore, req.Pagination, func(key, value []byte) error {
+		var minimum types.LandscapeMinimum
+		if err := q.cdc.Unmarshal(value, &minimum); err != nil {
+			return err
+		}
+		minima = append(minima, minimum)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	epochStore := prefix.NewStore(store, append(types.EpochLandscapeKeyPrefix, types.LandscapeKey(job.Id, nil)...))
+
+	epochs := []types.EpochLandscape{}
+	epochPageRes, err := query.Paginate(epochStore, req.EpochPagination, func(key, value []byte) error {
+		var stats types.EpochLandscape
+		if err := q.cdc.Unmarshal(value, &stats); err != nil {
+			return err
+		}
+		epochs = append(epochs, stats)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryJobLandscapeResponse{
+		JobId:           job.Id,
+		TotalSteps:      job.TotalSteps,
+		DistinctMinima:  job.DistinctMinima,
+		Minima:          minima,
+		Epochs:          epochs,
+		Pagination:      pageRes,
+		EpochPagination: epochPageRes,
+	}, nil
+}
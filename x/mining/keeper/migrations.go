@@ -0,0 +1,375 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/protobuf/encoding/protowire"
+	"nexus/x/mining/types"
+)
+
+// Migrator handles in-place store migrations
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 moves token amounts from int64 to math.Int:
+//   - emission escrow and validator reward pool: 8-byte big endian -> math.Int
+//   - Job.Reward (field 7), Job.PriorityFee (field 15),
+//     Checkpoint.ValidatorRewards (field 4) and
+//     ValidatorMiningRecord.TotalRewards (field 3): varint -> math.Int bytes
+//   - paid job queue: 8-byte priority fee -> length-prefixed math.Int
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	k := m.keeper
+	store := ctx.KVStore(k.storeKey)
+
+	for _, key := range [][]byte{EmissionEscrowKey, ValidatorRewardPoolKey} {
+		if bz := store.Get(key); bz != nil {
+			k.setInt(ctx, key, math.NewInt(int64(bytesToUint64(bz))))
+		}
+	}
+
+	for _, field := range []protowire.Number{7, 15} {
+		if err := migrateVarintField(prefix.NewStore(store, types.JobKeyPrefix), field); err != nil {
+			return fmt.Errorf("jobs: %w", err)
+		}
+	}
+	if err := migrateVarintField(prefix.NewStore(store, types.CheckpointKeyPrefix), 4); err != nil {
+		return fmt.Errorf("checkpoints: %w", err)
+	}
+	if err := migrateVarintField(prefix.NewStore(store, types.ValidatorRecordKeyPrefix), 3); err != nil {
+		return fmt.Errorf("validator records: %w", err)
+	}
+
+	if bz := store.Get(PaidJobQueueKey); bz != nil {
+		queue, err := decodeLegacyPaidJobQueue(bz)
+		if err != nil {
+			return fmt.Errorf("paid job queue: %w", err)
+		}
+		k.SetPaidJobQueue(ctx, queue)
+	}
+	return nil
+}
+
+// decodeLegacyPaidJobQueue reads the version 1 paid job queue: per entry
+// 8 bytes priorityFee + 8 bytes submitTime + 4 bytes jobID length + jobID
+func decodeLegacyPaidJobQueue(bz []byte) ([]PaidJobEntry, error) {
+	var queue []PaidJobEntry
+	for offset := 0; offset < len(bz); {
+		if offset+20 > len(bz) {
+			return nil, fmt.Errorf("truncated entry at offset %d", offset)
+		}
+		priorityFee := int64(bytesToUint64(bz[offset : offset+8]))
+		submitTime := int64(bytesToUint64(bz[offset+8 : offset+16]))
+		idLen := int(binary.BigEndian.Uint32(bz[offset+16 : offset+20]))
+		offset += 20
+		if offset+idLen > len(bz) {
+			return nil, fmt.Errorf("truncated job ID at offset %d", offset)
+		}
+		queue = append(queue, PaidJobEntry{
+			JobID:       string(bz[offset : offset+idLen]),
+			PriorityFee: math.NewInt(priorityFee),
+			SubmitTime:  submitTime,
+		})
+		offset += idLen
+	}
+	return queue, nil
+}
+
+// migrateVarintField rewrites an int64 varint field of every record in store
+// as a math.Int bytes field with the same number. Records where the field
+// was omitted (zero) get an explicit zero.
+func migrateVarintField(store prefix.Store, field protowire.Number) error {
+	type record struct{ key, value []byte }
+	var records []record
+
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		records = append(records, record{key: iterator.Key(), value: iterator.Value()})
+	}
+	iterator.Close()
+
+	for _, r := range records {
+		bz, err := rewriteVarintAsInt(r.value, field)
+		if err != nil {
+			return fmt.Errorf("record %x: %w", r.key, err)
+		}
+		store.Set(r.key, bz)
+	}
+	return nil
+}
+
+// rewriteVarintAsInt re-encodes one protobuf message, replacing a varint
+// field with the math.Int encoding of the same value
+func rewriteVarintAsInt(bz []byte, field protowire.Number) ([]byte, error) {
+	out := make([]byte, 0, len(bz)+8)
+	var value int64
+
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, bz[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+
+		switch {
+		case num == field && typ == protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(bz[n:])
+			value = int64(v)
+		case num == field:
+			return nil, fmt.Errorf("field %d already migrated", field)
+		default:
+			out = append(out, bz[:n+m]...)
+		}
+		bz = bz[n+m:]
+	}
+
+	intBz, err := math.NewInt(value).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	out = protowire.AppendTag(out, field, protowire.BytesType)
+	out = protowire.AppendBytes(out, intBz)
+	return out, nil
+}
+
+// Migrate2to3 moves the emission schedule into Params and starts tracking
+// cumulative minted emission. Chains upgrading from version 2 get the
+// default schedule (the one previously hard-coded), and the minted total is
+// seeded with what that schedule emitted up to the last processed minute.
+// Params added before the schedule without a migration of their own get
+// their defaults here too, as do the workload weights Validate requires
+// (Migrate5to6 sets the same defaults again). Version 1 chains also never
+// registered the default algorithms, so collaborative jobs would be rejected;
+// any missing default is registered without touching governance changes.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	k := m.keeper
+	params := k.GetParams(ctx)
+	defaults := types.DefaultParams()
+
+	setMissingParamDefaults(&params)
+	if params.TotalEmissionWeight() == 0 {
+		params.SyntheticEmissionWeight = defaults.SyntheticEmissionWeight
+		params.PublicEmissionWeight = defaults.PublicEmissionWeight
+		params.DockingEmissionWeight = defaults.DockingEmissionWeight
+		params.ProteinEmissionWeight = defaults.ProteinEmissionWeight
+	}
+	params.EmissionBaseRate = defaults.EmissionBaseRate
+	params.EmissionEpochMinutes = defaults.EmissionEpochMinutes
+	params.EmissionDecayPermille = defaults.EmissionDecayPermille
+	params.EmissionFloorPermille = defaults.EmissionFloorPermille
+	params.EmissionSupplyCap = defaults.EmissionSupplyCap
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	if err := k.SetParams(ctx, params); err != nil {
+		return err
+	}
+
+	minted := math.ZeroInt()
+	if last := k.GetLastEmissionMinute(ctx); last != 0 {
+		genesisMinute := k.GetGenesisMinute(ctx)
+		minted = ScheduledEmission(params, 0, last-genesisMinute)
+	}
+	k.SetTotalEmissionMinted(ctx, minted)
+
+	for _, algo := range types.DefaultAlgorithms() {
+		if _, found := k.GetAlgorithm(ctx, algo.Id); !found {
+			k.SetAlgorithm(ctx, algo)
+		}
+	}
+	return nil
+}
+
+// setMissingParamDefaults fills params that version 1 chains never stored:
+// collaborative epochs, payout pools, per-epoch submission limits and the
+// vesting schedule. A group is only filled while all of its fields are zero,
+// so values already set by governance are kept. The treasury share defaults
+// to zero, which is what an unset field already decodes to.
+func setMissingParamDefaults(params *types.Params) {
+	if params.CollabEpochBlocks == 0 && params.CollabEpochDuration == 0 {
+		params.CollabEpochBlocks = types.DefaultCollabEpochBlocks
+		params.CollabEpochDuration = types.DefaultCollabEpochDuration
+	}
+	if params.WorkPoolPercent == 0 && params.ImprovementPoolPercent == 0 && params.CollabValidatorPercent == 0 {
+		params.WorkPoolPercent = types.DefaultWorkPoolPercent
+		params.ImprovementPoolPercent = types.DefaultImprovementPoolPercent
+		params.CollabValidatorPercent = types.DefaultCollabValidatorPercent
+	}
+	if params.MaxSubmissionsPerEpoch == 0 && params.MaxStepsPerEpoch == 0 {
+		params.MaxSubmissionsPerEpoch = types.DefaultMaxSubmissionsPerEpoch
+		params.MaxStepsPerEpoch = types.DefaultMaxStepsPerEpoch
+	}
+	if params.VestingPercent == 0 && params.VestingDuration == 0 {
+		params.VestingDuration = types.DefaultVestingDuration
+	}
+}
+
+// Migrate3to4 indexes existing share holders by job so jobs that finished
+// before settlement existed can be settled on their first claim
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	k := m.keeper
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.ShareKeyPrefix)
+
+	type holder struct {
+		miner sdk.AccAddress
+		jobId string
+	}
+	var holders []holder
+
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		if int64(bytesToUint64(iterator.Value())) <= 0 {
+			continue
+		}
+		// Keys are miner address bytes followed by the job ID
+		key := iterator.Key()
+		for _, addrLen := range []int{20, 32} {
+			if len(key) <= addrLen {
+				continue
+			}
+			jobId := string(key[addrLen:])
+			if _, found := k.GetJob(ctx, jobId); found {
+				holders = append(holders, holder{miner: sdk.AccAddress(key[:addrLen]), jobId: jobId})
+				break
+			}
+		}
+	}
+	iterator.Close()
+
+	for _, h := range holders {
+		k.setJobMiner(ctx, h.jobId, h.miner)
+	}
+	return nil
+}
+
+// Migrate4to5 builds the per-miner index of jobs not yet withdrawn from the
+// per-job miner index, so MsgClaimAllRewards can find existing payouts
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	k := m.keeper
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.JobMinerKeyPrefix)
+
+	type entry struct {
+		miner string
+		jobId string
+	}
+	var entries []entry
+
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		// Keys are job ID + "/" + miner; bech32 addresses contain no "/"
+		key := string(iterator.Key())
+		sep := strings.LastIndex(key, "/")
+		if sep < 0 {
+			continue
+		}
+		jobId, miner := key[:sep], key[sep+1:]
+
+		job, found := k.GetJob(ctx, jobId)
+		if !found || job.Status == types.JobStatusCancelled {
+			continue
+		}
+		if job.IsSettled() {
+			if _, found := k.GetClaimableReward(ctx, jobId, miner); !found {
+				continue
+			}
+		}
+		entries = append(entries, entry{miner: miner, jobId: jobId})
+	}
+	iterator.Close()
+
+	for _, e := range entries {
+		k.setMinerJob(ctx, e.miner, e.jobId)
+	}
+	return nil
+}
+
+// Migrate5to6 splits emission across workloads: chains upgrading from
+// version 5 get the default weights, and the single emission escrow is
+// divided into the workload escrows by those weights
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	k := m.keeper
+	params := k.GetParams(ctx)
+	defaults := types.DefaultParams()
+
+	params.SyntheticEmissionWeight = defaults.SyntheticEmissionWeight
+	params.PublicEmissionWeight = defaults.PublicEmissionWeight
+	params.DockingEmissionWeight = defaults.DockingEmissionWeight
+	params.ProteinEmissionWeight = defaults.ProteinEmissionWeight
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	if err := k.SetParams(ctx, params); err != nil {
+		return err
+	}
+
+	k.AllocateEmission(ctx, k.GetUnallocatedEscrow(ctx))
+	ctx.KVStore(k.storeKey).Delete(EmissionEscrowKey)
+	return nil
+}
+
+// Migrate6to7 starts the base fee market: chains upgrading from version 6
+// get the default base fee params and start at the floor
+func (m Migrator) Migrate6to7(ctx sdk.Context) error {
+	k := m.keeper
+	params := k.GetParams(ctx)
+	defaults := types.DefaultParams()
+
+	params.MinBaseFee = defaults.MinBaseFee
+	params.BaseFeeTargetGas = defaults.BaseFeeTargetGas
+	params.BaseFeeChangeDenominator = defaults.BaseFeeChangeDenominator
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	if err := k.SetParams(ctx, params); err != nil {
+		return err
+	}
+
+	k.SetBaseFee(ctx, params.BaseFeeFloor())
+	return nil
+}
+
+// Migrate7to8 adds the miner registry: chains upgrading from version 7 get
+// the default bond and unbonding period, but registration is not required
+// until governance enables it, so existing miners can register first
+func (m Migrator) Migrate7to8(ctx sdk.Context) error {
+	k := m.keeper
+	params := k.GetParams(ctx)
+	defaults := types.DefaultParams()
+
+	params.MinerRegistrationRequired = false
+	params.MinMinerBond = defaults.MinMinerBond
+	params.MinerUnbondingPeriod = defaults.MinerUnbondingPeriod
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	return k.SetParams(ctx, params)
+}
+
+// Migrate8to9 moves the invariant checks into params: chains upgrading from
+// version 8 keep the 100-block interval but report broken invariants
+// instead of halting
+func (m Migrator) Migrate8to9(ctx sdk.Context) error {
+	k := m.keeper
+	params := k.GetParams(ctx)
+	defaults := types.DefaultParams()
+
+	params.InvariantCheckInterval = defaults.InvariantCheckInterval
+	params.InvariantHalt = defaults.InvariantHalt
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	return k.SetParams(ctx, params)
+}
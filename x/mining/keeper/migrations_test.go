@@ -0,0 +1,442 @@
+package keeper_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"nexus/x/mining/keeper"
+	"nexus/x/mining/types"
+)
+
+func TestMigrate1to2(t *testing.T) {
+	k, ctx, storeKey := setupKeeperWithStore(t, nil)
+	store := ctx.KVStore(storeKey)
+
+	legacyUint := func(v uint64) []byte {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, v)
+		return bz
+	}
+	store.Set(keeper.EmissionEscrowKey, legacyUint(123456789))
+	store.Set(keeper.ValidatorRewardPoolKey, legacyUint(42))
+
+	// Version 1 encoded Job.Reward (field 7) as an int64 varint
+	var legacyJob []byte
+	legacyJob = protowire.AppendTag(legacyJob, 1, protowire.BytesType)
+	legacyJob = protowire.AppendString(legacyJob, "job_legacy")
+	legacyJob = protowire.AppendTag(legacyJob, 7, protowire.VarintType)
+	legacyJob = protowire.AppendVarint(legacyJob, 5000000)
+	legacyJob = protowire.AppendTag(legacyJob, 8, protowire.VarintType)
+	legacyJob = protowire.AppendVarint(legacyJob, uint64(types.JobStatusActive))
+	legacyJob = protowire.AppendTag(legacyJob, 15, protowire.VarintType)
+	legacyJob = protowire.AppendVarint(legacyJob, 2500)
+	store.Set(append(types.JobKeyPrefix, []byte("job_legacy")...), legacyJob)
+
+	// A job with zero reward had the field omitted entirely
+	var zeroJob []byte
+	zeroJob = protowire.AppendTag(zeroJob, 1, protowire.BytesType)
+	zeroJob = protowire.AppendString(zeroJob, "job_zero")
+	store.Set(append(types.JobKeyPrefix, []byte("job_zero")...), zeroJob)
+
+	var legacyCp []byte
+	legacyCp = protowire.AppendTag(legacyCp, 1, protowire.VarintType)
+	legacyCp = protowire.AppendVarint(legacyCp, 3)
+	legacyCp = protowire.AppendTag(legacyCp, 4, protowire.VarintType)
+	legacyCp = protowire.AppendVarint(legacyCp, 777)
+	store.Set(append(types.CheckpointKeyPrefix, legacyUint(3)...), legacyCp)
+
+	// ValidatorMiningRecord.TotalRewards (field 3) was an int64 varint too
+	valAddr := sdk.ValAddress([]byte("validator_operator__"))
+	var legacyRecord []byte
+	legacyRecord = protowire.AppendTag(legacyRecord, 1, protowire.BytesType)
+	legacyRecord = protowire.AppendString(legacyRecord, valAddr.String())
+	legacyRecord = protowire.AppendTag(legacyRecord, 3, protowire.VarintType)
+	legacyRecord = protowire.AppendVarint(legacyRecord, 784000)
+	legacyRecord = protowire.AppendTag(legacyRecord, 4, protowire.VarintType)
+	legacyRecord = protowire.AppendVarint(legacyRecord, 1)
+	store.Set(append(types.ValidatorRecordKeyPrefix, valAddr.Bytes()...), legacyRecord)
+
+	// Version 1 stored each paid queue entry with an 8-byte priority fee
+	var legacyQueue []byte
+	for _, entry := range []struct {
+		id  string
+		fee uint64
+	}{{"job_legacy", 2500}, {"job_other", 10}} {
+		legacyQueue = append(legacyQueue, legacyUint(entry.fee)...)
+		legacyQueue = append(legacyQueue, legacyUint(1_700_000_000)...)
+		legacyQueue = binary.BigEndian.AppendUint32(legacyQueue, uint32(len(entry.id)))
+		legacyQueue = append(legacyQueue, entry.id...)
+	}
+	store.Set(keeper.PaidJobQueueKey, legacyQueue)
+
+	if err := keeper.NewMigrator(k).Migrate1to2(ctx); err != nil {
+		t.Fatalf("Migrate1to2 failed: %v", err)
+	}
+
+	if escrow := k.GetEmissionEscrow(ctx); escrow.Int64() != 123456789 {
+		t.Errorf("Expected escrow 123456789, got %s", escrow)
+	}
+	if pool := k.GetValidatorRewardPool(ctx); pool.Int64() != 42 {
+		t.Errorf("Expected validator pool 42, got %s", pool)
+	}
+
+	job, found := k.GetJob(ctx, "job_legacy")
+	if !found {
+		t.Fatal("Migrated job not found")
+	}
+	if job.Reward.Int64() != 5000000 || job.PriorityFee.Int64() != 2500 || job.Status != types.JobStatusActive {
+		t.Errorf("Unexpected migrated job: reward %s, priority fee %s, status %d", job.Reward, job.PriorityFee, job.Status)
+	}
+
+	zero, found := k.GetJob(ctx, "job_zero")
+	if !found || !zero.Reward.IsZero() || !zero.PriorityFee.IsZero() {
+		t.Errorf("Expected zero reward and fee on job without legacy fields, got %+v", zero)
+	}
+
+	queue := k.GetPaidJobQueue(ctx)
+	if len(queue) != 2 || queue[0].JobID != "job_legacy" || queue[0].PriorityFee.Int64() != 2500 ||
+		queue[1].JobID != "job_other" || queue[1].PriorityFee.Int64() != 10 || queue[1].SubmitTime != 1_700_000_000 {
+		t.Errorf("Unexpected migrated paid queue %+v", queue)
+	}
+
+	cp, found := k.GetCheckpoint(ctx, 3)
+	if !found || cp.ValidatorRewards.Int64() != 777 {
+		t.Errorf("Unexpected migrated checkpoint: %+v", cp)
+	}
+
+	record, found := k.GetValidatorMiningRecord(ctx, valAddr)
+	if !found || record.TotalRewards.Int64() != 784000 || record.JobsCompleted != 1 {
+		t.Errorf("Unexpected migrated validator record: %+v", record)
+	}
+
+	// Running the migration twice must not silently corrupt migrated records
+	if err := keeper.NewMigrator(k).Migrate1to2(ctx); err == nil {
+		t.Error("Expected second migration to fail on already migrated records")
+	}
+}
+
+func TestMigrate2to3(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	// Version 2 params carry no emission schedule
+	legacy := types.DefaultParams()
+	legacy.EmissionBaseRate = math.Int{}
+	legacy.EmissionEpochMinutes = 0
+	legacy.EmissionDecayPermille = nil
+	legacy.EmissionFloorPermille = 0
+	legacy.EmissionSupplyCap = math.Int{}
+	// Params added without their own migration were never stored
+	legacy.CollabEpochBlocks = 0
+	legacy.CollabEpochDuration = 0
+	legacy.WorkPoolPercent = 0
+	legacy.ImprovementPoolPercent = 0
+	legacy.CollabValidatorPercent = 0
+	legacy.VestingDuration = 0
+	// Governance already set the per-epoch limits
+	legacy.MaxSubmissionsPerEpoch = 3
+	legacy.MaxStepsPerEpoch = 0
+	k.SetParams(ctx, legacy)
+
+	k.SetGenesisMinute(ctx, 1000)
+	k.SetLastEmissionMinute(ctx, 1060)
+
+	// Governance already retuned the default algorithm
+	algo := types.DefaultAlgorithms()[0]
+	algo.MaxSteps = 500
+	k.SetAlgorithm(ctx, algo)
+
+	if err := keeper.NewMigrator(k).Migrate2to3(ctx); err != nil {
+		t.Fatalf("Migrate2to3 failed: %v", err)
+	}
+
+	params := k.GetParams(ctx)
+	if err := params.Validate(); err != nil {
+		t.Fatalf("Migrated params invalid: %v", err)
+	}
+	if !params.EmissionSupplyCap.Equal(types.DefaultEmissionSupplyCap) || len(params.EmissionDecayPermille) != 6 {
+		t.Errorf("Expected default emission schedule, got %+v", params)
+	}
+	if params.CollabEpochBlocks != types.DefaultCollabEpochBlocks || params.CollabEpochDuration != types.DefaultCollabEpochDuration {
+		t.Errorf("Expected default collaborative epoch, got %d / %s", params.CollabEpochBlocks, params.CollabEpochDuration)
+	}
+	if params.WorkPoolPercent != 70 || params.ImprovementPoolPercent != 20 || params.CollabValidatorPercent != 10 {
+		t.Errorf("Expected 70/20/10 pools, got %d/%d/%d", params.WorkPoolPercent, params.ImprovementPoolPercent, params.CollabValidatorPercent)
+	}
+	if params.MaxSubmissionsPerEpoch != 3 || params.MaxStepsPerEpoch != 0 {
+		t.Errorf("Expected governance epoch limits kept, got %d / %d", params.MaxSubmissionsPerEpoch, params.MaxStepsPerEpoch)
+	}
+	if params.VestingPercent != 0 || params.VestingDuration != types.DefaultVestingDuration || params.TreasurySharePercent != 0 {
+		t.Errorf("Expected default vesting and treasury share, got %d / %s / %d", params.VestingPercent, params.VestingDuration, params.TreasurySharePercent)
+	}
+	if algo, _ := k.GetAlgorithm(ctx, types.DefaultCollaborativeAlgorithm); algo.MaxSteps != 500 {
+		t.Errorf("Expected governance algorithm kept, got max steps %d", algo.MaxSteps)
+	}
+
+	// One hour of epoch 1 emission was minted before the upgrade
+	if minted := k.GetTotalEmissionMinted(ctx); minted.Int64() != 60*35950000000 {
+		t.Errorf("Expected seeded minted total %d, got %s", 60*35950000000, minted)
+	}
+}
+
+func TestMigrate3to4(t *testing.T) {
+	k, ctx, storeKey := setupKeeperWithStore(t, nil)
+	store := ctx.KVStore(storeKey)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0))
+
+	k.SetJob(ctx, types.Job{Id: "job_old", Status: types.JobStatusExpired, Reward: math.NewInt(1000), TotalShares: 10})
+	minerAddr, _ := sdk.AccAddressFromBech32(testMiner)
+
+	// Version 3 stored shares without the job index
+	shareKey := append(append([]byte{}, types.ShareKeyPrefix...), minerAddr.Bytes()...)
+	shareKey = append(shareKey, []byte("job_old")...)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, 10)
+	store.Set(shareKey, bz)
+
+	if err := keeper.NewMigrator(k).Migrate3to4(ctx); err != nil {
+		t.Fatalf("Migrate3to4 failed: %v", err)
+	}
+
+	var miners []string
+	k.IterateJobMiners(ctx, "job_old", func(miner sdk.AccAddress) bool {
+		miners = append(miners, miner.String())
+		return false
+	})
+	if len(miners) != 1 || miners[0] != testMiner {
+		t.Fatalf("Expected %s indexed under job_old, got %v", testMiner, miners)
+	}
+
+	// The finished job settles on its first claim: 80% of 1000 to the only miner
+	msgServer := keeper.NewMsgServerImpl(k)
+	resp, err := msgServer.ClaimRewards(ctx, &types.MsgClaimRewards{Claimer: testMiner, JobId: "job_old"})
+	if err != nil {
+		t.Fatalf("ClaimRewards failed: %v", err)
+	}
+	if got := resp.Amount.AmountOf("unexus").Int64(); got != 800 {
+		t.Errorf("Expected payout 800, got %d", got)
+	}
+}
+
+func TestMigrate4to5(t *testing.T) {
+	k, ctx, storeKey := setupKeeperWithStore(t, nil)
+	store := ctx.KVStore(storeKey)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0))
+
+	k.SetJob(ctx, types.Job{Id: "job_settled", Status: types.JobStatusExpired, SettledAt: 1, SettledEmission: math.ZeroInt(), Reward: math.ZeroInt()})
+	k.SetJob(ctx, types.Job{Id: "job_claimed", Status: types.JobStatusExpired, SettledAt: 1, SettledEmission: math.ZeroInt(), Reward: math.ZeroInt()})
+	k.SetJob(ctx, types.Job{Id: "job_active", Status: types.JobStatusActive, Reward: math.ZeroInt()})
+	k.SetClaimableReward(ctx, types.ClaimableReward{
+		JobId: "job_settled", Miner: testMiner, CustomerReward: math.NewInt(5), EmissionReward: math.ZeroInt(),
+		WorkReward: math.ZeroInt(), ImprovementReward: math.ZeroInt(),
+	})
+
+	// Version 4 only indexed miners by job
+	for _, jobId := range []string{"job_settled", "job_claimed", "job_active"} {
+		store.Set(append(append([]byte{}, types.JobMinerKeyPrefix...), types.JobMinerKey(jobId, testMiner)...), []byte{1})
+	}
+
+	if err := keeper.NewMigrator(k).Migrate4to5(ctx); err != nil {
+		t.Fatalf("Migrate4to5 failed: %v", err)
+	}
+
+	var jobIds []string
+	k.IterateMinerJobs(ctx, testMiner, func(jobId string) bool {
+		jobIds = append(jobIds, jobId)
+		return false
+	})
+	if len(jobIds) != 2 || jobIds[0] != "job_active" || jobIds[1] != "job_settled" {
+		t.Errorf("Expected job_active and job_settled outstanding, got %v", jobIds)
+	}
+}
+
+func TestMigrate5to6(t *testing.T) {
+	k, ctx, storeKey := setupKeeperWithStore(t, nil)
+	store := ctx.KVStore(storeKey)
+
+	// Version 5 params carry no workload weights and keep a single escrow
+	legacy := types.DefaultParams()
+	legacy.SyntheticEmissionWeight = 0
+	legacy.PublicEmissionWeight = 0
+	legacy.DockingEmissionWeight = 0
+	legacy.ProteinEmissionWeight = 0
+	k.SetParams(ctx, legacy)
+
+	bz, err := math.NewInt(1001).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set(keeper.EmissionEscrowKey, bz)
+
+	if err := keeper.NewMigrator(k).Migrate5to6(ctx); err != nil {
+		t.Fatalf("Migrate5to6 failed: %v", err)
+	}
+
+	if err := k.GetParams(ctx).Validate(); err != nil {
+		t.Fatalf("Migrated params invalid: %v", err)
+	}
+
+	// 40/25/25/10 of 1001, truncation dust to synthetic
+	expected := map[string]int64{
+		types.WorkloadSynthetic: 401,
+		types.WorkloadPublic:    250,
+		types.WorkloadDocking:   250,
+		types.WorkloadProtein:   100,
+	}
+	for workload, amount := range expected {
+		if escrow := k.GetWorkloadEscrow(ctx, workload); escrow.Int64() != amount {
+			t.Errorf("Expected %s escrow %d, got %s", workload, amount, escrow)
+		}
+	}
+	if unallocated := k.GetUnallocatedEscrow(ctx); !unallocated.IsZero() {
+		t.Errorf("Expected no unallocated escrow, got %s", unallocated)
+	}
+	if escrow := k.GetEmissionEscrow(ctx); escrow.Int64() != 1001 {
+		t.Errorf("Expected total escrow 1001, got %s", escrow)
+	}
+}
+
+func TestMigrate6to7(t *testing.T) {
+	k, ctx, _ := setupKeeperWithStore(t, nil)
+
+	// Version 6 params carry no base fee market
+	legacy := types.DefaultParams()
+	legacy.MinBaseFee = math.LegacyDec{}
+	legacy.BaseFeeTargetGas = 0
+	legacy.BaseFeeChangeDenominator = 0
+	k.SetParams(ctx, legacy)
+
+	if err := keeper.NewMigrator(k).Migrate6to7(ctx); err != nil {
+		t.Fatalf("Migrate6to7 failed: %v", err)
+	}
+
+	params := k.GetParams(ctx)
+	if !params.MinBaseFee.Equal(types.DefaultMinBaseFee) || params.BaseFeeTargetGas != types.DefaultBaseFeeTargetGas ||
+		params.BaseFeeChangeDenominator != types.DefaultBaseFeeChangeDenominator {
+		t.Fatalf("Expected default base fee params, got %s / %d / %d", params.MinBaseFee, params.BaseFeeTargetGas, params.BaseFeeChangeDenominator)
+	}
+	if baseFee := k.GetBaseFee(ctx); !baseFee.Equal(types.DefaultMinBaseFee) {
+		t.Errorf("Expected base fee to start at the floor, got %s", baseFee)
+	}
+}
+
+func TestMigrate7to8(t *testing.T) {
+	k, ctx, _ := setupKeeperWithStore(t, nil)
+
+	// Version 7 params carry no miner registry
+	legacy := types.DefaultParams()
+	legacy.MinerRegistrationRequired = false
+	legacy.MinMinerBond = math.Int{}
+	legacy.MinerUnbondingPeriod = 0
+	k.SetParams(ctx, legacy)
+
+	if err := keeper.NewMigrator(k).Migrate7to8(ctx); err != nil {
+		t.Fatalf("Migrate7to8 failed: %v", err)
+	}
+
+	// Existing miners keep mining until governance requires registration
+	params := k.GetParams(ctx)
+	if params.MinerRegistrationRequired {
+		t.Error("Expected registration to stay optional after the upgrade")
+	}
+	if !params.MinMinerBond.Equal(types.DefaultMinMinerBond) || params.MinerUnbondingPeriod != types.DefaultMinerUnbondingPeriod {
+		t.Errorf("Expected default bond and unbonding period, got %s / %s", params.MinMinerBond, params.MinerUnbondingPeriod)
+	}
+}
+
+func TestMigrate8to9(t *testing.T) {
+	k, ctx, _ := setupKeeperWithStore(t, nil)
+
+	// Version 8 checked invariants on a fixed interval and halted
+	legacy := types.DefaultParams()
+	legacy.InvariantCheckInterval = 0
+	legacy.InvariantHalt = true
+	k.SetParams(ctx, legacy)
+
+	if err := keeper.NewMigrator(k).Migrate8to9(ctx); err != nil {
+		t.Fatalf("Migrate8to9 failed: %v", err)
+	}
+
+	params := k.GetParams(ctx)
+	if params.InvariantCheckInterval != types.DefaultInvariantCheckInterval || params.InvariantHalt {
+		t.Errorf("Expected the default interval without halting, got %d / %t", params.InvariantCheckInterval, params.InvariantHalt)
+	}
+}
+
+func TestMigrateFromBaseline(t *testing.T) {
+	k, ctx, storeKey := setupKeeperWithStore(t, nil)
+	store := ctx.KVStore(storeKey)
+
+	// Version 1 params only had the first nine fields
+	k.SetParams(ctx, types.Params{
+		MinerSharePercent:      types.DefaultMinerSharePercent,
+		ValidatorSharePercent:  types.DefaultValidatorSharePercent,
+		CheckpointInterval:     types.DefaultCheckpointInterval,
+		MinProofPeriod:         types.DefaultMinProofPeriod,
+		JobFeeBurnPercent:      types.DefaultJobFeeBurnPercent,
+		TxFeeBurnPercent:       types.DefaultTxFeeBurnPercent,
+		BackgroundEmissionRate: types.DefaultBackgroundEmissionRate,
+		MinJobReward:           types.DefaultMinJobReward,
+		MaxJobDuration:         types.DefaultMaxJobDuration,
+	})
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, 1000)
+	store.Set(keeper.EmissionEscrowKey, bz)
+
+	// Version 1 had no algorithm registry
+	for _, algo := range types.DefaultAlgorithms() {
+		store.Delete(append(append([]byte{}, types.AlgorithmKeyPrefix...), []byte(algo.Id)...))
+	}
+	if _, found := k.GetAlgorithm(ctx, types.DefaultCollaborativeAlgorithm); found {
+		t.Fatal("Expected no algorithms before the upgrade")
+	}
+
+	m := keeper.NewMigrator(k)
+	steps := []func(sdk.Context) error{
+		m.Migrate1to2, m.Migrate2to3, m.Migrate3to4, m.Migrate4to5,
+		m.Migrate5to6, m.Migrate6to7, m.Migrate7to8, m.Migrate8to9,
+	}
+	for i, migrate := range steps {
+		if err := migrate(ctx); err != nil {
+			t.Fatalf("Migrate%dto%d failed: %v", i+1, i+2, err)
+		}
+	}
+
+	params := k.GetParams(ctx)
+	if err := params.Validate(); err != nil {
+		t.Fatalf("Migrated params invalid: %v", err)
+	}
+	if params.MinerSharePercent != types.DefaultMinerSharePercent || params.CheckpointInterval != types.DefaultCheckpointInterval {
+		t.Errorf("Expected version 1 params kept, got %+v", params)
+	}
+	if params.WorkPoolPercent != 70 || params.ImprovementPoolPercent != 20 || params.CollabValidatorPercent != 10 {
+		t.Errorf("Expected 70/20/10 pools, got %d/%d/%d", params.WorkPoolPercent, params.ImprovementPoolPercent, params.CollabValidatorPercent)
+	}
+	if params.CollabEpochBlocks != types.DefaultCollabEpochBlocks || params.MaxSubmissionsPerEpoch != types.DefaultMaxSubmissionsPerEpoch ||
+		params.MaxStepsPerEpoch != types.DefaultMaxStepsPerEpoch {
+		t.Errorf("Expected default collaborative epoch and limits, got %d / %d / %d",
+			params.CollabEpochBlocks, params.MaxSubmissionsPerEpoch, params.MaxStepsPerEpoch)
+	}
+	if params.TotalEmissionWeight() != 100 || params.SyntheticEmissionWeight != types.DefaultSyntheticEmissionWeight {
+		t.Errorf("Expected default workload weights, got %+v", params)
+	}
+	if params.MinerRegistrationRequired {
+		t.Error("Expected registration to stay optional after the upgrade")
+	}
+	if params.InvariantCheckInterval != types.DefaultInvariantCheckInterval {
+		t.Errorf("Expected invariant checks every %d blocks, got %d", types.DefaultInvariantCheckInterval, params.InvariantCheckInterval)
+	}
+	if baseFee := k.GetBaseFee(ctx); !baseFee.Equal(types.DefaultMinBaseFee) {
+		t.Errorf("Expected base fee to start at the floor, got %s", baseFee)
+	}
+	if escrow := k.GetEmissionEscrow(ctx); escrow.Int64() != 1000 {
+		t.Errorf("Expected escrow 1000 carried into workload escrows, got %s", escrow)
+	}
+	if _, err := k.GetActiveAlgorithm(ctx, types.DefaultCollaborativeAlgorithm); err != nil {
+		t.Errorf("Expected default algorithm registered: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// GetMinerGroup returns a named miner group
+func (k Keeper) GetMinerGroup(ctx sdk.Context, name string) (types.MinerGroup, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.MinerGroupKeyPrefix, []byte(name)...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.MinerGroup{}, false
+	}
+	var group types.MinerGroup
+	k.cdc.MustUnmarshal(bz, &group)
+	return group, true
+}
+
+// SetMinerGroup stores a miner group under its name
+func (k Keeper) SetMinerGroup(ctx sdk.Context, group types.MinerGroup) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.MinerGroupKeyPrefix, []byte(group.Name)...)
+	bz := k.cdc.MustMarshal(&group)
+	store.Set(key, bz)
+}
+
+// IterateMinerGroups iterates over all miner groups
+func (k Keeper) IterateMinerGroups(ctx sdk.Context, fn func(group types.MinerGroup) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.MinerGroupKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var group types.MinerGroup
+		k.cdc.MustUnmarshal(iterator.Value(), &group)
+		if fn(group) {
+			break
+		}
+	}
+}
+
+// IsMinerAllowed checks whether a miner may submit to a job.
+// Open jobs accept everyone; permissioned jobs accept miners on the
+// inline allowlist or in the miner group as it was when the job was posted.
+func (k Keeper) IsMinerAllowed(ctx sdk.Context, job types.Job, miner string) bool {
+	if job.IsOpen() {
+		return true
+	}
+
+	for _, addr := range job.Allowlist {
+		if addr == miner {
+			return true
+		}
+	}
+
+	for _, addr := range job.GroupMembers {
+		if addr == miner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetMinerGroup creates a miner group or replaces the members of one owned by
+// the sender. Jobs already posted keep the members they copied at posting.
+func (k msgServer) SetMinerGroup(goCtx context.Context, msg *types.MsgSetMinerGroup) (*types.MsgSetMinerGroupResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	existing, found := k.GetMinerGroup(ctx, msg.Name)
+	if found && existing.Owner != msg.Owner {
+		return nil, types.ErrUnauthorized
+	}
+
+	// Deduplicate members while preserving order
+	seen := make(map[string]bool, len(msg.Members))
+	members := make([]string, 0, len(msg.Members))
+	for _, addr := range msg.Members {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		members = append(members, addr)
+	}
+
+	k.Keeper.SetMinerGroup(ctx, types.MinerGroup{
+		Name:    msg.Name,
+		Owner:   msg.Owner,
+		Members: members,
+	})
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"miner_group_set",
+			sdk.NewAttribute("name", msg.Name),
+			sdk.NewAttribute("owner", msg.Owner),
+			sdk.NewAttribute("member_count", fmt.Sprintf("%d", len(members))),
+		),
+	)
+
+	return &types.MsgSetMinerGroupResponse{MemberCount: int64(len(members))}, nil
+}
+
+// MinerGroup returns a named miner group
+func (q queryServer) MinerGroup(goCtx context.Context, req *types.QueryMinerGroupRequest) (*types.QueryMinerGroupResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	group, found := q.Keeper.GetMinerGroup(ctx, req.Name)
+	if !found {
+		return nil, types.ErrMinerGroupNotFound
+	}
+	return &types.QueryMinerGroupResponse{Group: group}, nil
+}
@@ -0,0 +1,287 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// MINER REGISTRY
+// ============================================
+//
+// Miners bond at least min_miner_bond with MsgRegisterMiner, declaring
+// metadata, an optional hardware class and an optional payout address that
+// liquid rewards are sent to. While miner_registration_required is set,
+// SubmitProof, SubmitWork and ClaimDockingJob only accept active miners.
+// MsgUnbondMiner stops a miner at once and queues its bond, which EndBlocker
+// returns once miner_unbonding_period has passed.
+
+// GetMinerRegistration returns a miner's registry entry
+func (k Keeper) GetMinerRegistration(ctx sdk.Context, miner string) (types.MinerRegistration, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.MinerRegistrationKeyPrefix, []byte(miner)...))
+	if bz == nil {
+		return types.MinerRegistration{}, false
+	}
+	var reg types.MinerRegistration
+	k.cdc.MustUnmarshal(bz, &reg)
+	return reg, true
+}
+
+// SetMinerRegistration stores a miner's registry entry, queueing it for bond
+// return while it is unbonding
+func (k Keeper) SetMinerRegistration(ctx sdk.Context, reg types.MinerRegistration) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.MinerRegistrationKeyPrefix, []byte(reg.Miner)...), k.cdc.MustMarshal(&reg))
+	if !reg.IsActive() {
+		store.Set(append(types.MinerUnbondingKeyPrefix, types.MinerUnbondingKey(reg.UnbondingEndsAt, reg.Miner)...), []byte{1})
+	}
+}
+
+// deleteMinerRegistration removes a miner's entry and its unbonding queue entry
+func (k Keeper) deleteMinerRegistration(ctx sdk.Context, reg types.MinerRegistration) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(types.MinerRegistrationKeyPrefix, []byte(reg.Miner)...))
+	store.Delete(append(types.MinerUnbondingKeyPrefix, types.MinerUnbondingKey(reg.UnbondingEndsAt, reg.Miner)...))
+}
+
+// IterateMinerRegistrations iterates over the registry, ordered by miner
+func (k Keeper) IterateMinerRegistrations(ctx sdk.Context, fn func(reg types.MinerRegistration) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.MinerRegistrationKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var reg types.MinerRegistration
+		k.cdc.MustUnmarshal(iterator.Value(), &reg)
+		if fn(reg) {
+			break
+		}
+	}
+}
+
+// requireActiveMiner rejects miners without an active registration while
+// registration is required
+func (k Keeper) requireActiveMiner(ctx sdk.Context, miner string) error {
+	if !k.GetParams(ctx).MinerRegistrationRequired {
+		return nil
+	}
+	reg, found := k.GetMinerRegistration(ctx, miner)
+	if !found {
+		return errorsmod.Wrapf(types.ErrMinerNotRegistered, "%s", miner)
+	}
+	if !reg.IsActive() {
+		return errorsmod.Wrapf(types.ErrMinerUnbonding, "%s", miner)
+	}
+	return nil
+}
+
+// payoutAddress returns where a miner's liquid rewards are sent: its
+// registered payout address, or the miner itself. RegisterMiner and genesis
+// reject bad payout addresses, so one that fails to parse here is logged.
+func (k Keeper) payoutAddress(ctx sdk.Context, miner sdk.AccAddress) sdk.AccAddress {
+	reg, found := k.GetMinerRegistration(ctx, miner.String())
+	if !found || reg.PayoutAddress == "" {
+		return miner
+	}
+	payout, err := sdk.AccAddressFromBech32(reg.PayoutAddress)
+	if err != nil {
+		k.Logger(ctx).Error("Invalid stored payout address, paying the miner", "miner", miner.String(), "payout_address", reg.PayoutAddress, "error", err)
+		return miner
+	}
+	return payout
+}
+
+// ProcessMinerUnbonding returns the bonds of miners whose unbonding period
+// has ended and removes them from the registry. Called in EndBlocker.
+func (k Keeper) ProcessMinerUnbonding(ctx sdk.Context) {
+	now := ctx.BlockTime().Unix()
+
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.MinerUnbondingKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	var matured []string
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if int64(binary.BigEndian.Uint64(key[:8])) > now {
+			break
+		}
+		matured = append(matured, string(key[8:]))
+	}
+	iterator.Close()
+
+	for _, miner := range matured {
+		reg, found := k.GetMinerRegistration(ctx, miner)
+		if !found || reg.IsActive() {
+			continue
+		}
+		if err := k.returnMinerBond(ctx, reg); err != nil {
+			k.Logger(ctx).Error("Failed to return miner bond", "miner", miner, "error", err)
+			continue
+		}
+		k.deleteMinerRegistration(ctx, reg)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"miner_unbonded",
+				sdk.NewAttribute("miner", miner),
+				sdk.NewAttribute("bond", reg.Bond.String()),
+			),
+		)
+	}
+}
+
+func (k Keeper) returnMinerBond(ctx sdk.Context, reg types.MinerRegistration) error {
+	if k.bankKeeper == nil || !reg.Bond.IsPositive() {
+		return nil
+	}
+	minerAddr, err := sdk.AccAddressFromBech32(reg.Miner)
+	if err != nil {
+		return err
+	}
+	coins := sdk.NewCoins(sdk.NewCoin("unexus", reg.Bond))
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, minerAddr, coins); err != nil {
+		return fmt.Errorf("failed to return bond: %w", err)
+	}
+	return nil
+}
+
+// RegisterMiner bonds stake into the registry, or tops up an active miner's
+// bond and replaces its details
+func (k msgServer) RegisterMiner(goCtx context.Context, msg *types.MsgRegisterMiner) (*types.MsgRegisterMinerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	minerAddr, err := sdk.AccAddressFromBech32(msg.Miner)
+	if err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+	for _, coin := range msg.Bond {
+		if coin.Denom != "unexus" {
+			return nil, errorsmod.Wrapf(types.ErrInsufficientBond, "bond must be unexus, got %s", coin.Denom)
+		}
+	}
+	if msg.PayoutAddress != "" {
+		if _, err := sdk.AccAddressFromBech32(msg.PayoutAddress); err != nil {
+			return nil, errorsmod.Wrapf(types.ErrInvalidMiner, "invalid payout address %q: %v", msg.PayoutAddress, err)
+		}
+	}
+
+	reg, found := k.GetMinerRegistration(ctx, msg.Miner)
+	if found && !reg.IsActive() {
+		return nil, errorsmod.Wrapf(types.ErrMinerUnbonding, "unbonding ends at %d", reg.UnbondingEndsAt)
+	}
+	if !found {
+		reg = types.MinerRegistration{
+			Miner:        msg.Miner,
+			Bond:         math.ZeroInt(),
+			Status:       types.MinerStatusActive,
+			RegisteredAt: ctx.BlockTime().Unix(),
+		}
+	}
+
+	added := msg.Bond.AmountOf("unexus")
+	if minBond := k.GetParams(ctx).MinimumMinerBond(); reg.Bond.Add(added).LT(minBond) {
+		return nil, errorsmod.Wrapf(types.ErrInsufficientBond, "need %s, have %s", minBond, reg.Bond.Add(added))
+	}
+	if k.bankKeeper != nil && added.IsPositive() {
+		coins := sdk.NewCoins(sdk.NewCoin("unexus", added))
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, minerAddr, types.ModuleName, coins); err != nil {
+			return nil, fmt.Errorf("failed to bond: %w", err)
+		}
+	}
+
+	reg.Bond = reg.Bond.Add(added)
+	reg.Metadata = msg.Metadata
+	reg.HardwareClass = msg.HardwareClass
+	reg.PayoutAddress = msg.PayoutAddress
+	k.SetMinerRegistration(ctx, reg)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"miner_registered",
+			sdk.NewAttribute("miner", msg.Miner),
+			sdk.NewAttribute("bond", reg.Bond.String()),
+			sdk.NewAttribute("hardware_class", reg.HardwareClass),
+			sdk.NewAttribute("payout_address", reg.PayoutAddress),
+		),
+	)
+
+	return &types.MsgRegisterMinerResponse{Bond: reg.Bond}, nil
+}
+
+// UnbondMiner deactivates a miner and starts its unbonding period
+func (k msgServer) UnbondMiner(goCtx context.Context, msg *types.MsgUnbondMiner) (*types.MsgUnbondMinerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	reg, found := k.GetMinerRegistration(ctx, msg.Miner)
+	if !found {
+		return nil, errorsmod.Wrapf(types.ErrMinerNotRegistered, "%s", msg.Miner)
+	}
+	if !reg.IsActive() {
+		return nil, errorsmod.Wrapf(types.ErrMinerUnbonding, "unbonding ends at %d", reg.UnbondingEndsAt)
+	}
+
+	reg.Status = types.MinerStatusUnbonding
+	reg.UnbondingEndsAt = ctx.BlockTime().Unix() + int64(k.GetParams(ctx).MinerUnbondingPeriod/time.Second)
+	k.SetMinerRegistration(ctx, reg)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"miner_unbonding",
+			sdk.NewAttribute("miner", msg.Miner),
+			sdk.NewAttribute("bond", reg.Bond.String()),
+			sdk.NewAttribute("unbonding_ends_at", fmt.Sprintf("%d", reg.UnbondingEndsAt)),
+		),
+	)
+
+	return &types.MsgUnbondMinerResponse{Bond: reg.Bond, UnbondingEndsAt: reg.UnbondingEndsAt}, nil
+}
+
+// MinerRegistration returns one miner's registry entry
+func (q queryServer) MinerRegistration(goCtx context.Context, req *types.QueryMinerRegistrationRequest) (*types.QueryMinerRegistrationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, err := sdk.AccAddressFromBech32(req.Miner); err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+
+	reg, found := q.Keeper.GetMinerRegistration(ctx, req.Miner)
+	if !found {
+		return nil, errorsmod.Wrapf(types.ErrMinerNotRegistered, "%s", req.Miner)
+	}
+	return &types.QueryMinerRegistrationResponse{Registration: reg}, nil
+}
+
+// MinerRegistry lists registered miners, paginated and ordered by address
+func (q queryServer) MinerRegistry(goCtx context.Context, req *types.QueryMinerRegistryRequest) (*types.QueryMinerRegistryResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	store := prefix.NewStore(ctx.KVStore(q.storeKey), types.MinerRegistrationKeyPrefix)
+	miners := []types.MinerRegistration{}
+	pageRes, err := query.FilteredPaginate(store, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+		var reg types.MinerRegistration
+		if err := q.cdc.Unmarshal(value, &reg); err != nil {
+			return false, err
+		}
+		if req.ActiveOnly && !reg.IsActive() {
+			return false, nil
+		}
+		if accumulate {
+			miners = append(miners, reg)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryMinerRegistryResponse{Miners: miners, Pagination: pageRes}, nil
+}
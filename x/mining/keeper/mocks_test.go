@@ -6,8 +6,11 @@ import (
 
 	"cosmossdk.io/math"
 	cmtprotocrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
@@ -38,6 +41,12 @@ func (m *MockBankKeeper) SendCoinsFromAccountToModule(ctx context.Context, sende
 	if err, ok := m.SendErrors["SendCoinsFromAccountToModule"]; ok {
 		return err
 	}
+	// Module accounts can send like any other account
+	for moduleName := range m.ModuleBalances {
+		if authtypes.NewModuleAddress(moduleName).Equals(senderAddr) {
+			return m.SendCoinsFromModuleToModule(ctx, moduleName, recipientModule, amt)
+		}
+	}
 	senderKey := senderAddr.String()
 	senderBalance := m.Balances[senderKey]
 	if !senderBalance.IsAllGTE(amt) {
@@ -64,6 +73,20 @@ func (m *MockBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, sende
 	return nil
 }
 
+func (m *MockBankKeeper) SendCoinsFromModuleToModule(ctx context.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	if err, ok := m.SendErrors["SendCoinsFromModuleToModule"]; ok {
+		return err
+	}
+	senderBalance := m.ModuleBalances[senderModule]
+	if !senderBalance.IsAllGTE(amt) {
+		return fmt.Errorf("insufficient module funds: %s < %s", senderBalance, amt)
+	}
+	m.ModuleBalances[senderModule] = senderBalance.Sub(amt...)
+	recipientBalance := m.ModuleBalances[recipientModule]
+	m.ModuleBalances[recipientModule] = recipientBalance.Add(amt...)
+	return nil
+}
+
 func (m *MockBankKeeper) MintCoins(ctx context.Context, moduleName string, amt sdk.Coins) error {
 	if err, ok := m.SendErrors["MintCoins"]; ok {
 		return err
@@ -86,6 +109,11 @@ func (m *MockBankKeeper) BurnCoins(ctx context.Context, moduleName string, amt s
 }
 
 func (m *MockBankKeeper) GetBalance(ctx context.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	for moduleName, balance := range m.ModuleBalances {
+		if authtypes.NewModuleAddress(moduleName).Equals(addr) {
+			return sdk.NewCoin(denom, balance.AmountOf(denom))
+		}
+	}
 	balance := m.Balances[addr.String()]
 	return sdk.NewCoin(denom, balance.AmountOf(denom))
 }
@@ -94,6 +122,14 @@ func (m *MockBankKeeper) GetBalance(ctx context.Context, addr sdk.AccAddress, de
 type MockStakingKeeper struct {
 	Validators  []MockValidator
 	TotalBonded int64
+	Slashes     []MockSlash
+}
+
+// MockSlash records a Slash call
+type MockSlash struct {
+	ConsAddr         sdk.ConsAddress
+	InfractionHeight int64
+	SlashFactor      math.LegacyDec
 }
 
 type MockValidator struct {
@@ -116,8 +152,23 @@ func (m *MockStakingKeeper) AddValidator(operatorAddr string, bondedTokens int64
 	m.TotalBonded += bondedTokens
 }
 
+// GetValidator returns a bonded validator for operators added with
+// AddValidator, with a consensus key derived from the operator address
 func (m *MockStakingKeeper) GetValidator(ctx context.Context, addr sdk.ValAddress) (stakingtypes.Validator, error) {
-	return stakingtypes.Validator{}, nil
+	for _, v := range m.Validators {
+		if v.OperatorAddr != addr.String() {
+			continue
+		}
+		pubKey := ed25519.GenPrivKeyFromSecret([]byte(v.OperatorAddr)).PubKey()
+		validator, err := stakingtypes.NewValidator(v.OperatorAddr, pubKey, stakingtypes.Description{})
+		if err != nil {
+			return stakingtypes.Validator{}, err
+		}
+		validator.Status = stakingtypes.Bonded
+		validator.Tokens = math.NewInt(v.BondedTokens)
+		return validator, nil
+	}
+	return stakingtypes.Validator{}, stakingtypes.ErrNoValidatorFound
 }
 
 func (m *MockStakingKeeper) TotalBondedTokens(ctx context.Context) (math.Int, error) {
@@ -141,6 +192,44 @@ func (m *MockStakingKeeper) GetValidatorByConsAddr(ctx context.Context, consAddr
 	return stakingtypes.Validator{}, nil
 }
 
+func (m *MockStakingKeeper) Slash(ctx context.Context, consAddr sdk.ConsAddress, infractionHeight, power int64, slashFactor math.LegacyDec) (math.Int, error) {
+	m.Slashes = append(m.Slashes, MockSlash{ConsAddr: consAddr, InfractionHeight: infractionHeight, SlashFactor: slashFactor})
+	return slashFactor.MulInt64(sdk.TokensFromConsensusPower(power, sdk.DefaultPowerReduction).Int64()).TruncateInt(), nil
+}
+
+func (m *MockStakingKeeper) PowerReduction(ctx context.Context) math.Int {
+	return sdk.DefaultPowerReduction
+}
+
+// MockDistributionKeeper implements types.DistributionKeeper for testing.
+// With Bank set, funding the community pool moves the sender's coins.
+type MockDistributionKeeper struct {
+	Allocations   map[string]sdk.DecCoins
+	CommunityPool sdk.Coins
+	Bank          *MockBankKeeper
+}
+
+func NewMockDistributionKeeper() *MockDistributionKeeper {
+	return &MockDistributionKeeper{
+		Allocations: make(map[string]sdk.DecCoins),
+	}
+}
+
+func (m *MockDistributionKeeper) AllocateTokensToValidator(ctx context.Context, val stakingtypes.ValidatorI, tokens sdk.DecCoins) error {
+	m.Allocations[val.GetOperator()] = m.Allocations[val.GetOperator()].Add(tokens...)
+	return nil
+}
+
+func (m *MockDistributionKeeper) FundCommunityPool(ctx context.Context, amount sdk.Coins, sender sdk.AccAddress) error {
+	if m.Bank != nil {
+		if err := m.Bank.SendCoinsFromAccountToModule(ctx, sender, distrtypes.ModuleName, amount); err != nil {
+			return err
+		}
+	}
+	m.CommunityPool = m.CommunityPool.Add(amount...)
+	return nil
+}
+
 // mockValidatorI implements stakingtypes.ValidatorI
 type mockValidatorI struct {
 	operatorAddr string
@@ -5,10 +5,13 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
 )
@@ -57,43 +60,60 @@ func (k msgServer) PostJob(goCtx context.Context, msg *types.MsgPostJob) (*types
 		return nil, types.ErrInvalidJob
 	}
 
-	// Convert sdk.Coins reward to int64
-	var grossRewardAmount int64
-	if len(msg.Reward) > 0 {
-		grossRewardAmount = msg.Reward[0].Amount.Int64()
+	// Collaborative jobs always run a named algorithm
+	algorithmId := msg.AlgorithmId
+	if msg.MiningMode == types.MiningModeCollaborative {
+		if algorithmId == "" {
+			algorithmId = types.DefaultCollaborativeAlgorithm
+		}
+		if _, err := k.GetActiveAlgorithm(ctx, algorithmId); err != nil {
+			return nil, err
+		}
 	}
 
-	// Get priority fee amount
-	var priorityFeeAmount int64
-	if len(msg.PriorityFee) > 0 {
-		priorityFeeAmount = msg.PriorityFee[0].Amount.Int64()
+	// Permissioned jobs must reference an existing miner group; its members
+	// are copied onto the job so the owner cannot change them afterwards
+	var groupMembers []string
+	if msg.MinerGroup != "" {
+		group, found := k.GetMinerGroup(ctx, msg.MinerGroup)
+		if !found {
+			return nil, types.ErrMinerGroupNotFound
+		}
+		groupMembers = group.Members
 	}
 
+	grossRewardAmount := msg.Reward.AmountOf("unexus")
+
+	// Get priority fee amount
+	priorityFeeAmount := msg.PriorityFee.AmountOf("unexus")
+
 	// Calculate job fee burn (2% of reward)
 	params := k.GetParams(ctx)
 	feeBurnPercent := int64(params.JobFeeBurnPercent)
-	feeBurnAmount := (grossRewardAmount * feeBurnPercent) / 100
-	netRewardAmount := grossRewardAmount - feeBurnAmount
+	feeBurnAmount := grossRewardAmount.MulRaw(feeBurnPercent).QuoRaw(100)
+	netRewardAmount := grossRewardAmount.Sub(feeBurnAmount)
 
 	// Total to collect = reward + priority fee
-	totalToCollect := grossRewardAmount + priorityFeeAmount
+	totalToCollect := grossRewardAmount.Add(priorityFeeAmount)
 
 	// Transfer total amount from customer to module
-	if k.bankKeeper != nil && totalToCollect > 0 {
-		collectCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", totalToCollect))
+	if k.bankKeeper != nil && totalToCollect.IsPositive() {
+		collectCoins := sdk.NewCoins(sdk.NewCoin("unexus", totalToCollect))
 		err = k.bankKeeper.SendCoinsFromAccountToModule(ctx, customerAddr, types.ModuleName, collectCoins)
 		if err != nil {
 			return nil, fmt.Errorf("failed to escrow reward: %w", err)
 		}
 
 		// Burn the job fee (2% of reward) + entire priority fee
-		totalBurnAmount := feeBurnAmount + priorityFeeAmount
-		if totalBurnAmount > 0 {
-			burnCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", totalBurnAmount))
+		totalBurnAmount := feeBurnAmount.Add(priorityFeeAmount)
+		if totalBurnAmount.IsPositive() {
+			burnCoins := sdk.NewCoins(sdk.NewCoin("unexus", totalBurnAmount))
 			err = k.bankKeeper.BurnCoins(ctx, types.ModuleName, burnCoins)
 			if err != nil {
 				return nil, fmt.Errorf("failed to burn fees: %w", err)
 			}
+			k.RecordBurn(ctx, types.BurnSourceJobFee, feeBurnAmount)
+			k.RecordBurn(ctx, types.BurnSourcePriorityFee, priorityFeeAmount)
 
 			ctx.Logger().Info("Burned job fees",
 				"job_id", jobID,
@@ -106,8 +126,8 @@ func (k msgServer) PostJob(goCtx context.Context, msg *types.MsgPostJob) (*types
 				sdk.NewEvent(
 					"fee_burned",
 					sdk.NewAttribute("job_id", jobID),
-					sdk.NewAttribute("job_fee", fmt.Sprintf("%d", feeBurnAmount)),
-					sdk.NewAttribute("priority_fee", fmt.Sprintf("%d", priorityFeeAmount)),
+					sdk.NewAttribute("job_fee", feeBurnAmount.String()),
+					sdk.NewAttribute("priority_fee", priorityFeeAmount.String()),
 					sdk.NewAttribute("type", "paid_job"),
 				),
 			)
@@ -131,6 +151,11 @@ func (k msgServer) PostJob(goCtx context.Context, msg *types.MsgPostJob) (*types
 		Deadline:     0, // Set when activated
 		IsBackground: false,
 		PriorityFee:  priorityFeeAmount,
+		Allowlist:    msg.Allowlist,
+		MinerGroup:   msg.MinerGroup,
+		GroupMembers: groupMembers,
+		MiningMode:   msg.MiningMode,
+		AlgorithmId:  algorithmId,
 	}
 
 	k.SetJob(ctx, job)
@@ -151,9 +176,11 @@ func (k msgServer) PostJob(goCtx context.Context, msg *types.MsgPostJob) (*types
 			"job_posted",
 			sdk.NewAttribute("job_id", jobID),
 			sdk.NewAttribute("customer", msg.Customer),
-			sdk.NewAttribute("net_reward", fmt.Sprintf("%d", netRewardAmount)),
-			sdk.NewAttribute("priority_fee", fmt.Sprintf("%d", priorityFeeAmount)),
+			sdk.NewAttribute("net_reward", netRewardAmount.String()),
+			sdk.NewAttribute("priority_fee", priorityFeeAmount.String()),
 			sdk.NewAttribute("queue_position", fmt.Sprintf("%d", queuePosition)),
+			sdk.NewAttribute("mining_mode", fmt.Sprintf("%d", msg.MiningMode)),
+			sdk.NewAttribute("algorithm_id", algorithmId),
 		),
 	)
 
@@ -162,6 +189,10 @@ func (k msgServer) PostJob(goCtx context.Context, msg *types.MsgPostJob) (*types
 func (k msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof) (*types.MsgSubmitProofResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
+	if err := k.requireActiveMiner(ctx, msg.Miner); err != nil {
+		return nil, err
+	}
+
 	// Get job
 	job, found := k.GetJob(ctx, msg.JobId)
 	if !found {
@@ -172,10 +203,25 @@ func (k msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof)
 		return nil, types.ErrJobNotActive
 	}
 
+	// Proofs are only accepted on competitive jobs; collaborative jobs take SubmitWork
+	if job.MiningMode != types.MiningModeCompetitive {
+		return nil, types.ErrWrongMiningMode
+	}
+
+	if !k.IsMinerAllowed(ctx, job, msg.Miner) {
+		return nil, types.ErrMinerNotAllowed
+	}
+
 	if ctx.BlockHeight() > job.Deadline {
 		return nil, types.ErrJobExpired
 	}
 
+	// Shares go to the pool when the miner submits on its behalf
+	earner, err := k.shareEarner(ctx, msg.Miner, msg.Pool)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the ZK proof via the Nova verification service
 	valid, err := k.verifyNovaProof(msg, job)
 	if err != nil {
@@ -218,13 +264,12 @@ func (k msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof)
 
 	// Update miner's shares for this job
 	if sharesEarned > 0 {
-		minerAddr, err := sdk.AccAddressFromBech32(msg.Miner)
-		if err != nil {
-			return nil, types.ErrInvalidMiner
+		currentShares := k.GetShares(ctx, earner, msg.JobId)
+		k.SetShares(ctx, earner, msg.JobId, currentShares+sharesEarned)
+		if msg.Pool != "" {
+			k.recordPoolContribution(ctx, msg.Pool, msg.JobId, msg.Miner, 0, 1)
 		}
-
-		currentShares := k.GetShares(ctx, minerAddr, msg.JobId)
-		k.SetShares(ctx, minerAddr, msg.JobId, currentShares+sharesEarned)
+		k.attributeToValidator(ctx, msg.Miner, sharesEarned, math.ZeroInt(), 0)
 
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
@@ -234,6 +279,7 @@ func (k msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof)
 				sdk.NewAttribute("energy", fmt.Sprintf("%d", msg.Energy)),
 				sdk.NewAttribute("shares_earned", fmt.Sprintf("%d", sharesEarned)),
 				sdk.NewAttribute("proof_type", msg.ProofType),
+				sdk.NewAttribute("pool", msg.Pool),
 			),
 		)
 	}
@@ -277,9 +323,9 @@ func (k msgServer) verifyNovaProof(msg *types.MsgSubmitProof, job types.Job) (bo
 }
 
 
-// ClaimRewards allows miners to claim their earned rewards with actual token transfer
-// Rewards include: customer payment (80/20 split) + emission reward (time-based, 80/20 split)
-// Validator share remains in module for later distribution to validators
+// ClaimRewards withdraws a miner's settled payout on a job. Payouts are
+// computed once by SettleJob; jobs that finished before settlement existed
+// are settled on their first claim.
 func (k msgServer) ClaimRewards(goCtx context.Context, msg *types.MsgClaimRewards) (*types.MsgClaimRewardsResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -293,67 +339,132 @@ func (k msgServer) ClaimRewards(goCtx context.Context, msg *types.MsgClaimReward
 		return nil, types.ErrUnauthorized
 	}
 
-	shares := k.GetShares(ctx, claimerAddr, msg.JobId)
-	if shares == 0 {
-		return nil, types.ErrNoShares
+	claim, err := k.withdrawClaimableReward(ctx, &job, claimerAddr.String())
+	if err != nil {
+		return nil, err
 	}
 
-	params := k.GetParams(ctx)
-	minerPercent := int64(params.MinerSharePercent)
-
-	// === CUSTOMER REWARD (from escrowed job payment) ===
-	minerProportionalReward := (shares * job.Reward) / job.TotalShares
-	customerMinerReward := (minerProportionalReward * minerPercent) / 100
-	customerValidatorShare := minerProportionalReward - customerMinerReward
-
-	// === EMISSION REWARD (time-based: minutes_to_solve * emission_rate) ===
-	emissionReward := k.ClaimEmissionReward(ctx, job)
-	emissionMinerReward := (emissionReward * minerPercent) / 100
-	emissionValidatorShare := emissionReward - emissionMinerReward
-
-	// === TOTALS ===
-	totalMinerReward := customerMinerReward + emissionMinerReward
-	totalValidatorShare := customerValidatorShare + emissionValidatorShare
-
-	rewardCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", totalMinerReward))
-
-	// Transfer tokens from module to miner
-	if k.bankKeeper != nil && totalMinerReward > 0 {
-		err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, claimerAddr, rewardCoins)
-		if err != nil {
-			return nil, fmt.Errorf("failed to transfer reward: %w", err)
-		}
+	totalMinerReward := claim.Total()
+	rewardCoins := sdk.NewCoins(sdk.NewCoin("unexus", totalMinerReward))
 
+	// Transfer the liquid part to the miner, vest the rest
+	_, vesting, err := k.payMinerReward(ctx, claimerAddr, totalMinerReward, "job:"+job.Id)
+	if err != nil {
+		return nil, err
+	}
+	if totalMinerReward.IsPositive() {
 		ctx.Logger().Info("Mining reward claimed",
 			"job_id", msg.JobId,
 			"claimer", msg.Claimer,
-			"customer_reward", customerMinerReward,
-			"emission_reward", emissionMinerReward,
+			"customer_reward", claim.CustomerReward,
+			"emission_reward", claim.EmissionReward,
 			"total_miner_reward", totalMinerReward,
-			"validator_share", totalValidatorShare,
+			"vesting", vesting,
 		)
 	}
 
-	// Add validator share to reward pool for checkpoint distribution
-	k.AddToValidatorRewardPool(ctx, totalValidatorShare)
-
-	// Clear shares to mark as claimed
-	k.SetShares(ctx, claimerAddr, msg.JobId, 0)
-
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			"rewards_claimed",
 			sdk.NewAttribute("job_id", msg.JobId),
 			sdk.NewAttribute("claimer", msg.Claimer),
-			sdk.NewAttribute("customer_miner_reward", fmt.Sprintf("%d", customerMinerReward)),
-			sdk.NewAttribute("emission_miner_reward", fmt.Sprintf("%d", emissionMinerReward)),
-			sdk.NewAttribute("total_miner_reward", fmt.Sprintf("%d", totalMinerReward)),
-			sdk.NewAttribute("validator_share", fmt.Sprintf("%d", totalValidatorShare)),
+			sdk.NewAttribute("customer_miner_reward", claim.CustomerReward.String()),
+			sdk.NewAttribute("emission_miner_reward", claim.EmissionReward.String()),
+			sdk.NewAttribute("work_reward", claim.WorkReward.String()),
+			sdk.NewAttribute("improvement_reward", claim.ImprovementReward.String()),
+			sdk.NewAttribute("total_miner_reward", totalMinerReward.String()),
+			sdk.NewAttribute("vesting", vesting.String()),
 		),
 	)
 
-	return &types.MsgClaimRewardsResponse{Amount: rewardCoins}, nil
+	return &types.MsgClaimRewardsResponse{
+		Amount:  rewardCoins,
+		Vesting: sdk.NewCoins(sdk.NewCoin("unexus", vesting)),
+	}, nil
 }
+
+// ClaimAllRewards withdraws the claimer's settled payouts across jobs in a
+// single transfer. It walks the miner's outstanding job index, settles jobs
+// that finished before settlement existed, and skips jobs still running.
+func (k msgServer) ClaimAllRewards(goCtx context.Context, msg *types.MsgClaimAllRewards) (*types.MsgClaimAllRewardsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	claimerAddr, err := sdk.AccAddressFromBech32(msg.Claimer)
+	if err != nil {
+		return nil, types.ErrUnauthorized
+	}
+
+	limit := int(msg.Limit)
+	if limit == 0 || limit > types.MaxClaimAllLimit {
+		limit = types.MaxClaimAllLimit
+	}
+	filter := make(map[string]bool, len(msg.JobIds))
+	for _, jobId := range msg.JobIds {
+		filter[jobId] = true
+	}
+
+	// Collect first: withdrawing mutates the index being iterated
+	var jobIds []string
+	k.IterateMinerJobs(ctx, msg.Claimer, func(jobId string) bool {
+		if len(filter) == 0 || filter[jobId] {
+			jobIds = append(jobIds, jobId)
+		}
+		return false
+	})
+
+	total := math.ZeroInt()
+	claims := []types.ClaimableReward{}
+	hasMore := false
+	for _, jobId := range jobIds {
+		job, found := k.GetJob(ctx, jobId)
+		if !found {
+			k.deleteMinerJob(ctx, msg.Claimer, jobId)
+			continue
+		}
+		if !job.IsSettled() && !job.IsFinished() {
+			continue
+		}
+		if len(claims) == limit {
+			hasMore = true
+			break
+		}
+
+		claim, err := k.withdrawClaimableReward(ctx, &job, msg.Claimer)
+		if errors.Is(err, types.ErrNoShares) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(claim.Total())
+		claims = append(claims, claim)
+	}
+
+	rewardCoins := sdk.NewCoins(sdk.NewCoin("unexus", total))
+	_, vesting, err := k.payMinerReward(ctx, claimerAddr, total, "claim_all")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"all_rewards_claimed",
+			sdk.NewAttribute("claimer", msg.Claimer),
+			sdk.NewAttribute("jobs", fmt.Sprintf("%d", len(claims))),
+			sdk.NewAttribute("total_miner_reward", total.String()),
+			sdk.NewAttribute("vesting", vesting.String()),
+			sdk.NewAttribute("has_more", fmt.Sprintf("%t", hasMore)),
+		),
+	)
+
+	return &types.MsgClaimAllRewardsResponse{
+		Amount:  rewardCoins,
+		Claims:  claims,
+		HasMore: hasMore,
+		Vesting: sdk.NewCoins(sdk.NewCoin("unexus", vesting)),
+	}, nil
+}
+
 func (k msgServer) CancelJob(goCtx context.Context, msg *types.MsgCancelJob) (*types.MsgCancelJobResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -366,19 +477,26 @@ func (k msgServer) CancelJob(goCtx context.Context, msg *types.MsgCancelJob) (*t
 		return nil, types.ErrUnauthorized
 	}
 
+	// Only jobs still waiting in the queue can be cancelled: active jobs may
+	// be worked on, and settled jobs were already paid out or refunded
+	if job.Status != types.JobStatusQueued || job.IsSettled() {
+		return nil, errorsmod.Wrap(types.ErrCannotCancel, "only queued jobs can be cancelled")
+	}
+
 	// Can only cancel if no shares have been earned
 	if job.TotalShares > 0 {
 		return nil, types.ErrCannotCancel
 	}
 
 	// Refund net reward to customer (fee was already burned on PostJob)
-	if k.bankKeeper != nil && job.Reward > 0 {
+	refunded := job.Reward
+	if k.bankKeeper != nil && job.Reward.IsPositive() {
 		customerAddr, err := sdk.AccAddressFromBech32(msg.Customer)
 		if err != nil {
 			return nil, types.ErrUnauthorized
 		}
 
-		refundCoins := sdk.NewCoins(sdk.NewInt64Coin("unexus", job.Reward))
+		refundCoins := sdk.NewCoins(sdk.NewCoin("unexus", job.Reward))
 		err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, customerAddr, refundCoins)
 		if err != nil {
 			return nil, fmt.Errorf("failed to refund: %w", err)
@@ -391,21 +509,127 @@ func (k msgServer) CancelJob(goCtx context.Context, msg *types.MsgCancelJob) (*t
 		)
 	}
 
+	// The escrow is gone, so the job can never be refunded or paid again
 	job.Status = types.JobStatusCancelled
+	job.Reward = math.ZeroInt()
 	k.SetJob(ctx, job)
+	k.RemoveFromPaidJobQueue(ctx, job.Id)
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			"job_cancelled",
 			sdk.NewAttribute("job_id", msg.JobId),
 			sdk.NewAttribute("customer", msg.Customer),
-			sdk.NewAttribute("refunded", fmt.Sprintf("%d", job.Reward)),
+			sdk.NewAttribute("refunded", refunded.String()),
 		),
 	)
 
 	return &types.MsgCancelJobResponse{Success: true}, nil
 }
 
+// ExtendJob lets the customer of a paid job top up its escrowed reward and/or
+// push out its deadline. The added reward is charged the same job fee burn as
+// PostJob, and the new deadline may not exceed MaxJobDuration from now.
+func (k msgServer) ExtendJob(goCtx context.Context, msg *types.MsgExtendJob) (*types.MsgExtendJobResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	job, found := k.GetJob(ctx, msg.JobId)
+	if !found {
+		return nil, types.ErrJobNotFound
+	}
+
+	if job.Customer != msg.Customer {
+		return nil, types.ErrUnauthorized
+	}
+
+	if job.IsBackground {
+		return nil, errorsmod.Wrap(types.ErrInvalidExtension, "only paid jobs can be extended")
+	}
+
+	if job.Status != types.JobStatusActive && job.Status != types.JobStatusQueued {
+		return nil, types.ErrJobNotActive
+	}
+
+	// Queued jobs get their deadline on activation, so only the reward can change
+	params := k.GetParams(ctx)
+	newDeadline := job.Deadline
+	if msg.Extension > 0 {
+		if job.Status != types.JobStatusActive {
+			return nil, errorsmod.Wrap(types.ErrInvalidExtension, "deadline can only be extended once the job is active")
+		}
+		// Compare before adding so a huge extension cannot wrap the deadline
+		maxDeadline := ctx.BlockTime().Unix() + int64(params.MaxJobDuration.Seconds())
+		if msg.Extension > maxDeadline-job.Deadline {
+			return nil, errorsmod.Wrapf(types.ErrInvalidExtension,
+				"extension of %ds exceeds max job duration (latest allowed deadline %d)", msg.Extension, maxDeadline)
+		}
+		newDeadline = job.Deadline + msg.Extension
+	}
+
+	grossRewardAmount := msg.AdditionalReward.AmountOf("unexus")
+
+	// Same job fee burn as PostJob
+	feeBurnAmount := grossRewardAmount.MulRaw(int64(params.JobFeeBurnPercent)).QuoRaw(100)
+	netRewardAmount := grossRewardAmount.Sub(feeBurnAmount)
+
+	if k.bankKeeper != nil && grossRewardAmount.IsPositive() {
+		customerAddr, err := sdk.AccAddressFromBech32(msg.Customer)
+		if err != nil {
+			return nil, types.ErrUnauthorized
+		}
+
+		collectCoins := sdk.NewCoins(sdk.NewCoin("unexus", grossRewardAmount))
+		err = k.bankKeeper.SendCoinsFromAccountToModule(ctx, customerAddr, types.ModuleName, collectCoins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to escrow reward: %w", err)
+		}
+
+		if feeBurnAmount.IsPositive() {
+			burnCoins := sdk.NewCoins(sdk.NewCoin("unexus", feeBurnAmount))
+			err = k.bankKeeper.BurnCoins(ctx, types.ModuleName, burnCoins)
+			if err != nil {
+				return nil, fmt.Errorf("failed to burn fees: %w", err)
+			}
+			k.RecordBurn(ctx, types.BurnSourceJobFee, feeBurnAmount)
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					"fee_burned",
+					sdk.NewAttribute("job_id", job.Id),
+					sdk.NewAttribute("job_fee", feeBurnAmount.String()),
+					sdk.NewAttribute("priority_fee", "0"),
+					sdk.NewAttribute("type", "job_extension"),
+				),
+			)
+		}
+	}
+
+	job.Reward = job.Reward.Add(netRewardAmount)
+	job.Deadline = newDeadline
+	k.SetJob(ctx, job)
+
+	ctx.Logger().Info("Job extended",
+		"job_id", job.Id,
+		"added_reward", netRewardAmount,
+		"new_reward", job.Reward,
+		"new_deadline", job.Deadline,
+	)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"job_extended",
+			sdk.NewAttribute("job_id", job.Id),
+			sdk.NewAttribute("customer", msg.Customer),
+			sdk.NewAttribute("added_reward", netRewardAmount.String()),
+			sdk.NewAttribute("new_reward", job.Reward.String()),
+			sdk.NewAttribute("extension", fmt.Sprintf("%d", msg.Extension)),
+			sdk.NewAttribute("new_deadline", fmt.Sprintf("%d", job.Deadline)),
+		),
+	)
+
+	return &types.MsgExtendJobResponse{NewReward: job.Reward, NewDeadline: job.Deadline}, nil
+}
+
 // SubmitPublicJob allows staked users to submit free background jobs for public benefit
 // Requirements: minimum stake, posting fee (burned)
 // Job goes into queue, selected randomly when no paid jobs
@@ -441,6 +665,7 @@ func (k msgServer) SubmitPublicJob(goCtx context.Context, msg *types.MsgSubmitPu
 	if err != nil {
 		return nil, fmt.Errorf("failed to burn posting fee: %w", err)
 	}
+	k.RecordBurn(ctx, types.BurnSourcePostingFee, postingFee.AmountOf("unexus"))
 
 	// Validate problem size meets minimum difficulty
 	minSize := k.GetCurrentProblemSize(ctx)
@@ -467,13 +692,14 @@ func (k msgServer) SubmitPublicJob(goCtx context.Context, msg *types.MsgSubmitPu
 		ProblemData:  msg.ProblemData,
 		ProblemHash:  msg.ProblemHash,
 		Threshold:    msg.Threshold,
-		Reward:       0, // No customer reward - emission only
+		Reward:       math.ZeroInt(), // No customer reward - emission only
 		Status:       types.JobStatusQueued,
 		BestEnergy:   0,
 		TotalShares:  0,
 		CreatedAt:    ctx.BlockTime().Unix(),
 		Deadline:     0, // Set when activated
 		IsBackground: true,
+		MiningMode:   types.MiningModeCompetitive,
 	}
 
 	k.SetJob(ctx, job)
@@ -532,13 +758,18 @@ type CollaborativeWorkVerifyResponse struct {
 	Error           *string `json:"error"`
 }
 
-const CollaborativeVerifierURL = "http://localhost:3000/verify-work"
+// CollaborativeVerifierURL is the Nova verification service endpoint
+var CollaborativeVerifierURL = "http://localhost:3000/verify-work"
 
 // SubmitWork handles collaborative mining work submissions
 // Miners prove: "I ran L steps of algorithm A from seed S, achieving energy E"
 func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (*types.MsgSubmitWorkResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
+	if err := k.requireActiveMiner(ctx, msg.Miner); err != nil {
+		return nil, err
+	}
+
 	// Get job
 	job, found := k.GetJob(ctx, msg.JobId)
 	if !found {
@@ -549,19 +780,57 @@ func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (
 		return nil, types.ErrJobNotActive
 	}
 
+	// Work submissions are only accepted on collaborative jobs
+	if job.MiningMode != types.MiningModeCollaborative {
+		return nil, types.ErrWrongMiningMode
+	}
+
+	if !k.IsMinerAllowed(ctx, job, msg.Miner) {
+		return nil, types.ErrMinerNotAllowed
+	}
+
 	if ctx.BlockHeight() > job.Deadline {
 		return nil, types.ErrJobExpired
 	}
 
-	// Verify epoch matches current job epoch
-	if msg.Epoch != job.CurrentEpoch {
-		return nil, fmt.Errorf("epoch mismatch: expected %d, got %d", job.CurrentEpoch, msg.Epoch)
+	// Verify epoch matches current job epoch; work from earlier epochs was
+	// seeded with stale randomness and must be resubmitted
+	if msg.Epoch < job.CurrentEpoch {
+		return nil, errorsmod.Wrapf(types.ErrStaleEpoch, "job %s is in epoch %d, got %d", job.Id, job.CurrentEpoch, msg.Epoch)
+	}
+	if msg.Epoch > job.CurrentEpoch {
+		return nil, errorsmod.Wrapf(types.ErrFutureEpoch, "job %s is in epoch %d, got %d", job.Id, job.CurrentEpoch, msg.Epoch)
 	}
 
-	// Verify algorithm matches
-	if msg.AlgorithmId != "" && job.AlgorithmId != "" && msg.AlgorithmId != job.AlgorithmId {
+	// Verify algorithm matches the job and is still active in the registry
+	if msg.AlgorithmId != "" && msg.AlgorithmId != job.AlgorithmId {
 		return nil, fmt.Errorf("algorithm mismatch: expected %s, got %s", job.AlgorithmId, msg.AlgorithmId)
 	}
+	algo, err := k.GetActiveAlgorithm(ctx, job.AlgorithmId)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single run can't exceed the algorithm's canonical step count, and
+	// steps must fit the int64 share totals even when no maximum is set
+	if msg.NumSteps > types.MaxNumSteps {
+		return nil, errorsmod.Wrapf(types.ErrInvalidStepCount, "%d steps", msg.NumSteps)
+	}
+	if algo.MaxSteps > 0 && msg.NumSteps > algo.MaxSteps {
+		return nil, errorsmod.Wrapf(types.ErrInvalidStepCount, "%d steps, %s allows %d", msg.NumSteps, algo.Id, algo.MaxSteps)
+	}
+
+	// Per-miner submission and step limits for this epoch
+	usage := k.GetMinerEpochUsage(ctx, msg.JobId, msg.Miner, msg.Epoch)
+	if err := checkEpochLimits(k.GetParams(ctx), usage, msg.NumSteps); err != nil {
+		return nil, err
+	}
+
+	// Shares go to the pool when the miner submits on its behalf
+	earner, err := k.shareEarner(ctx, msg.Miner, msg.Pool)
+	if err != nil {
+		return nil, err
+	}
 
 	// Verify the collaborative work proof via Nova verification service
 	valid, err := k.verifyCollaborativeWorkProof(msg, job)
@@ -604,16 +873,23 @@ func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (
 	job.BonusPoolShares += bonusShares
 	job.TotalShares += workShares + bonusShares
 	job.SubmissionCount++
+
+	// Fold into the job's solution landscape
+	if k.RecordLandscapeSubmission(ctx, msg.JobId, msg.Miner, msg.Epoch, msg.NumSteps, msg.BestEnergy, msg.BestConfigHash) {
+		job.DistinctMinima++
+	}
 	k.SetJob(ctx, job)
 
 	// Record work submission
-	minerAddr, err := sdk.AccAddressFromBech32(msg.Miner)
-	if err != nil {
-		return nil, types.ErrInvalidMiner
-	}
+	// Each submission gets its own sequence number within the epoch so
+	// repeated submissions never overwrite earlier records
+	sequence := usage.Submissions
+	usage.Submissions++
+	usage.Steps += msg.NumSteps
+	k.SetMinerEpochUsage(ctx, msg.JobId, msg.Miner, msg.Epoch, usage)
 
 	submission := types.WorkSubmission{
-		Id:             fmt.Sprintf("%s_%s_%d", msg.JobId, msg.Miner[:8], msg.Epoch),
+		Id:             types.WorkSubmissionID(msg.JobId, msg.Miner, msg.Epoch, sequence),
 		JobId:          msg.JobId,
 		Miner:          msg.Miner,
 		Epoch:          msg.Epoch,
@@ -626,18 +902,23 @@ func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (
 		WorkShares:     workShares,
 		BonusShares:    bonusShares,
 		SubmittedAt:    ctx.BlockTime().Unix(),
+		Sequence:       sequence,
 	}
 	k.SetWorkSubmission(ctx, submission)
 
-	// Update miner's shares
-	currentWorkShares := k.GetWorkShares(ctx, minerAddr, msg.JobId)
-	currentBonusShares := k.GetBonusShares(ctx, minerAddr, msg.JobId)
-	k.SetWorkShares(ctx, minerAddr, msg.JobId, currentWorkShares+workShares)
-	k.SetBonusShares(ctx, minerAddr, msg.JobId, currentBonusShares+bonusShares)
+	// Update the earner's shares (the miner, or its pool)
+	currentWorkShares := k.GetWorkShares(ctx, earner, msg.JobId)
+	currentBonusShares := k.GetBonusShares(ctx, earner, msg.JobId)
+	k.SetWorkShares(ctx, earner, msg.JobId, currentWorkShares+workShares)
+	k.SetBonusShares(ctx, earner, msg.JobId, currentBonusShares+bonusShares)
 
 	// Also update total shares for backward compatibility
-	currentShares := k.GetShares(ctx, minerAddr, msg.JobId)
-	k.SetShares(ctx, minerAddr, msg.JobId, currentShares+workShares+bonusShares)
+	currentShares := k.GetShares(ctx, earner, msg.JobId)
+	k.SetShares(ctx, earner, msg.JobId, currentShares+workShares+bonusShares)
+	if msg.Pool != "" {
+		k.recordPoolContribution(ctx, msg.Pool, msg.JobId, msg.Miner, msg.NumSteps, 1)
+	}
+	k.attributeToValidator(ctx, msg.Miner, workShares+bonusShares, math.ZeroInt(), 0)
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -645,11 +926,13 @@ func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (
 			sdk.NewAttribute("job_id", msg.JobId),
 			sdk.NewAttribute("miner", msg.Miner),
 			sdk.NewAttribute("epoch", fmt.Sprintf("%d", msg.Epoch)),
+			sdk.NewAttribute("sequence", fmt.Sprintf("%d", sequence)),
 			sdk.NewAttribute("num_steps", fmt.Sprintf("%d", msg.NumSteps)),
 			sdk.NewAttribute("final_energy", fmt.Sprintf("%d", msg.FinalEnergy)),
 			sdk.NewAttribute("best_energy", fmt.Sprintf("%d", msg.BestEnergy)),
 			sdk.NewAttribute("work_shares", fmt.Sprintf("%d", workShares)),
 			sdk.NewAttribute("bonus_shares", fmt.Sprintf("%d", bonusShares)),
+			sdk.NewAttribute("pool", msg.Pool),
 		),
 	)
 
@@ -663,9 +946,10 @@ func (k msgServer) SubmitWork(goCtx context.Context, msg *types.MsgSubmitWork) (
 	)
 
 	return &types.MsgSubmitWorkResponse{
-		Accepted:    true,
-		WorkShares:  workShares,
-		BonusShares: bonusShares,
+		Accepted:     true,
+		WorkShares:   workShares,
+		BonusShares:  bonusShares,
+		SubmissionId: submission.Id,
 	}, nil
 }
 
@@ -708,3 +992,31 @@ func (k msgServer) verifyCollaborativeWorkProof(msg *types.MsgSubmitWork, job ty
 
 	return verifyResp.Valid && verifyResp.SeedCorrect && verifyResp.EnergyVerified, nil
 }
+
+// UpdateParams replaces the module parameters, including the emission
+// schedule and supply cap (governance only)
+func (k msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Authority != k.authority {
+		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "expected %s, got %s", k.authority, msg.Authority)
+	}
+	if err := msg.Params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := k.SetParams(ctx, msg.Params); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"params_updated",
+			sdk.NewAttribute("authority", msg.Authority),
+			sdk.NewAttribute("emission_base_rate", msg.Params.EmissionBaseRate.String()),
+			sdk.NewAttribute("emission_supply_cap", msg.Params.EmissionSupplyCap.String()),
+		),
+	)
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"cosmossdk.io/math"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/docking"
 	"nexus/x/mining/types"
@@ -73,6 +75,10 @@ func (k msgServer) CreateDockingJob(goCtx context.Context, msg *types.MsgCreateD
 func (k msgServer) ClaimDockingJob(goCtx context.Context, msg *types.MsgClaimDockingJob) (*types.MsgClaimDockingJobResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
+	if err := k.requireActiveMiner(ctx, msg.Miner); err != nil {
+		return nil, err
+	}
+
 	var job types.DockingJob
 	var found bool
 
@@ -200,15 +206,10 @@ func (k msgServer) SubmitDockingResult(goCtx context.Context, msg *types.MsgSubm
 	}
 	k.SetDockingJob(ctx, job)
 
-	// Track miner rewards (for later distribution)
+	// Credit weighted epoch shares; the epoch's emission is split by them
 	minerAddr, _ := sdk.AccAddressFromBech32(msg.Miner)
-	k.AddMinerDockingRewards(ctx, minerAddr, reward)
-
-	// For background jobs, mint and distribute reward immediately
-	if job.IsBackground && k.bankKeeper != nil {
-		// Rewards tracked for later distribution via emission
-		// Mint from emission (handled in ABCI) - for now just track
-	}
+	shares := k.AddMinerEpochShares(ctx, minerAddr, int(msg.RotatableBonds))
+	k.attributeToValidator(ctx, msg.Miner, shares, math.ZeroInt(), 0)
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -218,6 +219,8 @@ func (k msgServer) SubmitDockingResult(goCtx context.Context, msg *types.MsgSubm
 			sdk.NewAttribute("miner", msg.Miner),
 			sdk.NewAttribute("binding_score", fmt.Sprintf("%.2f", msg.BindingScore)),
 			sdk.NewAttribute("reward", fmt.Sprintf("%d", reward)),
+			sdk.NewAttribute("shares", fmt.Sprintf("%d", shares)),
+			sdk.NewAttribute("epoch", fmt.Sprintf("%d", k.GetDockingEpochNumber(ctx))),
 			sdk.NewAttribute("is_hit", fmt.Sprintf("%t", isHit)),
 		),
 	)
@@ -235,6 +238,7 @@ func (k msgServer) SubmitDockingResult(goCtx context.Context, msg *types.MsgSubm
 		Accepted: true,
 		Reward:   reward,
 		IsHit:    isHit,
+		Shares:   shares,
 	}, nil
 }
 
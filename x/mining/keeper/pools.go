@@ -0,0 +1,407 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// MINING POOLS
+// ============================================
+//
+// A member submits proofs or work with MsgSubmitProof.Pool /
+// MsgSubmitWork.Pool set. The shares go to the pool's derived address, so
+// settlement treats the pool as a single miner, and the member's
+// contribution to the job is recorded: steps for collaborative work, one
+// submission per share-earning proof for competitive jobs.
+//
+// MsgClaimPoolRewards withdraws the pool's settled payout on a job. The
+// operator takes fee_percent plus any truncation dust; the rest is split
+// across the members who contributed, pro-rata by that job's contribution.
+// Members and the operator are paid like solo miners, so vesting applies.
+// A payout with no recorded contribution goes back to the job's workload
+// escrow rather than to the operator.
+
+// GetPool returns a mining pool by ID
+func (k Keeper) GetPool(ctx sdk.Context, id string) (types.MiningPool, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.PoolKeyPrefix, []byte(id)...))
+	if bz == nil {
+		return types.MiningPool{}, false
+	}
+	var pool types.MiningPool
+	k.cdc.MustUnmarshal(bz, &pool)
+	return pool, true
+}
+
+// SetPool stores a mining pool under its ID
+func (k Keeper) SetPool(ctx sdk.Context, pool types.MiningPool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.PoolKeyPrefix, []byte(pool.Id)...), k.cdc.MustMarshal(&pool))
+}
+
+// IteratePools iterates over all mining pools
+func (k Keeper) IteratePools(ctx sdk.Context, fn func(pool types.MiningPool) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.PoolKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var pool types.MiningPool
+		k.cdc.MustUnmarshal(iterator.Value(), &pool)
+		if fn(pool) {
+			break
+		}
+	}
+}
+
+// GetPoolContribution returns a member's recorded work for a pool on a job
+func (k Keeper) GetPoolContribution(ctx sdk.Context, poolId, jobId, member string) types.PoolContribution {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.PoolContributionKeyPrefix, types.PoolContributionKey(poolId, jobId, member)...))
+	if bz == nil {
+		return types.PoolContribution{PoolId: poolId, JobId: jobId, Member: member}
+	}
+	var contribution types.PoolContribution
+	k.cdc.MustUnmarshal(bz, &contribution)
+	return contribution
+}
+
+// SetPoolContribution stores a member's recorded work for a pool on a job
+func (k Keeper) SetPoolContribution(ctx sdk.Context, contribution types.PoolContribution) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.PoolContributionKey(contribution.PoolId, contribution.JobId, contribution.Member)
+	store.Set(append(types.PoolContributionKeyPrefix, key...), k.cdc.MustMarshal(&contribution))
+}
+
+// IteratePoolContributions iterates over every member's work for a pool on a job
+func (k Keeper) IteratePoolContributions(ctx sdk.Context, poolId, jobId string, fn func(contribution types.PoolContribution) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.PoolContributionKeyPrefix, []byte(poolId+"/"+jobId+"/")...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var contribution types.PoolContribution
+		k.cdc.MustUnmarshal(iterator.Value(), &contribution)
+		if fn(contribution) {
+			break
+		}
+	}
+}
+
+// recordPoolContribution adds a member's steps and submissions on a job
+func (k Keeper) recordPoolContribution(ctx sdk.Context, poolId, jobId, member string, steps, submissions uint64) {
+	contribution := k.GetPoolContribution(ctx, poolId, jobId, member)
+	contribution.Steps += steps
+	contribution.Submissions += submissions
+	k.SetPoolContribution(ctx, contribution)
+}
+
+// GetPoolMemberEarnings returns a member's cumulative payout from a pool
+func (k Keeper) GetPoolMemberEarnings(ctx sdk.Context, poolId, member string) math.Int {
+	return k.getInt(ctx, append(types.PoolEarningsKeyPrefix, []byte(poolId+"/"+member)...))
+}
+
+// SetPoolMemberEarnings sets a member's cumulative payout from a pool
+func (k Keeper) SetPoolMemberEarnings(ctx sdk.Context, poolId, member string, amount math.Int) {
+	k.setInt(ctx, append(types.PoolEarningsKeyPrefix, []byte(poolId+"/"+member)...), amount)
+}
+
+// IteratePoolMemberEarnings iterates over every member's payout from a pool
+func (k Keeper) IteratePoolMemberEarnings(ctx sdk.Context, poolId string, fn func(earnings types.PoolMemberEarnings) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.PoolEarningsKeyPrefix, []byte(poolId+"/")...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var amount math.Int
+		if err := amount.Unmarshal(iterator.Value()); err != nil {
+			panic(err)
+		}
+		if fn(types.PoolMemberEarnings{Member: string(iterator.Key()), Amount: amount}) {
+			break
+		}
+	}
+}
+
+// shareEarner returns the account credited for a submission: the pool's
+// address when the miner submits on behalf of a pool it belongs to,
+// otherwise the miner itself
+func (k Keeper) shareEarner(ctx sdk.Context, miner, poolId string) (sdk.AccAddress, error) {
+	if poolId == "" {
+		minerAddr, err := sdk.AccAddressFromBech32(miner)
+		if err != nil {
+			return nil, types.ErrInvalidMiner
+		}
+		return minerAddr, nil
+	}
+	pool, found := k.GetPool(ctx, poolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+	if !pool.HasMember(miner) {
+		return nil, errorsmod.Wrapf(types.ErrNotPoolMember, "%s is not in pool %s", miner, poolId)
+	}
+	return pool.Address(), nil
+}
+
+// CreatePool registers a new mining pool operated by the sender
+func (k msgServer) CreatePool(goCtx context.Context, msg *types.MsgCreatePool) (*types.MsgCreatePoolResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, found := k.GetPool(ctx, msg.PoolId); found {
+		return nil, types.ErrPoolExists
+	}
+	if !types.ValidPoolId(msg.PoolId) || msg.FeePercent > 100 {
+		return nil, types.ErrInvalidParams
+	}
+
+	members := []string{}
+	seen := make(map[string]bool, len(msg.Members))
+	for _, member := range msg.Members {
+		if _, err := sdk.AccAddressFromBech32(member); err != nil {
+			return nil, types.ErrInvalidMiner
+		}
+		if !seen[member] {
+			seen[member] = true
+			members = append(members, member)
+		}
+	}
+
+	pool := types.MiningPool{
+		Id:          msg.PoolId,
+		Operator:    msg.Operator,
+		FeePercent:  msg.FeePercent,
+		Members:     members,
+		OpenJoin:    msg.OpenJoin,
+		CreatedAt:   ctx.BlockTime().Unix(),
+		TotalEarned: math.ZeroInt(),
+		TotalFees:   math.ZeroInt(),
+	}
+	k.SetPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pool_created",
+			sdk.NewAttribute("pool_id", pool.Id),
+			sdk.NewAttribute("operator", pool.Operator),
+			sdk.NewAttribute("address", pool.Address().String()),
+			sdk.NewAttribute("fee_percent", fmt.Sprintf("%d", pool.FeePercent)),
+			sdk.NewAttribute("open_join", fmt.Sprintf("%t", pool.OpenJoin)),
+		),
+	)
+
+	return &types.MsgCreatePoolResponse{Address: pool.Address().String()}, nil
+}
+
+// JoinPool adds the sender to an open mining pool
+func (k msgServer) JoinPool(goCtx context.Context, msg *types.MsgJoinPool) (*types.MsgJoinPoolResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pool, found := k.GetPool(ctx, msg.PoolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+	if !pool.OpenJoin {
+		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "pool %s is closed", pool.Id)
+	}
+	if pool.HasMember(msg.Member) {
+		return &types.MsgJoinPoolResponse{}, nil
+	}
+	if len(pool.Members) >= types.MaxAllowlistSize {
+		return nil, errorsmod.Wrapf(types.ErrInvalidParams, "pool %s is full", pool.Id)
+	}
+
+	pool.Members = append(pool.Members, msg.Member)
+	k.SetPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pool_joined",
+			sdk.NewAttribute("pool_id", pool.Id),
+			sdk.NewAttribute("member", msg.Member),
+		),
+	)
+
+	return &types.MsgJoinPoolResponse{}, nil
+}
+
+// LeavePool removes the sender from a mining pool
+func (k msgServer) LeavePool(goCtx context.Context, msg *types.MsgLeavePool) (*types.MsgLeavePoolResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pool, found := k.GetPool(ctx, msg.PoolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+	if !pool.HasMember(msg.Member) {
+		return nil, types.ErrNotPoolMember
+	}
+
+	members := make([]string, 0, len(pool.Members)-1)
+	for _, member := range pool.Members {
+		if member != msg.Member {
+			members = append(members, member)
+		}
+	}
+	pool.Members = members
+	k.SetPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pool_left",
+			sdk.NewAttribute("pool_id", pool.Id),
+			sdk.NewAttribute("member", msg.Member),
+		),
+	)
+
+	return &types.MsgLeavePoolResponse{}, nil
+}
+
+// ClaimPoolRewards withdraws a pool's settled payout on a job and splits it
+// between the operator's fee and the contributing members
+func (k msgServer) ClaimPoolRewards(goCtx context.Context, msg *types.MsgClaimPoolRewards) (*types.MsgClaimPoolRewardsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pool, found := k.GetPool(ctx, msg.PoolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+	if msg.Claimer != pool.Operator && !pool.HasMember(msg.Claimer) {
+		return nil, types.ErrNotPoolMember
+	}
+	operatorAddr, err := sdk.AccAddressFromBech32(pool.Operator)
+	if err != nil {
+		return nil, types.ErrUnauthorized
+	}
+
+	job, found := k.GetJob(ctx, msg.JobId)
+	if !found {
+		return nil, types.ErrJobNotFound
+	}
+
+	claim, err := k.withdrawClaimableReward(ctx, &job, pool.Address().String())
+	if err != nil {
+		return nil, err
+	}
+	total := claim.Total()
+	memberShare := total.Sub(total.MulRaw(int64(pool.FeePercent)).QuoRaw(100))
+	source := "pool:" + pool.Id + ":" + job.Id
+
+	// Collect first: paying mutates state under the iterated prefix
+	var contributions []types.PoolContribution
+	totalWeight := math.ZeroInt()
+	k.IteratePoolContributions(ctx, pool.Id, job.Id, func(contribution types.PoolContribution) bool {
+		contributions = append(contributions, contribution)
+		totalWeight = totalWeight.Add(math.NewIntFromUint64(contribution.Weight(job.MiningMode)))
+		return false
+	})
+
+	payouts := []types.PoolMemberEarnings{}
+	paid := math.ZeroInt()
+	for _, contribution := range contributions {
+		weight := contribution.Weight(job.MiningMode)
+		if weight == 0 {
+			continue
+		}
+		amount := memberShare.Mul(math.NewIntFromUint64(weight)).Quo(totalWeight)
+		if !amount.IsPositive() {
+			continue
+		}
+		memberAddr, err := sdk.AccAddressFromBech32(contribution.Member)
+		if err != nil {
+			continue
+		}
+		if _, _, err := k.payMinerReward(ctx, memberAddr, amount, source); err != nil {
+			return nil, err
+		}
+		paid = paid.Add(amount)
+		k.SetPoolMemberEarnings(ctx, pool.Id, contribution.Member, k.GetPoolMemberEarnings(ctx, pool.Id, contribution.Member).Add(amount))
+		payouts = append(payouts, types.PoolMemberEarnings{Member: contribution.Member, Amount: amount})
+	}
+
+	// The operator keeps the fee and whatever the split left over. Without
+	// any contribution there is nothing to take a fee on, so the payout
+	// returns to the workload escrow.
+	fee := math.ZeroInt()
+	if totalWeight.IsZero() {
+		workload := JobWorkload(job)
+		k.SetWorkloadEscrow(ctx, workload, k.GetWorkloadEscrow(ctx, workload).Add(total))
+	} else if fee = total.Sub(paid); fee.IsPositive() {
+		if _, _, err := k.payMinerReward(ctx, operatorAddr, fee, source); err != nil {
+			return nil, err
+		}
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	for _, contribution := range contributions {
+		store.Delete(append(types.PoolContributionKeyPrefix, types.PoolContributionKey(pool.Id, job.Id, contribution.Member)...))
+	}
+
+	pool.TotalEarned = pool.TotalEarned.Add(paid).Add(fee)
+	pool.TotalFees = pool.TotalFees.Add(fee)
+	k.SetPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pool_rewards_claimed",
+			sdk.NewAttribute("pool_id", pool.Id),
+			sdk.NewAttribute("job_id", job.Id),
+			sdk.NewAttribute("claimer", msg.Claimer),
+			sdk.NewAttribute("total", total.String()),
+			sdk.NewAttribute("fee", fee.String()),
+			sdk.NewAttribute("members_paid", fmt.Sprintf("%d", len(payouts))),
+		),
+	)
+
+	return &types.MsgClaimPoolRewardsResponse{Total: total, Fee: fee, Payouts: payouts}, nil
+}
+
+// Pool returns a mining pool with its members and share-holding address
+func (q queryServer) Pool(goCtx context.Context, req *types.QueryPoolRequest) (*types.QueryPoolResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	pool, found := q.Keeper.GetPool(ctx, req.PoolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+	return &types.QueryPoolResponse{Pool: pool, Address: pool.Address().String()}, nil
+}
+
+// PoolEarnings reports a pool's claimed earnings per member and its settled
+// payouts not yet claimed
+func (q queryServer) PoolEarnings(goCtx context.Context, req *types.QueryPoolEarningsRequest) (*types.QueryPoolEarningsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	pool, found := q.Keeper.GetPool(ctx, req.PoolId)
+	if !found {
+		return nil, types.ErrPoolNotFound
+	}
+
+	resp := &types.QueryPoolEarningsResponse{
+		TotalEarned: pool.TotalEarned,
+		TotalFees:   pool.TotalFees,
+		Members:     []types.PoolMemberEarnings{},
+		Unclaimed:   math.ZeroInt(),
+	}
+	q.Keeper.IteratePoolMemberEarnings(ctx, pool.Id, func(earnings types.PoolMemberEarnings) bool {
+		resp.Members = append(resp.Members, earnings)
+		return false
+	})
+
+	poolAddr := pool.Address().String()
+	q.Keeper.IterateMinerJobs(ctx, poolAddr, func(jobId string) bool {
+		if claim, found := q.Keeper.GetClaimableReward(ctx, jobId, poolAddr); found {
+			resp.Unclaimed = resp.Unclaimed.Add(claim.Total())
+		}
+		return false
+	})
+	return resp, nil
+}
@@ -72,6 +72,7 @@ func queryActiveJob(ctx sdk.Context, k Keeper) ([]byte, error) {
 		"job":            job,
 		"time_remaining": timeRemaining,
 		"problem_size":   k.GetCurrentProblemSize(ctx),
+		"is_open":        job.IsOpen(),
 	})
 }
 
@@ -109,47 +110,16 @@ func queryQueueStatus(ctx sdk.Context, k Keeper) ([]byte, error) {
 }
 
 func queryEmissionInfo(ctx sdk.Context, k Keeper) ([]byte, error) {
-	currentEpoch := k.GetCurrentEpoch(ctx)
-	emissionRate := k.GetCurrentEmissionRate(ctx)
-	emissionEscrow := k.GetEmissionEscrow(ctx)
-	genesisMinute := k.GetGenesisMinute(ctx)
-	currentMinute := ctx.BlockTime().Unix() / 60
-
-	minutesSinceGenesis := currentMinute - genesisMinute
-	epochDuration := int64(EpochDurationMinutes)
-
-	var minutesIntoEpoch int64
-	var minutesUntilNext int64
-
-	if currentEpoch < 8 {
-		epochStartMinute := int64(currentEpoch-1) * epochDuration
-		minutesIntoEpoch = minutesSinceGenesis - epochStartMinute
-		minutesUntilNext = epochDuration - minutesIntoEpoch
-	} else {
-		epochStartMinute := int64(7) * epochDuration
-		minutesIntoEpoch = minutesSinceGenesis - epochStartMinute
-		minutesUntilNext = -1
-	}
-
-	var nextEpochRate int64
-	if currentEpoch < 8 {
-		epochs := GetEmissionEpochs()
-		if currentEpoch < len(epochs) {
-			nextEpochRate = (BaseEmissionPerMinute * epochs[currentEpoch].RatePercent) / 1000
-		}
-	} else {
-		nextEpochRate = emissionRate
-	}
-
+	info := k.GetEmissionInfo(ctx)
 	return json.Marshal(map[string]interface{}{
-		"current_epoch":      currentEpoch,
-		"emission_rate":      emissionRate,
-		"emission_escrow":    emissionEscrow,
-		"genesis_time":       genesisMinute * 60,
-		"minutes_into_epoch": minutesIntoEpoch,
-		"minutes_until_next": minutesUntilNext,
-		"epoch_duration":     epochDuration,
-		"next_epoch_rate":    nextEpochRate,
+		"current_epoch":      info.CurrentEpoch,
+		"emission_rate":      info.EmissionRate,
+		"emission_escrow":    info.EmissionEscrow,
+		"genesis_time":       info.GenesisTime,
+		"minutes_into_epoch": info.MinutesIntoEpoch,
+		"minutes_until_next": info.MinutesUntilNext,
+		"epoch_duration":     info.EpochDuration,
+		"next_epoch_rate":    info.NextEpochRate,
 	})
 }
 
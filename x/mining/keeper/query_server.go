@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"nexus/x/mining/types"
 )
@@ -72,7 +73,7 @@ func (q queryServer) MinerStats(goCtx context.Context, req *types.QueryMinerStat
 
 	var totalShares int64
 	var jobsParticipated int64
-	var pendingRewards int64
+	pendingRewards := math.ZeroInt()
 	var activeJobs []types.MinerJobInfo
 
 	// Iterate through all jobs to find miner's participation
@@ -88,14 +89,14 @@ func (q queryServer) MinerStats(goCtx context.Context, req *types.QueryMinerStat
 				minerPercent := int64(params.MinerSharePercent)
 				
 				// Customer reward portion
-				minerProportionalReward := (shares * job.Reward) / job.TotalShares
-				customerMinerReward := (minerProportionalReward * minerPercent) / 100
+				minerProportionalReward := mulDiv(job.Reward, shares, job.TotalShares)
+				customerMinerReward := minerProportionalReward.MulRaw(minerPercent).QuoRaw(100)
 				
 				// Emission reward portion
 				emissionReward := q.Keeper.CalculateEmissionReward(ctx, job)
-				emissionMinerReward := (emissionReward * minerPercent) / 100
+				emissionMinerReward := emissionReward.MulRaw(minerPercent).QuoRaw(100)
 				
-				pendingRewards += customerMinerReward + emissionMinerReward
+				pendingRewards = pendingRewards.Add(customerMinerReward).Add(emissionMinerReward)
 			}
 
 			// Add to active jobs list if job is still active
@@ -114,7 +115,7 @@ func (q queryServer) MinerStats(goCtx context.Context, req *types.QueryMinerStat
 	return &types.QueryMinerStatsResponse{
 		MinerAddress:     req.MinerAddress,
 		TotalShares:      totalShares,
-		PendingRewards:   sdk.NewCoins(sdk.NewInt64Coin("unexus", pendingRewards)),
+		PendingRewards:   sdk.NewCoins(sdk.NewCoin("unexus", pendingRewards)),
 		JobsParticipated: jobsParticipated,
 		TotalClaimed:     sdk.NewCoins(), // TODO: Track claimed rewards
 		ActiveJobs:       activeJobs,
@@ -151,6 +152,7 @@ func (q queryServer) ActiveJob(goCtx context.Context, req *types.QueryActiveJobR
 		Job:           &job,
 		TimeRemaining: timeRemaining,
 		ProblemSize:   q.Keeper.GetCurrentProblemSize(ctx),
+		IsOpen:        job.IsOpen(),
 	}, nil
 }
 
@@ -193,52 +195,8 @@ func (q queryServer) QueueStatus(goCtx context.Context, req *types.QueryQueueSta
 
 func (q queryServer) EmissionInfo(goCtx context.Context, req *types.QueryEmissionInfoRequest) (*types.QueryEmissionInfoResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	
-	currentEpoch := q.Keeper.GetCurrentEpoch(ctx)
-	emissionRate := q.Keeper.GetCurrentEmissionRate(ctx)
-	emissionEscrow := q.Keeper.GetEmissionEscrow(ctx)
-	genesisMinute := q.Keeper.GetGenesisMinute(ctx)
-	currentMinute := ctx.BlockTime().Unix() / 60
-
-	// Calculate minutes into current epoch
-	minutesSinceGenesis := currentMinute - genesisMinute
-	epochDuration := int64(EpochDurationMinutes)
-	
-	var minutesIntoEpoch int64
-	var minutesUntilNext int64
-	
-	if currentEpoch < 8 {
-		epochStartMinute := int64(currentEpoch-1) * epochDuration
-		minutesIntoEpoch = minutesSinceGenesis - epochStartMinute
-		minutesUntilNext = epochDuration - minutesIntoEpoch
-	} else {
-		// Perpetual epoch
-		epochStartMinute := int64(7) * epochDuration
-		minutesIntoEpoch = minutesSinceGenesis - epochStartMinute
-		minutesUntilNext = -1 // No next epoch
-	}
-
-	// Calculate next epoch rate
-	var nextEpochRate int64
-	if currentEpoch < 8 {
-		epochs := GetEmissionEpochs()
-		if currentEpoch < len(epochs) {
-			nextEpochRate = (BaseEmissionPerMinute * epochs[currentEpoch].RatePercent) / 1000
-		}
-	} else {
-		nextEpochRate = emissionRate // Same rate perpetually
-	}
-
-	return &types.QueryEmissionInfoResponse{
-		CurrentEpoch:     int32(currentEpoch),
-		EmissionRate:     emissionRate,
-		EmissionEscrow:   emissionEscrow,
-		GenesisTime:      genesisMinute * 60,
-		MinutesIntoEpoch: minutesIntoEpoch,
-		MinutesUntilNext: minutesUntilNext,
-		EpochDuration:    epochDuration,
-		NextEpochRate:    nextEpochRate,
-	}, nil
+	info := q.Keeper.GetEmissionInfo(ctx)
+	return &info, nil
 }
 
 func (q queryServer) ValidatorMiningRecord(goCtx context.Context, req *types.QueryValidatorMiningRecordRequest) (*types.QueryValidatorMiningRecordResponse, error) {
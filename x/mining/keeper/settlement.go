@@ -0,0 +1,348 @@
+package keeper
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// JOB SETTLEMENT
+// ============================================
+//
+// When a job completes or expires its payouts are computed exactly once:
+//   - the job's emission reward is released from escrow a single time
+//   - the customer reward and the emission are split into miner and
+//     validator portions (competitive: miner_share_percent / remainder;
+//     collaborative: work / improvement / validator pools)
+//   - each miner's cut is recorded as a ClaimableReward
+//   - truncation remainders go back where they came from: customer-funded
+//     dust is refunded to the customer, emission dust to the escrow
+// MsgClaimRewards then only withdraws a recorded balance; MsgClaimAllRewards
+// walks the miner's outstanding job index and withdraws many at once.
+
+// setJobMiner records that miner holds shares on a job
+func (k Keeper) setJobMiner(ctx sdk.Context, jobId string, miner sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.JobMinerKeyPrefix, types.JobMinerKey(jobId, miner.String())...)
+	store.Set(key, []byte{1})
+	k.setMinerJob(ctx, miner.String(), jobId)
+}
+
+// setMinerJob indexes a job under the miner until its payout is withdrawn
+func (k Keeper) setMinerJob(ctx sdk.Context, miner, jobId string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.MinerJobKeyPrefix, types.MinerJobKey(miner, jobId)...), []byte{1})
+}
+
+// deleteMinerJob drops a job from the miner's outstanding index
+func (k Keeper) deleteMinerJob(ctx sdk.Context, miner, jobId string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(types.MinerJobKeyPrefix, types.MinerJobKey(miner, jobId)...))
+}
+
+// IterateMinerJobs iterates over the jobs a miner earned shares on and has
+// not yet withdrawn, in job ID order
+func (k Keeper) IterateMinerJobs(ctx sdk.Context, miner string, fn func(jobId string) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.MinerJobKeyPrefix, types.MinerJobKey(miner, "")...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if fn(string(iterator.Key())) {
+			break
+		}
+	}
+}
+
+// IterateJobMiners iterates over every miner that earned shares on a job
+func (k Keeper) IterateJobMiners(ctx sdk.Context, jobId string, fn func(miner sdk.AccAddress) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.JobMinerKeyPrefix, types.JobMinerKey(jobId, "")...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		miner, err := sdk.AccAddressFromBech32(string(iterator.Key()))
+		if err != nil {
+			continue
+		}
+		if fn(miner) {
+			break
+		}
+	}
+}
+
+// GetClaimableReward returns a miner's settled, unclaimed payout on a job
+func (k Keeper) GetClaimableReward(ctx sdk.Context, jobId, miner string) (types.ClaimableReward, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.ClaimableRewardKeyPrefix, types.JobMinerKey(jobId, miner)...)
+	bz := store.Get(key)
+	if bz == nil {
+		return types.ClaimableReward{}, false
+	}
+	var reward types.ClaimableReward
+	k.cdc.MustUnmarshal(bz, &reward)
+	return reward, true
+}
+
+// SetClaimableReward stores a miner's settled payout on a job
+func (k Keeper) SetClaimableReward(ctx sdk.Context, reward types.ClaimableReward) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.ClaimableRewardKeyPrefix, types.JobMinerKey(reward.JobId, reward.Miner)...)
+	bz := k.cdc.MustMarshal(&reward)
+	store.Set(key, bz)
+}
+
+// DeleteClaimableReward removes a payout once it has been withdrawn
+func (k Keeper) DeleteClaimableReward(ctx sdk.Context, jobId, miner string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(types.ClaimableRewardKeyPrefix, types.JobMinerKey(jobId, miner)...))
+}
+
+// IterateClaimableRewards iterates over every settled, unclaimed payout
+func (k Keeper) IterateClaimableRewards(ctx sdk.Context, fn func(reward types.ClaimableReward) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.ClaimableRewardKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var reward types.ClaimableReward
+		k.cdc.MustUnmarshal(iterator.Value(), &reward)
+		if fn(reward) {
+			break
+		}
+	}
+}
+
+// withdrawClaimableReward settles a finished job if needed, then removes the
+// miner's payout and outstanding index entry. The caller transfers the funds.
+func (k Keeper) withdrawClaimableReward(ctx sdk.Context, job *types.Job, miner string) (types.ClaimableReward, error) {
+	if !job.IsSettled() {
+		if !job.IsFinished() {
+			return types.ClaimableReward{}, errorsmod.Wrapf(types.ErrJobNotSettled, "job %s is still %d", job.Id, job.Status)
+		}
+		if err := k.SettleJob(ctx, job); err != nil {
+			return types.ClaimableReward{}, err
+		}
+	}
+
+	k.deleteMinerJob(ctx, miner, job.Id)
+	claim, found := k.GetClaimableReward(ctx, job.Id, miner)
+	if !found {
+		return types.ClaimableReward{}, types.ErrNoShares
+	}
+	k.DeleteClaimableReward(ctx, job.Id, miner)
+	return claim, nil
+}
+
+// settlementPool tracks one funding source (customer reward or emission)
+// through settlement so its remainder can be returned
+type settlementPool struct {
+	total     math.Int
+	validator math.Int
+	paid      math.Int
+}
+
+func newSettlementPool(total math.Int) *settlementPool {
+	return &settlementPool{total: total, validator: math.ZeroInt(), paid: math.ZeroInt()}
+}
+
+// dust is what neither miners nor validators received
+func (p *settlementPool) dust() math.Int {
+	return p.total.Sub(p.validator).Sub(p.paid)
+}
+
+// pay credits a miner's cut to the pool's paid total
+func (p *settlementPool) pay(amount math.Int) math.Int {
+	p.paid = p.paid.Add(amount)
+	return amount
+}
+
+// SettleJob fixes the payouts of a completed or expired job. It is a
+// no-op on a job that is already settled.
+func (k Keeper) SettleJob(ctx sdk.Context, job *types.Job) error {
+	if job.IsSettled() {
+		return nil
+	}
+
+	var miners []sdk.AccAddress
+	k.IterateJobMiners(ctx, job.Id, func(miner sdk.AccAddress) bool {
+		miners = append(miners, miner)
+		return false
+	})
+
+	// Emission is released from the workload escrow once per job, and only
+	// if someone worked
+	workload := JobWorkload(*job)
+	emission := math.ZeroInt()
+	if len(miners) > 0 {
+		emission = k.availableEmission(ctx, *job)
+		k.SetWorkloadEscrow(ctx, workload, k.GetWorkloadEscrow(ctx, workload).Sub(emission))
+	}
+
+	reward := job.Reward
+	if reward.IsNil() {
+		reward = math.ZeroInt()
+	}
+
+	// The treasury takes its share of both before the miner/validator split;
+	// a job nobody worked refunds its customer in full
+	params := k.GetParams(ctx)
+	customerTreasury, emissionTreasury := math.ZeroInt(), math.ZeroInt()
+	if len(miners) > 0 {
+		customerTreasury = TreasuryCut(params, reward)
+		emissionTreasury = TreasuryCut(params, emission)
+	}
+	customerPool := newSettlementPool(reward.Sub(customerTreasury))
+	emissionPool := newSettlementPool(emission.Sub(emissionTreasury))
+
+	claims := make([]types.ClaimableReward, 0, len(miners))
+	if len(miners) > 0 {
+		if job.MiningMode == types.MiningModeCollaborative {
+			claims = k.settleCollaborative(ctx, params, *job, miners, customerPool, emissionPool)
+		} else {
+			claims = k.settleCompetitive(ctx, params, *job, miners, customerPool, emissionPool)
+		}
+	}
+
+	for _, claim := range claims {
+		if claim.Total().IsPositive() {
+			k.SetClaimableReward(ctx, claim)
+			k.attributeToValidator(ctx, claim.Miner, 0, math.ZeroInt(), 1)
+		}
+	}
+
+	k.AddToValidatorRewardPool(ctx, customerPool.validator.Add(emissionPool.validator))
+	treasuryShare := customerTreasury.Add(emissionTreasury)
+	if err := k.depositToTreasury(ctx, treasuryShare, "job/"+job.Id); err != nil {
+		return err
+	}
+
+	// Return remainders: customer-funded dust to the customer, emission dust
+	// to escrow. Network-posted jobs have no customer account, so their
+	// dust joins the emission dust instead of staying unaccounted.
+	refund := customerPool.dust()
+	emissionDust := emissionPool.dust()
+	if customerAddr, err := sdk.AccAddressFromBech32(job.Customer); err != nil {
+		emissionDust = emissionDust.Add(refund)
+		refund = math.ZeroInt()
+	} else if refund.IsPositive() {
+		if err := k.refundCustomer(ctx, customerAddr, refund); err != nil {
+			return err
+		}
+	}
+	if emissionDust.IsPositive() {
+		k.SetWorkloadEscrow(ctx, workload, k.GetWorkloadEscrow(ctx, workload).Add(emissionDust))
+	}
+
+	// A job nobody worked was refunded in full and holds no escrow any more
+	if len(miners) == 0 {
+		job.Reward = math.ZeroInt()
+	}
+	job.SettledAt = ctx.BlockTime().Unix()
+	job.SettledEmission = emission
+	k.SetJob(ctx, *job)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"job_settled",
+			sdk.NewAttribute("job_id", job.Id),
+			sdk.NewAttribute("miners", fmt.Sprintf("%d", len(miners))),
+			sdk.NewAttribute("customer_reward", reward.String()),
+			sdk.NewAttribute("emission_reward", emission.String()),
+			sdk.NewAttribute("validator_share", customerPool.validator.Add(emissionPool.validator).String()),
+			sdk.NewAttribute("treasury_share", treasuryShare.String()),
+			sdk.NewAttribute("customer_refund", refund.String()),
+			sdk.NewAttribute("emission_returned", emissionDust.String()),
+		),
+	)
+
+	return nil
+}
+
+// settleCompetitive splits each pool miner_share_percent to miners, pro-rata
+// by shares, and the remainder to validators
+func (k Keeper) settleCompetitive(ctx sdk.Context, params types.Params, job types.Job, miners []sdk.AccAddress, pools ...*settlementPool) []types.ClaimableReward {
+	minerPools := make([]math.Int, len(pools))
+	for i, pool := range pools {
+		minerPools[i] = pool.total.MulRaw(int64(params.MinerSharePercent)).QuoRaw(100)
+		pool.validator = pool.total.Sub(minerPools[i])
+	}
+
+	claims := make([]types.ClaimableReward, 0, len(miners))
+	for _, miner := range miners {
+		shares := k.GetShares(ctx, miner, job.Id)
+		if shares <= 0 || job.TotalShares <= 0 {
+			continue
+		}
+		claims = append(claims, types.ClaimableReward{
+			JobId:             job.Id,
+			Miner:             miner.String(),
+			CustomerReward:    pools[0].pay(mulDiv(minerPools[0], shares, job.TotalShares)),
+			EmissionReward:    pools[1].pay(mulDiv(minerPools[1], shares, job.TotalShares)),
+			WorkReward:        math.ZeroInt(),
+			ImprovementReward: math.ZeroInt(),
+		})
+	}
+	return claims
+}
+
+// settleCollaborative splits each pool into work, improvement and validator
+// pools; miners are paid pro-rata by steps and by energy improvements
+func (k Keeper) settleCollaborative(ctx sdk.Context, params types.Params, job types.Job, miners []sdk.AccAddress, pools ...*settlementPool) []types.ClaimableReward {
+	workPools := make([]math.Int, len(pools))
+	improvementPools := make([]math.Int, len(pools))
+	for i, pool := range pools {
+		workPools[i] = pool.total.MulRaw(int64(params.WorkPoolPercent)).QuoRaw(100)
+		improvementPools[i] = pool.total.MulRaw(int64(params.ImprovementPoolPercent)).QuoRaw(100)
+		pool.validator = pool.total.Sub(workPools[i]).Sub(improvementPools[i])
+	}
+
+	claims := make([]types.ClaimableReward, 0, len(miners))
+	for _, miner := range miners {
+		steps := k.GetWorkShares(ctx, miner, job.Id)
+		bonus := k.GetBonusShares(ctx, miner, job.Id)
+
+		claim := types.ClaimableReward{
+			JobId:             job.Id,
+			Miner:             miner.String(),
+			WorkReward:        math.ZeroInt(),
+			ImprovementReward: math.ZeroInt(),
+		}
+		amounts := make([]math.Int, len(pools))
+		for i, pool := range pools {
+			work, improvement := math.ZeroInt(), math.ZeroInt()
+			if job.WorkPoolShares > 0 {
+				work = mulDiv(workPools[i], steps, job.WorkPoolShares)
+			}
+			if job.BonusPoolShares > 0 {
+				improvement = mulDiv(improvementPools[i], bonus, job.BonusPoolShares)
+			}
+			amounts[i] = pool.pay(work.Add(improvement))
+			claim.WorkReward = claim.WorkReward.Add(work)
+			claim.ImprovementReward = claim.ImprovementReward.Add(improvement)
+		}
+		claim.CustomerReward, claim.EmissionReward = amounts[0], amounts[1]
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// refundCustomer returns customer-funded settlement dust
+func (k Keeper) refundCustomer(ctx sdk.Context, customerAddr sdk.AccAddress, amount math.Int) error {
+	if k.bankKeeper == nil {
+		return nil
+	}
+	coins := sdk.NewCoins(sdk.NewCoin("unexus", amount))
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, customerAddr, coins); err != nil {
+		return fmt.Errorf("failed to refund settlement remainder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// MINING TREASURY
+// ============================================
+//
+// treasury_share_percent of every job's customer reward and released emission
+// and of each docking epoch's emission is moved to the mining_treasury module
+// account before anything is split between miners and validators. The
+// treasury funds the public research agenda: only governance can pay it out,
+// through MsgTreasurySpend, and every spend is kept as a history record.
+
+var (
+	TreasuryDepositedKey = []byte("treasury_deposited")
+	TreasurySpentKey     = []byte("treasury_spent")
+	TreasurySpendSeqKey  = []byte("treasury_spend_seq")
+)
+
+// TreasuryAddress returns the treasury module account address
+func TreasuryAddress() sdk.AccAddress {
+	return authtypes.NewModuleAddress(types.TreasuryModuleName)
+}
+
+// GetTreasuryDeposited returns everything ever paid into the treasury
+func (k Keeper) GetTreasuryDeposited(ctx sdk.Context) math.Int {
+	return k.getInt(ctx, TreasuryDepositedKey)
+}
+
+// GetTreasurySpent returns everything ever paid out of the treasury
+func (k Keeper) GetTreasurySpent(ctx sdk.Context) math.Int {
+	return k.getInt(ctx, TreasurySpentKey)
+}
+
+// TreasuryCut returns the treasury's share of amount
+func TreasuryCut(params types.Params, amount math.Int) math.Int {
+	if amount.IsNil() || !amount.IsPositive() || params.TreasurySharePercent == 0 {
+		return math.ZeroInt()
+	}
+	return amount.MulRaw(int64(params.TreasurySharePercent)).QuoRaw(100)
+}
+
+// depositToTreasury moves amount from the mining module account to the
+// treasury. source is recorded on the event.
+func (k Keeper) depositToTreasury(ctx sdk.Context, amount math.Int, source string) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	if k.bankKeeper != nil {
+		coins := sdk.NewCoins(sdk.NewCoin("unexus", amount))
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, types.TreasuryModuleName, coins); err != nil {
+			return fmt.Errorf("failed to fund treasury: %w", err)
+		}
+	}
+	k.setInt(ctx, TreasuryDepositedKey, k.GetTreasuryDeposited(ctx).Add(amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"treasury_deposit",
+			sdk.NewAttribute("source", source),
+			sdk.NewAttribute("amount", amount.String()),
+		),
+	)
+	return nil
+}
+
+// GetTreasurySpend returns a recorded treasury spend
+func (k Keeper) GetTreasurySpend(ctx sdk.Context, id uint64) (types.TreasurySpend, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.TreasurySpendKeyPrefix, uint64ToBytes(id)...))
+	if bz == nil {
+		return types.TreasurySpend{}, false
+	}
+	var spend types.TreasurySpend
+	k.cdc.MustUnmarshal(bz, &spend)
+	return spend, true
+}
+
+// SetTreasurySpend stores a treasury spend record
+func (k Keeper) SetTreasurySpend(ctx sdk.Context, spend types.TreasurySpend) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.TreasurySpendKeyPrefix, uint64ToBytes(spend.Id)...), k.cdc.MustMarshal(&spend))
+}
+
+// IterateTreasurySpends iterates over treasury spends, oldest first
+func (k Keeper) IterateTreasurySpends(ctx sdk.Context, fn func(spend types.TreasurySpend) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.TreasurySpendKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var spend types.TreasurySpend
+		k.cdc.MustUnmarshal(iterator.Value(), &spend)
+		if fn(spend) {
+			break
+		}
+	}
+}
+
+func (k Keeper) nextTreasurySpendID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	var id uint64
+	if bz := store.Get(TreasurySpendSeqKey); bz != nil {
+		id = binary.BigEndian.Uint64(bz)
+	}
+	id++
+	store.Set(TreasurySpendSeqKey, uint64ToBytes(id))
+	return id
+}
+
+// TreasurySpend pays a grant out of the treasury (governance only)
+func (k msgServer) TreasurySpend(goCtx context.Context, msg *types.MsgTreasurySpend) (*types.MsgTreasurySpendResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Authority != k.authority {
+		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "expected %s, got %s", k.authority, msg.Authority)
+	}
+	recipient, err := sdk.AccAddressFromBech32(msg.Recipient)
+	if err != nil {
+		return nil, types.ErrInvalidSpend
+	}
+	if msg.Amount.Empty() || !msg.Amount.IsValid() {
+		return nil, types.ErrInvalidSpend
+	}
+	if k.bankKeeper == nil {
+		return nil, errorsmod.Wrap(types.ErrInvalidSpend, "treasury unavailable")
+	}
+
+	for _, coin := range msg.Amount {
+		balance := k.bankKeeper.GetBalance(ctx, TreasuryAddress(), coin.Denom)
+		if balance.Amount.LT(coin.Amount) {
+			return nil, errorsmod.Wrapf(types.ErrInvalidSpend, "treasury holds %s, spend needs %s", balance, coin)
+		}
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.TreasuryModuleName, recipient, msg.Amount); err != nil {
+		return nil, fmt.Errorf("failed to pay treasury spend: %w", err)
+	}
+
+	spend := types.TreasurySpend{
+		Id:        k.nextTreasurySpendID(ctx),
+		Recipient: msg.Recipient,
+		Amount:    msg.Amount,
+		Purpose:   msg.Purpose,
+		Height:    ctx.BlockHeight(),
+		Time:      ctx.BlockTime().Unix(),
+	}
+	k.SetTreasurySpend(ctx, spend)
+	k.setInt(ctx, TreasurySpentKey, k.GetTreasurySpent(ctx).Add(msg.Amount.AmountOf("unexus")))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"treasury_spend",
+			sdk.NewAttribute("id", fmt.Sprintf("%d", spend.Id)),
+			sdk.NewAttribute("recipient", msg.Recipient),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+			sdk.NewAttribute("purpose", msg.Purpose),
+		),
+	)
+
+	return &types.MsgTreasurySpendResponse{Id: spend.Id}, nil
+}
+
+// Treasury returns the treasury balance, share and lifetime totals
+func (q queryServer) Treasury(goCtx context.Context, req *types.QueryTreasuryRequest) (*types.QueryTreasuryResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	balance := sdk.NewCoins()
+	if q.Keeper.bankKeeper != nil {
+		balance = sdk.NewCoins(q.Keeper.bankKeeper.GetBalance(ctx, TreasuryAddress(), "unexus"))
+	}
+	return &types.QueryTreasuryResponse{
+		Address:        TreasuryAddress().String(),
+		Balance:        balance,
+		SharePercent:   q.Keeper.GetParams(ctx).TreasurySharePercent,
+		TotalDeposited: q.Keeper.GetTreasuryDeposited(ctx),
+		TotalSpent:     q.Keeper.GetTreasurySpent(ctx),
+	}, nil
+}
+
+// TreasurySpends lists every treasury spend, oldest first
+func (q queryServer) TreasurySpends(goCtx context.Context, req *types.QueryTreasurySpendsRequest) (*types.QueryTreasurySpendsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	spends := []types.TreasurySpend{}
+	q.Keeper.IterateTreasurySpends(ctx, func(spend types.TreasurySpend) bool {
+		spends = append(spends, spend)
+		return false
+	})
+	return &types.QueryTreasurySpendsResponse{Spends: spends}, nil
+}
@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// VALIDATOR MINING RECORDS
+// ============================================
+//
+// A miner links itself to a validator with MsgLinkValidator and the
+// validator's operator accepts it with MsgAcceptMiner, in either order. Once
+// both sides agree the miner's accepted submissions add to the validator's
+// TotalShares, its payouts to TotalRewards and every finished job it earned
+// on to JobsCompleted. Beacon reveals are the validator's own attestations
+// and only mark it active.
+
+// GetMinerValidator returns the validator a miner's work is attributed to
+func (k Keeper) GetMinerValidator(ctx sdk.Context, miner string) (string, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.MinerValidatorKeyPrefix, []byte(miner)...))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// SetMinerValidator attributes a miner's work to a validator
+func (k Keeper) SetMinerValidator(ctx sdk.Context, miner, validator string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.MinerValidatorKeyPrefix, []byte(miner)...), []byte(validator))
+}
+
+// DeleteMinerValidator removes a miner's validator link
+func (k Keeper) DeleteMinerValidator(ctx sdk.Context, miner string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(types.MinerValidatorKeyPrefix, []byte(miner)...))
+}
+
+// IterateMinerValidators iterates over all miner to validator links
+func (k Keeper) IterateMinerValidators(ctx sdk.Context, fn func(link types.MinerValidatorLink) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.MinerValidatorKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		link := types.MinerValidatorLink{
+			Miner:     string(iterator.Key()),
+			Validator: string(iterator.Value()),
+		}
+		if fn(link) {
+			break
+		}
+	}
+}
+
+// validatorMinerKey returns the acceptance key for validator + "/" + miner
+func validatorMinerKey(validator, miner string) []byte {
+	key := append([]byte{}, types.ValidatorMinerKeyPrefix...)
+	key = append(key, []byte(validator)...)
+	key = append(key, '/')
+	return append(key, []byte(miner)...)
+}
+
+// IsMinerAccepted reports whether a validator has accepted a miner's work
+func (k Keeper) IsMinerAccepted(ctx sdk.Context, validator, miner string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(validatorMinerKey(validator, miner))
+}
+
+// SetMinerAccepted records a validator's acceptance of a miner
+func (k Keeper) SetMinerAccepted(ctx sdk.Context, validator, miner string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(validatorMinerKey(validator, miner), []byte{1})
+}
+
+// DeleteMinerAccepted withdraws a validator's acceptance of a miner
+func (k Keeper) DeleteMinerAccepted(ctx sdk.Context, validator, miner string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(validatorMinerKey(validator, miner))
+}
+
+// IterateAcceptedMiners iterates over all validator acceptances of miners
+func (k Keeper) IterateAcceptedMiners(ctx sdk.Context, fn func(link types.MinerValidatorLink) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.ValidatorMinerKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		validator, miner, ok := strings.Cut(string(iterator.Key()), "/")
+		if !ok {
+			continue
+		}
+		if fn(types.MinerValidatorLink{Miner: miner, Validator: validator}) {
+			break
+		}
+	}
+}
+
+// GetLinkedValidator returns the validator a miner's work is attributed to:
+// the miner must link to it and the validator must have accepted the miner
+func (k Keeper) GetLinkedValidator(ctx sdk.Context, miner string) (string, bool) {
+	validator, found := k.GetMinerValidator(ctx, miner)
+	if !found || !k.IsMinerAccepted(ctx, validator, miner) {
+		return "", false
+	}
+	return validator, true
+}
+
+// IterateValidatorMiningRecords iterates over all validator mining records
+func (k Keeper) IterateValidatorMiningRecords(ctx sdk.Context, fn func(record types.ValidatorMiningRecord) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.ValidatorRecordKeyPrefix)
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.ValidatorMiningRecord
+		k.cdc.MustUnmarshal(iterator.Value(), &record)
+		if fn(record) {
+			break
+		}
+	}
+}
+
+// updateValidatorRecord applies update to a validator's record, creating it
+// if needed, and marks the validator active at the current block time
+func (k Keeper) updateValidatorRecord(ctx sdk.Context, valAddr sdk.ValAddress, update func(record *types.ValidatorMiningRecord)) {
+	record, found := k.GetValidatorMiningRecord(ctx, valAddr)
+	if !found {
+		record = types.ValidatorMiningRecord{ValidatorAddr: valAddr.String(), TotalRewards: math.ZeroInt()}
+	}
+	if record.TotalRewards.IsNil() {
+		record.TotalRewards = math.ZeroInt()
+	}
+	update(&record)
+	record.LastActiveTime = ctx.BlockTime().Unix()
+	k.SetValidatorMiningRecord(ctx, record)
+}
+
+// attributeToValidator credits a linked miner's shares, payout and completed
+// jobs to its validator. Miners without an accepted link are ignored.
+func (k Keeper) attributeToValidator(ctx sdk.Context, miner string, shares int64, reward math.Int, jobsCompleted int64) {
+	validator, found := k.GetLinkedValidator(ctx, miner)
+	if !found {
+		return
+	}
+	valAddr, err := sdk.ValAddressFromBech32(validator)
+	if err != nil {
+		return
+	}
+	k.updateValidatorRecord(ctx, valAddr, func(record *types.ValidatorMiningRecord) {
+		record.TotalShares += shares
+		record.TotalRewards = record.TotalRewards.Add(reward)
+		record.JobsCompleted += jobsCompleted
+	})
+}
+
+// LinkValidator asks to attribute the sender's mining work to a validator
+func (k msgServer) LinkValidator(goCtx context.Context, msg *types.MsgLinkValidator) (*types.MsgLinkValidatorResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+
+	if msg.Validator == "" {
+		k.DeleteMinerValidator(ctx, msg.Miner)
+	} else {
+		valAddr, err := sdk.ValAddressFromBech32(msg.Validator)
+		if err != nil {
+			return nil, types.ErrValidatorNotFound
+		}
+		if k.stakingKeeper != nil {
+			if _, err := k.stakingKeeper.GetValidator(ctx, valAddr); err != nil {
+				return nil, types.ErrValidatorNotFound
+			}
+		}
+		k.SetMinerValidator(ctx, msg.Miner, msg.Validator)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"miner_validator_linked",
+			sdk.NewAttribute("miner", msg.Miner),
+			sdk.NewAttribute("validator", msg.Validator),
+		),
+	)
+
+	return &types.MsgLinkValidatorResponse{}, nil
+}
+
+// AcceptMiner records the sending validator's consent to a miner's work
+// being attributed to its mining record, or withdraws it
+func (k msgServer) AcceptMiner(goCtx context.Context, msg *types.MsgAcceptMiner) (*types.MsgAcceptMinerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	accAddr, err := sdk.AccAddressFromBech32(msg.Validator)
+	if err != nil {
+		return nil, types.ErrValidatorNotFound
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+	valAddr := sdk.ValAddress(accAddr)
+	if k.stakingKeeper != nil {
+		if _, err := k.stakingKeeper.GetValidator(ctx, valAddr); err != nil {
+			return nil, types.ErrValidatorNotFound
+		}
+	}
+
+	if msg.Revoke {
+		k.DeleteMinerAccepted(ctx, valAddr.String(), msg.Miner)
+	} else {
+		k.SetMinerAccepted(ctx, valAddr.String(), msg.Miner)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"validator_miner_accepted",
+			sdk.NewAttribute("validator", valAddr.String()),
+			sdk.NewAttribute("miner", msg.Miner),
+			sdk.NewAttribute("revoked", strconv.FormatBool(msg.Revoke)),
+		),
+	)
+
+	return &types.MsgAcceptMinerResponse{}, nil
+}
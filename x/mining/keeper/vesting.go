@@ -0,0 +1,294 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"nexus/x/mining/types"
+)
+
+// ============================================
+// REWARD VESTING
+// ============================================
+//
+// vesting_percent of every miner payout (job claims and docking epoch
+// rewards) is held by the module as a VestingGrant that unlocks linearly over
+// vesting_duration. MsgWithdrawVested releases the unlocked part. A miner
+// slashed for fraud forfeits everything still locked to the x/distribution
+// community pool.
+
+var VestingGrantSeqKey = []byte("vesting_grant_seq")
+
+// GetVestingGrant returns one of a miner's vesting grants
+func (k Keeper) GetVestingGrant(ctx sdk.Context, miner string, id uint64) (types.VestingGrant, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(types.VestingGrantKeyPrefix, types.VestingGrantKey(miner, id)...))
+	if bz == nil {
+		return types.VestingGrant{}, false
+	}
+	var grant types.VestingGrant
+	k.cdc.MustUnmarshal(bz, &grant)
+	return grant, true
+}
+
+// SetVestingGrant stores a vesting grant
+func (k Keeper) SetVestingGrant(ctx sdk.Context, grant types.VestingGrant) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(types.VestingGrantKeyPrefix, types.VestingGrantKey(grant.Miner, grant.Id)...)
+	store.Set(key, k.cdc.MustMarshal(&grant))
+}
+
+// DeleteVestingGrant removes a grant once it is fully withdrawn
+func (k Keeper) DeleteVestingGrant(ctx sdk.Context, miner string, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(types.VestingGrantKeyPrefix, types.VestingGrantKey(miner, id)...))
+}
+
+// IterateMinerVestingGrants iterates over a miner's grants, oldest first
+func (k Keeper) IterateMinerVestingGrants(ctx sdk.Context, miner string, fn func(grant types.VestingGrant) bool) {
+	k.iterateVestingGrants(ctx, append([]byte(miner), '/'), fn)
+}
+
+// IterateVestingGrants iterates over every miner's grants
+func (k Keeper) IterateVestingGrants(ctx sdk.Context, fn func(grant types.VestingGrant) bool) {
+	k.iterateVestingGrants(ctx, nil, fn)
+}
+
+func (k Keeper) iterateVestingGrants(ctx sdk.Context, keyPrefix []byte, fn func(grant types.VestingGrant) bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, append(types.VestingGrantKeyPrefix, keyPrefix...))
+	iterator := prefixStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.VestingGrant
+		k.cdc.MustUnmarshal(iterator.Value(), &grant)
+		if fn(grant) {
+			break
+		}
+	}
+}
+
+func (k Keeper) nextVestingGrantID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	var id uint64
+	if bz := store.Get(VestingGrantSeqKey); bz != nil {
+		id = binary.BigEndian.Uint64(bz)
+	}
+	id++
+	store.Set(VestingGrantSeqKey, uint64ToBytes(id))
+	return id
+}
+
+// payMinerReward pays a miner reward: vesting_percent is locked in a new
+// grant and the rest is transferred. Returns the liquid and vesting parts.
+func (k Keeper) payMinerReward(ctx sdk.Context, miner sdk.AccAddress, amount math.Int, source string) (math.Int, math.Int, error) {
+	params := k.GetParams(ctx)
+	vesting := amount.MulRaw(int64(params.VestingPercent)).QuoRaw(100)
+	liquid := amount.Sub(vesting)
+
+	if k.bankKeeper != nil && liquid.IsPositive() {
+		coins := sdk.NewCoins(sdk.NewCoin("unexus", liquid))
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, k.payoutAddress(ctx, miner), coins); err != nil {
+			return math.ZeroInt(), math.ZeroInt(), fmt.Errorf("failed to transfer reward: %w", err)
+		}
+	}
+
+	if vesting.IsPositive() {
+		now := ctx.BlockTime().Unix()
+		grant := types.VestingGrant{
+			Miner:     miner.String(),
+			Id:        k.nextVestingGrantID(ctx),
+			Source:    source,
+			Total:     vesting,
+			Withdrawn: math.ZeroInt(),
+			StartTime: now,
+			EndTime:   now + int64(params.VestingDuration/time.Second),
+		}
+		k.SetVestingGrant(ctx, grant)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"reward_vesting",
+				sdk.NewAttribute("miner", grant.Miner),
+				sdk.NewAttribute("grant_id", fmt.Sprintf("%d", grant.Id)),
+				sdk.NewAttribute("source", source),
+				sdk.NewAttribute("amount", vesting.String()),
+				sdk.NewAttribute("end_time", fmt.Sprintf("%d", grant.EndTime)),
+			),
+		)
+	}
+
+	k.attributeToValidator(ctx, miner.String(), 0, amount, 0)
+	return liquid, vesting, nil
+}
+
+// WithdrawVested releases everything unlocked across a miner's grants
+func (k Keeper) WithdrawVested(ctx sdk.Context, miner sdk.AccAddress) (math.Int, error) {
+	now := ctx.BlockTime().Unix()
+
+	var grants []types.VestingGrant
+	k.IterateMinerVestingGrants(ctx, miner.String(), func(grant types.VestingGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	total := math.ZeroInt()
+	for _, grant := range grants {
+		amount := grant.Withdrawable(now)
+		if !amount.IsPositive() {
+			continue
+		}
+		total = total.Add(amount)
+		grant.Withdrawn = grant.Withdrawn.Add(amount)
+		if grant.Outstanding().IsZero() {
+			k.DeleteVestingGrant(ctx, grant.Miner, grant.Id)
+		} else {
+			k.SetVestingGrant(ctx, grant)
+		}
+	}
+
+	if !total.IsPositive() {
+		return math.ZeroInt(), types.ErrNothingVested
+	}
+	if k.bankKeeper != nil {
+		coins := sdk.NewCoins(sdk.NewCoin("unexus", total))
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, k.payoutAddress(ctx, miner), coins); err != nil {
+			return math.ZeroInt(), fmt.Errorf("failed to transfer vested rewards: %w", err)
+		}
+	}
+	return total, nil
+}
+
+// SlashMinerForFraud forfeits every reward the miner still has locked to the
+// community pool. Unlocked amounts stay withdrawable. Returns the forfeiture.
+func (k Keeper) SlashMinerForFraud(ctx sdk.Context, miner sdk.AccAddress, reason string) (math.Int, error) {
+	now := ctx.BlockTime().Unix()
+
+	var grants []types.VestingGrant
+	k.IterateMinerVestingGrants(ctx, miner.String(), func(grant types.VestingGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	forfeited := math.ZeroInt()
+	for _, grant := range grants {
+		forfeited = forfeited.Add(grant.Total.Sub(grant.Vested(now)))
+	}
+
+	// Move the forfeiture before touching the grants, so a failed transfer
+	// leaves them intact
+	if err := k.fundCommunityPool(ctx, forfeited); err != nil {
+		return math.ZeroInt(), err
+	}
+
+	for _, grant := range grants {
+		// Cut the grant down to what had unlocked, all of it withdrawable now
+		grant.Total = grant.Vested(now)
+		grant.EndTime = now
+		if grant.Outstanding().IsZero() {
+			k.DeleteVestingGrant(ctx, grant.Miner, grant.Id)
+		} else {
+			k.SetVestingGrant(ctx, grant)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"miner_slashed",
+			sdk.NewAttribute("miner", miner.String()),
+			sdk.NewAttribute("reason", reason),
+			sdk.NewAttribute("forfeited", forfeited.String()),
+		),
+	)
+	return forfeited, nil
+}
+
+// fundCommunityPool moves amount from the mining module account to the
+// x/distribution community pool
+func (k Keeper) fundCommunityPool(ctx sdk.Context, amount math.Int) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	if k.bankKeeper == nil || k.distrKeeper == nil {
+		return fmt.Errorf("failed to fund community pool with %s unexus: no bank or distribution keeper", amount)
+	}
+	coins := sdk.NewCoins(sdk.NewCoin("unexus", amount))
+	if err := k.distrKeeper.FundCommunityPool(ctx, coins, authtypes.NewModuleAddress(types.ModuleName)); err != nil {
+		return fmt.Errorf("failed to fund community pool: %w", err)
+	}
+	return nil
+}
+
+// WithdrawVested releases a miner's unlocked vesting rewards
+func (k msgServer) WithdrawVested(goCtx context.Context, msg *types.MsgWithdrawVested) (*types.MsgWithdrawVestedResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	minerAddr, err := sdk.AccAddressFromBech32(msg.Miner)
+	if err != nil {
+		return nil, types.ErrUnauthorized
+	}
+
+	amount, err := k.Keeper.WithdrawVested(ctx, minerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"vested_rewards_withdrawn",
+			sdk.NewAttribute("miner", msg.Miner),
+			sdk.NewAttribute("amount", amount.String()),
+		),
+	)
+
+	return &types.MsgWithdrawVestedResponse{Amount: sdk.NewCoins(sdk.NewCoin("unexus", amount))}, nil
+}
+
+// SlashMiner forfeits a miner's locked rewards for fraud (governance only)
+func (k msgServer) SlashMiner(goCtx context.Context, msg *types.MsgSlashMiner) (*types.MsgSlashMinerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Authority != k.authority {
+		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "expected %s, got %s", k.authority, msg.Authority)
+	}
+	minerAddr, err := sdk.AccAddressFromBech32(msg.Miner)
+	if err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+
+	forfeited, err := k.SlashMinerForFraud(ctx, minerAddr, msg.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgSlashMinerResponse{Forfeited: forfeited}, nil
+}
+
+// VestingGrants lists a miner's vesting grants with unlocked and locked totals
+func (q queryServer) VestingGrants(goCtx context.Context, req *types.QueryVestingGrantsRequest) (*types.QueryVestingGrantsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, err := sdk.AccAddressFromBech32(req.Miner); err != nil {
+		return nil, types.ErrInvalidMiner
+	}
+
+	now := ctx.BlockTime().Unix()
+	resp := &types.QueryVestingGrantsResponse{
+		Grants:       []types.VestingGrant{},
+		Withdrawable: math.ZeroInt(),
+		Locked:       math.ZeroInt(),
+	}
+	q.Keeper.IterateMinerVestingGrants(ctx, req.Miner, func(grant types.VestingGrant) bool {
+		resp.Grants = append(resp.Grants, grant)
+		resp.Withdrawable = resp.Withdrawable.Add(grant.Withdrawable(now))
+		resp.Locked = resp.Locked.Add(grant.Total.Sub(grant.Vested(now)))
+		return false
+	})
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package types
+
+// AlgorithmStatus controls whether an algorithm can be used for new work
+type AlgorithmStatus uint32
+
+const (
+	AlgorithmStatusUnspecified AlgorithmStatus = 0
+	AlgorithmStatusActive      AlgorithmStatus = 1
+	AlgorithmStatusDisabled    AlgorithmStatus = 2
+)
+
+// IsValid reports whether the status is a known, settable status
+func (s AlgorithmStatus) IsValid() bool {
+	return s == AlgorithmStatusActive || s == AlgorithmStatusDisabled
+}
+
+// AlgorithmParam is a single canonical parameter, kept as a string so
+// miner software can parse it with the precision the algorithm needs
+type AlgorithmParam struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value"`
+}
+
+func (m *AlgorithmParam) Reset()         { *m = AlgorithmParam{} }
+func (m *AlgorithmParam) String() string { return m.Key + "=" + m.Value }
+func (m *AlgorithmParam) ProtoMessage()  {}
+
+// Algorithm is a governance-registered collaborative mining algorithm.
+// Miners fetch the canonical parameters from chain; the verifier checks
+// proofs against the circuit identified by VerifierKeyHash.
+type Algorithm struct {
+	Id              string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Description     string           `protobuf:"bytes,2,opt,name=description,proto3" json:"description"`
+	Parameters      []AlgorithmParam `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters"`
+	VerifierKeyHash string           `protobuf:"bytes,4,opt,name=verifier_key_hash,json=verifierKeyHash,proto3" json:"verifier_key_hash"`
+	Status          AlgorithmStatus  `protobuf:"varint,5,opt,name=status,proto3,casttype=AlgorithmStatus" json:"status"`
+	MaxSteps        uint64           `protobuf:"varint,6,opt,name=max_steps,json=maxSteps,proto3" json:"max_steps"`
+	UpdatedAt       int64            `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
+}
+
+func (m *Algorithm) Reset()         { *m = Algorithm{} }
+func (m *Algorithm) String() string { return m.Id }
+func (m *Algorithm) ProtoMessage()  {}
+
+// IsActive reports whether new jobs and work submissions may use the algorithm
+func (m Algorithm) IsActive() bool {
+	return m.Status == AlgorithmStatusActive
+}
+
+// Param returns the value of a named parameter
+func (m Algorithm) Param(key string) (string, bool) {
+	for _, p := range m.Parameters {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// MaxAlgorithmIdLength bounds algorithm identifiers
+const MaxAlgorithmIdLength = 64
+
+// DefaultAlgorithms returns the algorithms registered at genesis
+func DefaultAlgorithms() []Algorithm {
+	return []Algorithm{
+		{
+			Id:          DefaultCollaborativeAlgorithm,
+			Description: "Simulated annealing over Ising spins with PRG-selected flips and Metropolis acceptance",
+			Parameters: []AlgorithmParam{
+				{Key: "t_start", Value: "10.0"},
+				{Key: "t_end", Value: "0.01"},
+				{Key: "decay", Value: "0.99995"},
+				{Key: "steps", Value: "100000"},
+			},
+			Status:   AlgorithmStatusActive,
+			MaxSteps: 100000,
+		},
+	}
+}
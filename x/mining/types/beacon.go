@@ -0,0 +1,45 @@
+package types
+
+import "cosmossdk.io/math"
+
+// BeaconCommitment is a validator's outstanding commit for the randomness beacon.
+// Commitment is hex(sha256(secret)); the secret is revealed in a later block.
+type BeaconCommitment struct {
+	Validator  string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Commitment string `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Height     int64  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *BeaconCommitment) Reset()         { *m = BeaconCommitment{} }
+func (m *BeaconCommitment) String() string { return "BeaconCommitment" }
+func (m *BeaconCommitment) ProtoMessage()  {}
+
+// BlockRandomness is the beacon output fixed at the start of a block.
+// It only depends on secrets revealed in earlier blocks, so the block
+// proposer cannot grind it. A block with fewer than BeaconMinReveals fresh
+// reveals is not usable and carries no value, since anyone could compute it
+// in advance.
+type BlockRandomness struct {
+	Height  int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Value   string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Reveals int64  `protobuf:"varint,3,opt,name=reveals,proto3" json:"reveals,omitempty"`
+	Usable  bool   `protobuf:"varint,4,opt,name=usable,proto3" json:"usable,omitempty"`
+}
+
+func (m *BlockRandomness) Reset()         { *m = BlockRandomness{} }
+func (m *BlockRandomness) String() string { return m.Value }
+func (m *BlockRandomness) ProtoMessage()  {}
+
+const (
+	// BeaconRevealWindow is how many blocks a commitment stays revealable
+	BeaconRevealWindow = 1000
+	// BeaconHistoryBlocks is how many past block values are kept for queries
+	BeaconHistoryBlocks = 100000
+	// BeaconMinReveals is how many reveals must land since the previous
+	// block for the block's value to be usable
+	BeaconMinReveals = 1
+)
+
+// BeaconMissSlashFraction is the share of stake slashed from a validator
+// whose commitment expires without a reveal
+var BeaconMissSlashFraction = math.LegacyNewDecWithPrec(1, 2)
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"cosmossdk.io/math"
+)
+
+// Sources of burned tokens, each tracked cumulatively in state
+const (
+	BurnSourceBaseFee     = "base_fee"     // base fee x gas limit burned by the ante handler
+	BurnSourceTxFee       = "tx_fee"       // tx_fee_burn_percent of fees burned while the base fee is zero
+	BurnSourceJobFee      = "job_fee"      // job_fee_burn_percent of paid job rewards and extensions
+	BurnSourcePriorityFee = "priority_fee" // paid job priority fees
+	BurnSourcePostingFee  = "posting_fee"  // public job posting fees
+)
+
+// BurnSources lists every burn source in reporting order
+var BurnSources = []string{BurnSourceBaseFee, BurnSourceTxFee, BurnSourceJobFee, BurnSourcePriorityFee, BurnSourcePostingFee}
+
+// MsgFeeBurnOverride replaces tx_fee_burn_percent for transactions carrying
+// a message type
+type MsgFeeBurnOverride struct {
+	MsgTypeUrl  string `protobuf:"bytes,1,opt,name=msg_type_url,json=msgTypeUrl,proto3" json:"msg_type_url"`
+	BurnPercent uint64 `protobuf:"varint,2,opt,name=burn_percent,json=burnPercent,proto3" json:"burn_percent"`
+}
+
+func (m *MsgFeeBurnOverride) Reset()         { *m = MsgFeeBurnOverride{} }
+func (m *MsgFeeBurnOverride) String() string { return m.MsgTypeUrl }
+func (m *MsgFeeBurnOverride) ProtoMessage()  {}
+
+// BurnedAmount is the cumulative amount burned from one source
+type BurnedAmount struct {
+	Source string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source"`
+	Amount math.Int `protobuf:"bytes,2,opt,name=amount,proto3,customtype=cosmossdk.io/math.Int" json:"amount"`
+}
+
+func (m *BurnedAmount) Reset()         { *m = BurnedAmount{} }
+func (m *BurnedAmount) String() string { return m.Source }
+func (m *BurnedAmount) ProtoMessage()  {}
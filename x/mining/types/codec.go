@@ -14,6 +14,24 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	legacy.RegisterAminoMsg(cdc, &MsgCancelJob{}, "nexus/MsgCancelJob")
 	legacy.RegisterAminoMsg(cdc, &MsgSubmitPublicJob{}, "nexus/MsgSubmitPublicJob")
 	legacy.RegisterAminoMsg(cdc, &MsgSubmitWork{}, "nexus/MsgSubmitWork")
+	legacy.RegisterAminoMsg(cdc, &MsgExtendJob{}, "nexus/MsgExtendJob")
+	legacy.RegisterAminoMsg(cdc, &MsgSetMinerGroup{}, "nexus/MsgSetMinerGroup")
+	legacy.RegisterAminoMsg(cdc, &MsgCommitRandomness{}, "nexus/MsgCommitRandomness")
+	legacy.RegisterAminoMsg(cdc, &MsgRevealRandomness{}, "nexus/MsgRevealRandomness")
+	legacy.RegisterAminoMsg(cdc, &MsgSetAlgorithm{}, "nexus/MsgSetAlgorithm")
+	legacy.RegisterAminoMsg(cdc, &MsgUpdateParams{}, "nexus/MsgUpdateParams")
+	legacy.RegisterAminoMsg(cdc, &MsgClaimAllRewards{}, "nexus/MsgClaimAllRewards")
+	legacy.RegisterAminoMsg(cdc, &MsgWithdrawVested{}, "nexus/MsgWithdrawVested")
+	legacy.RegisterAminoMsg(cdc, &MsgSlashMiner{}, "nexus/MsgSlashMiner")
+	legacy.RegisterAminoMsg(cdc, &MsgLinkValidator{}, "nexus/MsgLinkValidator")
+	legacy.RegisterAminoMsg(cdc, &MsgAcceptMiner{}, "nexus/MsgAcceptMiner")
+	legacy.RegisterAminoMsg(cdc, &MsgCreatePool{}, "nexus/MsgCreatePool")
+	legacy.RegisterAminoMsg(cdc, &MsgJoinPool{}, "nexus/MsgJoinPool")
+	legacy.RegisterAminoMsg(cdc, &MsgLeavePool{}, "nexus/MsgLeavePool")
+	legacy.RegisterAminoMsg(cdc, &MsgClaimPoolRewards{}, "nexus/MsgClaimPoolRewards")
+	legacy.RegisterAminoMsg(cdc, &MsgTreasurySpend{}, "nexus/MsgTreasurySpend")
+	legacy.RegisterAminoMsg(cdc, &MsgRegisterMiner{}, "nexus/MsgRegisterMiner")
+	legacy.RegisterAminoMsg(cdc, &MsgUnbondMiner{}, "nexus/MsgUnbondMiner")
 }
 
 func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
@@ -24,6 +42,24 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgCancelJob{},
 		&MsgSubmitPublicJob{},
 		&MsgSubmitWork{},
+		&MsgExtendJob{},
+		&MsgSetMinerGroup{},
+		&MsgCommitRandomness{},
+		&MsgRevealRandomness{},
+		&MsgSetAlgorithm{},
+		&MsgUpdateParams{},
+		&MsgClaimAllRewards{},
+		&MsgWithdrawVested{},
+		&MsgSlashMiner{},
+		&MsgLinkValidator{},
+		&MsgAcceptMiner{},
+		&MsgCreatePool{},
+		&MsgJoinPool{},
+		&MsgLeavePool{},
+		&MsgClaimPoolRewards{},
+		&MsgTreasurySpend{},
+		&MsgRegisterMiner{},
+		&MsgUnbondMiner{},
 	)
 }
 
@@ -0,0 +1,238 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	msgv1 "cosmossdk.io/api/cosmos/msg/v1"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	typesProtoFile    = "nexus/mining/types.proto"
+	typesProtoPackage = "nexus.mining"
+)
+
+// msgSigners names the field holding the signer of each Msg, matching
+// its GetSigners
+var msgSigners = map[string]string{
+	"MsgPostJob":          "customer",
+	"MsgSubmitProof":      "miner",
+	"MsgClaimRewards":     "claimer",
+	"MsgClaimAllRewards":  "claimer",
+	"MsgCancelJob":        "customer",
+	"MsgExtendJob":        "customer",
+	"MsgSetMinerGroup":    "owner",
+	"MsgCommitRandomness": "validator",
+	"MsgRevealRandomness": "validator",
+	"MsgSetAlgorithm":     "authority",
+	"MsgUpdateParams":     "authority",
+	"MsgSubmitPublicJob":  "submitter",
+	"MsgSubmitWork":       "miner",
+	"MsgWithdrawVested":   "miner",
+	"MsgSlashMiner":       "authority",
+	"MsgLinkValidator":    "miner",
+	"MsgAcceptMiner":      "validator",
+	"MsgCreatePool":       "operator",
+	"MsgJoinPool":         "member",
+	"MsgLeavePool":        "member",
+	"MsgClaimPoolRewards": "claimer",
+	"MsgTreasurySpend":    "authority",
+	"MsgRegisterMiner":    "miner",
+	"MsgUnbondMiner":      "miner",
+}
+
+// The mining types are hand-written rather than generated, so their
+// descriptors are derived from the protobuf struct tags and registered with
+// gogoproto. The SDK resolves Msg and Query methods, message signers and
+// governance proposal messages through these descriptors.
+func init() {
+	b := descriptorBuilder{
+		file: &descriptorpb.FileDescriptorProto{
+			Name:    proto.String(typesProtoFile),
+			Package: proto.String(typesProtoPackage),
+			Syntax:  proto.String("proto3"),
+		},
+		names: make(map[reflect.Type]string),
+		deps:  map[string]bool{"cosmos/msg/v1/msg.proto": true},
+	}
+	msgService := b.service(&MsgServiceDesc, (*MsgServer)(nil))
+	proto.SetExtension(msgService.Options, msgv1.E_Service, true)
+	queryService := b.service(&QueryServiceDesc, (*QueryServer)(nil))
+
+	for dep := range b.deps {
+		b.file.Dependency = append(b.file.Dependency, dep)
+	}
+	sort.Strings(b.file.Dependency)
+	registerFileDescriptor(b.file)
+	registerFileDescriptor(serviceFile(&MsgServiceDesc, msgService))
+	registerFileDescriptor(serviceFile(&QueryServiceDesc, queryService))
+}
+
+// serviceFile places a service in the file named by its descriptor metadata
+func serviceFile(sd *grpc.ServiceDesc, svc *descriptorpb.ServiceDescriptorProto) *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(sd.Metadata.(string)),
+		Package:    proto.String(sd.ServiceName[:strings.LastIndex(sd.ServiceName, ".")]),
+		Dependency: []string{"cosmos/msg/v1/msg.proto", typesProtoFile},
+		Service:    []*descriptorpb.ServiceDescriptorProto{svc},
+		Syntax:     proto.String("proto3"),
+	}
+}
+
+// descriptorBuilder collects message descriptors for the mining types
+type descriptorBuilder struct {
+	file  *descriptorpb.FileDescriptorProto
+	names map[reflect.Type]string
+	deps  map[string]bool
+}
+
+// service describes a gRPC service and every message its methods use
+func (b *descriptorBuilder) service(sd *grpc.ServiceDesc, server interface{}) *descriptorpb.ServiceDescriptorProto {
+	serverType := reflect.TypeOf(server).Elem()
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:    proto.String(sd.ServiceName[strings.LastIndex(sd.ServiceName, ".")+1:]),
+		Options: &descriptorpb.ServiceOptions{},
+	}
+	for _, method := range sd.Methods {
+		m, ok := serverType.MethodByName(method.MethodName)
+		if !ok {
+			panic(fmt.Sprintf("%s has no method %s", serverType, method.MethodName))
+		}
+		svc.Method = append(svc.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(method.MethodName),
+			InputType:  proto.String(b.message(m.Type.In(1).Elem())),
+			OutputType: proto.String(b.message(m.Type.Out(0).Elem())),
+		})
+	}
+	return svc
+}
+
+// message returns the fully-qualified name of a struct type, describing it
+// first if it belongs to this package
+func (b *descriptorBuilder) message(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	msg, _ := reflect.New(t).Interface().(gogoproto.Message)
+	if t.PkgPath() != reflect.TypeOf(Params{}).PkgPath() {
+		name := gogoproto.MessageName(msg)
+		desc, err := gogoproto.HybridResolver.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			panic(fmt.Sprintf("no descriptor for %s: %v", t, err))
+		}
+		b.deps[desc.ParentFile().Path()] = true
+		b.names[t] = "." + name
+		return b.names[t]
+	}
+
+	name := typesProtoPackage + "." + t.Name()
+	if msg != nil && gogoproto.MessageName(msg) == "" {
+		gogoproto.RegisterType(msg, name)
+	}
+	b.names[t] = "." + name
+
+	desc := &descriptorpb.DescriptorProto{Name: proto.String(t.Name())}
+	b.file.MessageType = append(b.file.MessageType, desc)
+	if signer, ok := msgSigners[t.Name()]; ok {
+		desc.Options = &descriptorpb.MessageOptions{}
+		proto.SetExtension(desc.Options, msgv1.E_Signer, []string{signer})
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("protobuf"); tag != "" {
+			desc.Field = append(desc.Field, b.field(t.Field(i).Type, tag))
+		}
+	}
+	return b.names[t]
+}
+
+// field describes one struct field from its protobuf tag
+func (b *descriptorBuilder) field(goType reflect.Type, tag string) *descriptorpb.FieldDescriptorProto {
+	parts := strings.Split(tag, ",")
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		panic(fmt.Sprintf("invalid protobuf tag %q", tag))
+	}
+
+	field := &descriptorpb.FieldDescriptorProto{
+		Number: proto.Int32(int32(number)),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	var customType bool
+	for _, part := range parts[2:] {
+		switch {
+		case part == "rep":
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			goType = goType.Elem()
+		case strings.HasPrefix(part, "name="):
+			field.Name = proto.String(strings.TrimPrefix(part, "name="))
+		case strings.HasPrefix(part, "json="):
+			field.JsonName = proto.String(strings.TrimPrefix(part, "json="))
+		case strings.HasPrefix(part, "customtype="):
+			customType = true
+		}
+	}
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+
+	var kind descriptorpb.FieldDescriptorProto_Type
+	switch {
+	case parts[0] == "varint" && goType.Kind() == reflect.Bool:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	case parts[0] == "varint" && goType.Kind() == reflect.Int32:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	case parts[0] == "varint" && goType.Kind() == reflect.Int64:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_INT64
+	case parts[0] == "varint" && goType.Kind() == reflect.Uint32:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_UINT32
+	case parts[0] == "varint" && goType.Kind() == reflect.Uint64:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+	case parts[0] == "fixed64" && goType.Kind() == reflect.Uint64:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_FIXED64
+	case parts[0] == "fixed64" && goType.Kind() == reflect.Int64:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_SFIXED64
+	case parts[0] == "fixed64" && goType.Kind() == reflect.Float64:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+	case parts[0] == "bytes" && (customType || goType.Kind() == reflect.String):
+		// Custom types (math.Int, math.LegacyDec) are encoded as strings
+		kind = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	case parts[0] == "bytes" && goType.Kind() == reflect.Slice && goType.Elem().Kind() == reflect.Uint8:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	case parts[0] == "bytes" && goType.Kind() == reflect.Struct:
+		kind = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		field.TypeName = proto.String(b.message(goType))
+	default:
+		panic(fmt.Sprintf("unsupported protobuf tag %q on %s", tag, goType))
+	}
+	field.Type = kind.Enum()
+	return field
+}
+
+// registerFileDescriptor registers a file descriptor with gogoproto the way
+// generated code does
+func registerFileDescriptor(file *descriptorpb.FileDescriptorProto) {
+	bz, err := proto.Marshal(file)
+	if err != nil {
+		panic(err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(bz); err != nil {
+		panic(err)
+	}
+	if err := zw.Close(); err != nil {
+		panic(err)
+	}
+	gogoproto.RegisterFile(file.GetName(), buf.Bytes())
+}
@@ -0,0 +1,135 @@
+package types_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"cosmossdk.io/math"
+	msgv1 "cosmossdk.io/api/cosmos/msg/v1"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"nexus/x/mining/types"
+)
+
+var (
+	intType = reflect.TypeOf(math.Int{})
+	decType = reflect.TypeOf(math.LegacyDec{})
+)
+
+// TestDescriptorsRoundTrip encodes every request and response of the Msg
+// and Query services with their struct tags, decodes the bytes through the
+// registered descriptor and checks re-encoding yields the same message
+func TestDescriptorsRoundTrip(t *testing.T) {
+	for _, sd := range []*grpc.ServiceDesc{&types.MsgServiceDesc, &types.QueryServiceDesc} {
+		serverType := reflect.TypeOf(sd.HandlerType).Elem()
+		isMsg := sd == &types.MsgServiceDesc
+		for _, method := range sd.Methods {
+			m, ok := serverType.MethodByName(method.MethodName)
+			if !ok {
+				t.Fatalf("%s has no method %s", serverType, method.MethodName)
+			}
+			roundTrip(t, m.Type.In(1).Elem(), isMsg)
+			roundTrip(t, m.Type.Out(0).Elem(), false)
+		}
+	}
+}
+
+func roundTrip(t *testing.T, goType reflect.Type, isMsg bool) {
+	t.Helper()
+	msg, ok := reflect.New(goType).Interface().(gogoproto.Message)
+	if !ok {
+		t.Errorf("%s is not a proto message", goType)
+		return
+	}
+	name := gogoproto.MessageName(msg)
+	if name == "" {
+		t.Errorf("%s is not registered", goType)
+		return
+	}
+	d, err := gogoproto.HybridResolver.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		t.Errorf("no descriptor for %s: %v", name, err)
+		return
+	}
+	desc := d.(protoreflect.MessageDescriptor)
+
+	if isMsg {
+		signers, _ := proto.GetExtension(desc.Options(), msgv1.E_Signer).([]string)
+		if len(signers) != 1 || desc.Fields().ByName(protoreflect.Name(signers[0])) == nil {
+			t.Errorf("%s has no signer field, got %v", name, signers)
+		}
+	}
+
+	fill(reflect.ValueOf(msg).Elem())
+	bz, err := gogoproto.Marshal(msg)
+	if err != nil {
+		t.Errorf("marshal %s: %v", name, err)
+		return
+	}
+	dyn := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(bz, dyn); err != nil {
+		t.Errorf("decode %s through its descriptor: %v", name, err)
+		return
+	}
+	if len(dyn.GetUnknown()) != 0 {
+		t.Errorf("%s has fields missing from its descriptor", name)
+	}
+	dynBz, err := proto.Marshal(dyn)
+	if err != nil {
+		t.Errorf("re-encode %s: %v", name, err)
+		return
+	}
+	decoded := reflect.New(goType).Interface().(gogoproto.Message)
+	if err := gogoproto.Unmarshal(dynBz, decoded); err != nil {
+		t.Errorf("unmarshal %s: %v", name, err)
+		return
+	}
+	if again, _ := gogoproto.Marshal(decoded); !bytes.Equal(again, bz) {
+		t.Errorf("%s changed in the round trip", name)
+	}
+}
+
+// fill sets every tagged field of a struct to a non-zero value
+func fill(v reflect.Value) {
+	switch {
+	case v.Type() == intType:
+		v.Set(reflect.ValueOf(math.NewInt(7)))
+		return
+	case v.Type() == decType:
+		v.Set(reflect.ValueOf(math.LegacyNewDecWithPrec(75, 2)))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("nexus")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float64:
+		v.SetFloat(0.5)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte{1, 2, 3})
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("protobuf") != "" {
+				fill(v.Field(i))
+			}
+		}
+	}
+}
@@ -20,4 +20,25 @@ var (
 	ErrValidatorNotFound  = errorsmod.Register(ModuleName, 13, "validator not found")
 	ErrInvalidParams      = errorsmod.Register(ModuleName, 14, "invalid params")
 	ErrCannotCancel       = errorsmod.Register(ModuleName, 15, "cannot cancel job")
+	ErrInvalidExtension   = errorsmod.Register(ModuleName, 16, "invalid job extension")
+	ErrMinerNotAllowed    = errorsmod.Register(ModuleName, 17, "miner not on job allowlist")
+	ErrMinerGroupNotFound = errorsmod.Register(ModuleName, 18, "miner group not found")
+	ErrWrongMiningMode    = errorsmod.Register(ModuleName, 19, "operation not supported for job mining mode")
+	ErrStaleEpoch         = errorsmod.Register(ModuleName, 20, "work submitted for stale epoch")
+	ErrFutureEpoch        = errorsmod.Register(ModuleName, 21, "work submitted for future epoch")
+	ErrInvalidCommitment  = errorsmod.Register(ModuleName, 22, "invalid randomness commitment")
+	ErrRandomnessNotFound = errorsmod.Register(ModuleName, 23, "randomness not found for height")
+	ErrAlgorithmNotFound  = errorsmod.Register(ModuleName, 24, "algorithm not registered")
+	ErrAlgorithmInactive  = errorsmod.Register(ModuleName, 25, "algorithm not active")
+	ErrInvalidStepCount   = errorsmod.Register(ModuleName, 26, "step count exceeds algorithm maximum")
+	ErrEpochLimitExceeded = errorsmod.Register(ModuleName, 27, "per-epoch submission limit exceeded")
+	ErrJobNotSettled      = errorsmod.Register(ModuleName, 28, "job not settled")
+	ErrNothingVested      = errorsmod.Register(ModuleName, 29, "no vested rewards to withdraw")
+	ErrPoolNotFound       = errorsmod.Register(ModuleName, 30, "mining pool not found")
+	ErrPoolExists         = errorsmod.Register(ModuleName, 31, "mining pool already exists")
+	ErrNotPoolMember      = errorsmod.Register(ModuleName, 32, "not a member of the mining pool")
+	ErrInvalidSpend       = errorsmod.Register(ModuleName, 33, "invalid treasury spend")
+	ErrMinerNotRegistered = errorsmod.Register(ModuleName, 34, "miner has no active registration")
+	ErrMinerUnbonding     = errorsmod.Register(ModuleName, 35, "miner registration is unbonding")
+	ErrInsufficientBond   = errorsmod.Register(ModuleName, 36, "insufficient miner bond")
 )
@@ -3,7 +3,9 @@ package types
 import (
 	"encoding/json"
 
+	"cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // GenesisState defines the mining module's genesis state
@@ -11,11 +13,34 @@ type GenesisState struct {
 	Params              Params       `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
 	Jobs                []Job        `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs"`
 	Checkpoints         []Checkpoint `protobuf:"bytes,3,rep,name=checkpoints,proto3" json:"checkpoints"`
-	ValidatorRewardPool int64        `protobuf:"varint,4,opt,name=validator_reward_pool,json=validatorRewardPool,proto3" json:"validator_reward_pool"`
-	EmissionEscrow      int64        `protobuf:"varint,5,opt,name=emission_escrow,json=emissionEscrow,proto3" json:"emission_escrow"`
+	ValidatorRewardPool math.Int     `protobuf:"bytes,4,opt,name=validator_reward_pool,json=validatorRewardPool,proto3,customtype=cosmossdk.io/math.Int" json:"validator_reward_pool"`
+	EmissionEscrow      math.Int     `protobuf:"bytes,5,opt,name=emission_escrow,json=emissionEscrow,proto3,customtype=cosmossdk.io/math.Int" json:"emission_escrow"`
 	LastCheckpointID    uint64       `protobuf:"varint,6,opt,name=last_checkpoint_id,json=lastCheckpointId,proto3" json:"last_checkpoint_id"`
 	CurrentProblemSize  int64        `protobuf:"varint,7,opt,name=current_problem_size,json=currentProblemSize,proto3" json:"current_problem_size"`
 	BackgroundJobCount  int64        `protobuf:"varint,8,opt,name=background_job_count,json=backgroundJobCount,proto3" json:"background_job_count"`
+	MinerGroups         []MinerGroup `protobuf:"bytes,9,rep,name=miner_groups,json=minerGroups,proto3" json:"miner_groups"`
+	BeaconSeed          string       `protobuf:"bytes,10,opt,name=beacon_seed,json=beaconSeed,proto3" json:"beacon_seed"`
+	Algorithms          []Algorithm  `protobuf:"bytes,11,rep,name=algorithms,proto3" json:"algorithms"`
+	TotalEmissionMinted math.Int     `protobuf:"bytes,12,opt,name=total_emission_minted,json=totalEmissionMinted,proto3,customtype=cosmossdk.io/math.Int" json:"total_emission_minted"`
+
+	ValidatorRecords    []ValidatorMiningRecord `protobuf:"bytes,13,rep,name=validator_records,json=validatorRecords,proto3" json:"validator_records"`
+	MinerValidatorLinks []MinerValidatorLink    `protobuf:"bytes,14,rep,name=miner_validator_links,json=minerValidatorLinks,proto3" json:"miner_validator_links"`
+
+	// Per-workload emission escrows; EmissionEscrow holds emission not yet
+	// split and is allocated by the params weights at InitGenesis
+	WorkloadEscrows []WorkloadEscrow `protobuf:"bytes,15,rep,name=workload_escrows,json=workloadEscrows,proto3" json:"workload_escrows"`
+
+	Pools []MiningPool `protobuf:"bytes,16,rep,name=pools,proto3" json:"pools"`
+
+	// Current base fee in unexus per gas; unset starts at the params floor
+	BaseFee math.LegacyDec `protobuf:"bytes,17,opt,name=base_fee,json=baseFee,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"base_fee"`
+
+	// Miner registry, including miners still unbonding
+	MinerRegistrations []MinerRegistration `protobuf:"bytes,18,rep,name=miner_registrations,json=minerRegistrations,proto3" json:"miner_registrations"`
+
+	// Miners each validator has accepted onto its record; a miner link
+	// counts only when the pair is listed here
+	AcceptedMiners []MinerValidatorLink `protobuf:"bytes,19,rep,name=accepted_miners,json=acceptedMiners,proto3" json:"accepted_miners"`
 }
 
 func (gs *GenesisState) Reset()         { *gs = GenesisState{} }
@@ -28,11 +53,21 @@ func DefaultGenesis() *GenesisState {
 		Params:              DefaultParams(),
 		Jobs:                []Job{},
 		Checkpoints:         []Checkpoint{},
-		ValidatorRewardPool: 0,
-		EmissionEscrow:      0,
+		ValidatorRewardPool: math.ZeroInt(),
+		EmissionEscrow:      math.ZeroInt(),
 		LastCheckpointID:    0,
 		CurrentProblemSize:  64,
 		BackgroundJobCount:  0,
+		MinerGroups:         []MinerGroup{},
+		Algorithms:          DefaultAlgorithms(),
+		TotalEmissionMinted: math.ZeroInt(),
+		ValidatorRecords:    []ValidatorMiningRecord{},
+		MinerValidatorLinks: []MinerValidatorLink{},
+		WorkloadEscrows:     []WorkloadEscrow{},
+		Pools:               []MiningPool{},
+		BaseFee:             DefaultMinBaseFee,
+		MinerRegistrations:  []MinerRegistration{},
+		AcceptedMiners:      []MinerValidatorLink{},
 	}
 }
 
@@ -44,6 +79,87 @@ func (gs GenesisState) Validate() error {
 	if gs.CurrentProblemSize < 64 || gs.CurrentProblemSize > 2048 {
 		return ErrInvalidParams
 	}
+	seen := make(map[string]bool, len(gs.Algorithms))
+	for _, algo := range gs.Algorithms {
+		if algo.Id == "" || seen[algo.Id] || !algo.Status.IsValid() {
+			return ErrInvalidParams
+		}
+		seen[algo.Id] = true
+	}
+	for _, record := range gs.ValidatorRecords {
+		if _, err := sdk.ValAddressFromBech32(record.ValidatorAddr); err != nil {
+			return ErrValidatorNotFound
+		}
+		if !record.TotalRewards.IsNil() && record.TotalRewards.IsNegative() {
+			return ErrInvalidParams
+		}
+	}
+	linked := make(map[string]bool, len(gs.MinerValidatorLinks))
+	for _, link := range gs.MinerValidatorLinks {
+		if _, err := sdk.AccAddressFromBech32(link.Miner); err != nil || linked[link.Miner] {
+			return ErrInvalidMiner
+		}
+		if _, err := sdk.ValAddressFromBech32(link.Validator); err != nil {
+			return ErrValidatorNotFound
+		}
+		linked[link.Miner] = true
+	}
+	accepted := make(map[MinerValidatorLink]bool, len(gs.AcceptedMiners))
+	for _, link := range gs.AcceptedMiners {
+		if _, err := sdk.AccAddressFromBech32(link.Miner); err != nil || accepted[link] {
+			return ErrInvalidMiner
+		}
+		if _, err := sdk.ValAddressFromBech32(link.Validator); err != nil {
+			return ErrValidatorNotFound
+		}
+		accepted[link] = true
+	}
+	escrowed := make(map[string]bool, len(gs.WorkloadEscrows))
+	for _, escrow := range gs.WorkloadEscrows {
+		if !IsEmissionWorkload(escrow.Workload) || escrowed[escrow.Workload] {
+			return ErrInvalidParams
+		}
+		if escrow.Amount.IsNil() || escrow.Amount.IsNegative() {
+			return ErrInvalidParams
+		}
+		escrowed[escrow.Workload] = true
+	}
+	pools := make(map[string]bool, len(gs.Pools))
+	for _, pool := range gs.Pools {
+		if !ValidPoolId(pool.Id) || pools[pool.Id] || pool.FeePercent > 100 {
+			return ErrInvalidParams
+		}
+		if _, err := sdk.AccAddressFromBech32(pool.Operator); err != nil {
+			return ErrUnauthorized
+		}
+		for _, member := range pool.Members {
+			if _, err := sdk.AccAddressFromBech32(member); err != nil {
+				return ErrInvalidMiner
+			}
+		}
+		pools[pool.Id] = true
+	}
+	if !gs.BaseFee.IsNil() && gs.BaseFee.IsNegative() {
+		return ErrInvalidParams
+	}
+	registered := make(map[string]bool, len(gs.MinerRegistrations))
+	for _, reg := range gs.MinerRegistrations {
+		if _, err := sdk.AccAddressFromBech32(reg.Miner); err != nil || registered[reg.Miner] {
+			return ErrInvalidMiner
+		}
+		if reg.Bond.IsNil() || reg.Bond.IsNegative() {
+			return ErrInsufficientBond
+		}
+		if reg.Status != MinerStatusActive && reg.Status != MinerStatusUnbonding {
+			return ErrInvalidMiner
+		}
+		if reg.PayoutAddress != "" {
+			if _, err := sdk.AccAddressFromBech32(reg.PayoutAddress); err != nil {
+				return ErrInvalidMiner
+			}
+		}
+		registered[reg.Miner] = true
+	}
 	return nil
 }
 
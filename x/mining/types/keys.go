@@ -20,12 +20,57 @@ var (
 	ValidatorRecordKeyPrefix = []byte{0x05}
 	ParamsKey                = []byte{0x06}
 	ValidatorRewardPoolKey   = []byte{0x07}
+	MinerGroupKeyPrefix      = []byte{0x08}
+
+	// Randomness beacon prefixes
+	BeaconCommitmentKeyPrefix = []byte{0x09}
+	BlockRandomnessKeyPrefix  = []byte{0x0A}
+
+	// Algorithm registry prefix
+	AlgorithmKeyPrefix = []byte{0x0B}
+
+	// Solution landscape prefixes (keyed by job ID + "/")
+	LandscapeMinimumKeyPrefix = []byte{0x0C}
+	LandscapeFinderKeyPrefix  = []byte{0x0D}
+	EpochLandscapeKeyPrefix   = []byte{0x0E}
 
 	// Collaborative mining prefixes
-	WorkSubmissionKeyPrefix = []byte{0x10}
-	WorkShareKeyPrefix      = []byte{0x11}
-	BonusShareKeyPrefix     = []byte{0x12}
-	EpochKeyPrefix          = []byte{0x13}
+	WorkSubmissionKeyPrefix  = []byte{0x10}
+	WorkShareKeyPrefix       = []byte{0x11}
+	BonusShareKeyPrefix      = []byte{0x12}
+	EpochKeyPrefix           = []byte{0x13}
+	MinerEpochUsageKeyPrefix = []byte{0x14}
+
+	// Settlement prefixes (keyed by job ID + "/" + miner)
+	JobMinerKeyPrefix        = []byte{0x15}
+	ClaimableRewardKeyPrefix = []byte{0x16}
+
+	// Per-miner index of jobs not yet withdrawn (keyed by miner + "/" + job ID)
+	MinerJobKeyPrefix = []byte{0x17}
+
+	// Vesting grants (keyed by miner + "/" + grant ID)
+	VestingGrantKeyPrefix = []byte{0x18}
+
+	// Miner to validator attribution links (keyed by miner)
+	MinerValidatorKeyPrefix = []byte{0x19}
+
+	// Mining pools (keyed by pool ID), member contributions (pool ID + "/" +
+	// job ID + "/" + member) and member earnings (pool ID + "/" + member)
+	PoolKeyPrefix             = []byte{0x1A}
+	PoolContributionKeyPrefix = []byte{0x1B}
+	PoolEarningsKeyPrefix     = []byte{0x1C}
+
+	// Treasury spend history (keyed by big-endian spend ID)
+	TreasurySpendKeyPrefix = []byte{0x1D}
+
+	// Miner registry (keyed by miner) and unbonding queue (keyed by
+	// big-endian unbonding end time + miner)
+	MinerRegistrationKeyPrefix = []byte{0x1E}
+	MinerUnbondingKeyPrefix    = []byte{0x1F}
+
+	// Miners a validator accepts onto its mining record (keyed by validator
+	// + "/" + miner)
+	ValidatorMinerKeyPrefix = []byte{0x24}
 )
 
 // Docking-specific key prefixes
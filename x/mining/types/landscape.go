@@ -0,0 +1,45 @@
+package types
+
+// LandscapeMinimum aggregates every submission that reported the same
+// best configuration for a job, i.e. one distinct local minimum
+type LandscapeMinimum struct {
+	JobId          string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	ConfigHash     string `protobuf:"bytes,2,opt,name=config_hash,json=configHash,proto3" json:"config_hash"`
+	Energy         int64  `protobuf:"varint,3,opt,name=energy,proto3" json:"energy"`
+	MinerCount     int64  `protobuf:"varint,4,opt,name=miner_count,json=minerCount,proto3" json:"miner_count"`
+	HitCount       int64  `protobuf:"varint,5,opt,name=hit_count,json=hitCount,proto3" json:"hit_count"`
+	FirstFinder    string `protobuf:"bytes,6,opt,name=first_finder,json=firstFinder,proto3" json:"first_finder"`
+	FirstSeenEpoch uint64 `protobuf:"varint,7,opt,name=first_seen_epoch,json=firstSeenEpoch,proto3" json:"first_seen_epoch"`
+}
+
+func (m *LandscapeMinimum) Reset()         { *m = LandscapeMinimum{} }
+func (m *LandscapeMinimum) String() string { return m.ConfigHash }
+func (m *LandscapeMinimum) ProtoMessage()  {}
+
+// MaxEpochLandscapeEnergies bounds the energies sampled per epoch for the
+// median
+const MaxEpochLandscapeEnergies = 1000
+
+// EpochLandscape summarizes the submissions made to a job in one epoch.
+// Energies is a sorted sample of at most MaxEpochLandscapeEnergies
+// submissions, so the median is exact up to that many submissions and a
+// sampled estimate beyond.
+type EpochLandscape struct {
+	JobId        string  `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Epoch        uint64  `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch"`
+	Submissions  int64   `protobuf:"varint,3,opt,name=submissions,proto3" json:"submissions"`
+	TotalSteps   uint64  `protobuf:"varint,4,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps"`
+	BestEnergy   int64   `protobuf:"varint,5,opt,name=best_energy,json=bestEnergy,proto3" json:"best_energy"`
+	MedianEnergy int64   `protobuf:"varint,6,opt,name=median_energy,json=medianEnergy,proto3" json:"median_energy"`
+	Energies     []int64 `protobuf:"varint,7,rep,packed,name=energies,proto3" json:"-"`
+}
+
+func (m *EpochLandscape) Reset()         { *m = EpochLandscape{} }
+func (m *EpochLandscape) String() string { return "EpochLandscape" }
+func (m *EpochLandscape) ProtoMessage()  {}
+
+// LandscapeKey builds the per-job key suffix used by landscape prefixes
+func LandscapeKey(jobId string, rest []byte) []byte {
+	key := append([]byte(jobId), '/')
+	return append(key, rest...)
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"cosmossdk.io/math"
+)
+
+// MaxMinerMetadataLength bounds the free-form metadata a miner registers
+const MaxMinerMetadataLength = 256
+
+// Hardware classes a miner may declare at registration
+const (
+	HardwareClassCPU  = "cpu"
+	HardwareClassGPU  = "gpu"
+	HardwareClassFPGA = "fpga"
+	HardwareClassASIC = "asic"
+)
+
+// ValidHardwareClass reports whether class is empty or a known hardware class
+func ValidHardwareClass(class string) bool {
+	switch class {
+	case "", HardwareClassCPU, HardwareClassGPU, HardwareClassFPGA, HardwareClassASIC:
+		return true
+	}
+	return false
+}
+
+type MinerStatus uint32
+
+const (
+	MinerStatusActive    MinerStatus = 0
+	MinerStatusUnbonding MinerStatus = 1
+)
+
+// MinerRegistration is a miner's bonded entry in the registry. While
+// registration is required only active miners may submit work; an unbonding
+// miner's bond is returned once its unbonding period ends.
+type MinerRegistration struct {
+	Miner           string      `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner"`
+	Bond            math.Int    `protobuf:"bytes,2,opt,name=bond,proto3,customtype=cosmossdk.io/math.Int" json:"bond"`
+	Metadata        string      `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata"`
+	HardwareClass   string      `protobuf:"bytes,4,opt,name=hardware_class,json=hardwareClass,proto3" json:"hardware_class"`
+	PayoutAddress   string      `protobuf:"bytes,5,opt,name=payout_address,json=payoutAddress,proto3" json:"payout_address"`
+	Status          MinerStatus `protobuf:"varint,6,opt,name=status,proto3,casttype=MinerStatus" json:"status"`
+	RegisteredAt    int64       `protobuf:"varint,7,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at"`
+	UnbondingEndsAt int64       `protobuf:"varint,8,opt,name=unbonding_ends_at,json=unbondingEndsAt,proto3" json:"unbonding_ends_at"`
+}
+
+func (m *MinerRegistration) Reset()         { *m = MinerRegistration{} }
+func (m *MinerRegistration) String() string { return m.Miner }
+func (m *MinerRegistration) ProtoMessage()  {}
+
+// IsActive reports whether the miner may submit work
+func (m MinerRegistration) IsActive() bool {
+	return m.Status == MinerStatusActive
+}
+
+// MinerUnbondingKey returns big-endian unbonding end time + miner, ordering
+// the unbonding queue by maturity
+func MinerUnbondingKey(endsAt int64, miner string) []byte {
+	key := binary.BigEndian.AppendUint64(make([]byte, 0, 8+len(miner)), uint64(endsAt))
+	return append(key, []byte(miner)...)
+}
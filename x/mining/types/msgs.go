@@ -1,28 +1,64 @@
 package types
 
 import (
+	"encoding/hex"
+
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	TypeMsgPostJob         = "post_job"
-	TypeMsgSubmitProof     = "submit_proof"
-	TypeMsgClaimRewards    = "claim_rewards"
-	TypeMsgCancelJob       = "cancel_job"
-	TypeMsgSubmitPublicJob = "submit_public_job"
+	TypeMsgPostJob          = "post_job"
+	TypeMsgSubmitProof      = "submit_proof"
+	TypeMsgClaimRewards     = "claim_rewards"
+	TypeMsgCancelJob        = "cancel_job"
+	TypeMsgSubmitPublicJob  = "submit_public_job"
+	TypeMsgExtendJob        = "extend_job"
+	TypeMsgSetMinerGroup    = "set_miner_group"
+	TypeMsgCommitRandomness = "commit_randomness"
+	TypeMsgRevealRandomness = "reveal_randomness"
+	TypeMsgSetAlgorithm     = "set_algorithm"
+	TypeMsgUpdateParams     = "update_params"
+	TypeMsgClaimAllRewards  = "claim_all_rewards"
+	TypeMsgWithdrawVested   = "withdraw_vested"
+	TypeMsgSlashMiner       = "slash_miner"
+	TypeMsgLinkValidator    = "link_validator"
+	TypeMsgAcceptMiner      = "accept_miner"
+	TypeMsgCreatePool       = "create_pool"
+	TypeMsgJoinPool         = "join_pool"
+	TypeMsgLeavePool        = "leave_pool"
+	TypeMsgClaimPoolRewards = "claim_pool_rewards"
+
+	// MaxAllowlistSize bounds inline allowlists and miner groups
+	MaxAllowlistSize = 200
+	// MaxMinerGroupNameLength bounds miner group names
+	MaxMinerGroupNameLength = 64
+	// MaxClaimAllLimit bounds the jobs withdrawn by one MsgClaimAllRewards
+	// and the size of its job filter; it is also the default page limit
+	MaxClaimAllLimit = 100
 )
 
+// isNexusCoins reports whether coins are valid and hold nothing but unexus,
+// the only denom job rewards are escrowed in
+func isNexusCoins(coins sdk.Coins) bool {
+	return coins.IsValid() && (coins.Empty() || (len(coins) == 1 && coins[0].Denom == "unexus"))
+}
+
 // MsgPostJob - paid job submission with optional priority fee
 type MsgPostJob struct {
-	Customer    string    `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
-	ProblemType string    `protobuf:"bytes,2,opt,name=problem_type,json=problemType,proto3" json:"problem_type,omitempty"`
-	ProblemData []byte    `protobuf:"bytes,3,opt,name=problem_data,json=problemData,proto3" json:"problem_data,omitempty"`
-	ProblemHash string    `protobuf:"bytes,4,opt,name=problem_hash,json=problemHash,proto3" json:"problem_hash,omitempty"`
-	Threshold   int64     `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	Reward      sdk.Coins `protobuf:"bytes,6,rep,name=reward,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"reward"`
-	PriorityFee sdk.Coins `protobuf:"bytes,7,rep,name=priority_fee,json=priorityFee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"priority_fee"`
-	Duration    int64     `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
-	QuantumSafe bool      `protobuf:"varint,9,opt,name=quantum_safe,json=quantumSafe,proto3" json:"quantum_safe,omitempty"`
+	Customer    string     `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	ProblemType string     `protobuf:"bytes,2,opt,name=problem_type,json=problemType,proto3" json:"problem_type,omitempty"`
+	ProblemData []byte     `protobuf:"bytes,3,opt,name=problem_data,json=problemData,proto3" json:"problem_data,omitempty"`
+	ProblemHash string     `protobuf:"bytes,4,opt,name=problem_hash,json=problemHash,proto3" json:"problem_hash,omitempty"`
+	Threshold   int64      `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Reward      sdk.Coins  `protobuf:"bytes,6,rep,name=reward,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"reward"`
+	PriorityFee sdk.Coins  `protobuf:"bytes,7,rep,name=priority_fee,json=priorityFee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"priority_fee"`
+	Duration    int64      `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	QuantumSafe bool       `protobuf:"varint,9,opt,name=quantum_safe,json=quantumSafe,proto3" json:"quantum_safe,omitempty"`
+	Allowlist   []string   `protobuf:"bytes,10,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	MinerGroup  string     `protobuf:"bytes,11,opt,name=miner_group,json=minerGroup,proto3" json:"miner_group,omitempty"`
+	MiningMode  MiningMode `protobuf:"varint,12,opt,name=mining_mode,json=miningMode,proto3,casttype=MiningMode" json:"mining_mode,omitempty"`
+	AlgorithmId string     `protobuf:"bytes,13,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
 }
 
 func (m *MsgPostJob) Reset()                  { *m = MsgPostJob{} }
@@ -34,6 +70,24 @@ func (msg MsgPostJob) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(msg.Customer); err != nil {
 		return ErrInvalidJob
 	}
+	if !isNexusCoins(msg.Reward) {
+		return ErrInvalidJob
+	}
+	if len(msg.Allowlist) > MaxAllowlistSize || len(msg.MinerGroup) > MaxMinerGroupNameLength {
+		return ErrInvalidJob
+	}
+	for _, addr := range msg.Allowlist {
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return ErrInvalidMiner
+		}
+	}
+	if !msg.MiningMode.IsValid() {
+		return ErrWrongMiningMode
+	}
+	// Algorithms only apply to collaborative work submissions
+	if msg.MiningMode == MiningModeCompetitive && msg.AlgorithmId != "" {
+		return ErrWrongMiningMode
+	}
 	return nil
 }
 
@@ -50,6 +104,8 @@ type MsgSubmitProof struct {
 	Proof        []byte `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
 	ProofType    string `protobuf:"bytes,5,opt,name=proof_type,json=proofType,proto3" json:"proof_type,omitempty"`
 	SolutionHash string `protobuf:"bytes,6,opt,name=solution_hash,json=solutionHash,proto3" json:"solution_hash,omitempty"`
+	// Pool the miner submits on behalf of; empty mines solo
+	Pool string `protobuf:"bytes,7,opt,name=pool,proto3" json:"pool,omitempty"`
 }
 
 func (m *MsgSubmitProof) Reset()                  { *m = MsgSubmitProof{} }
@@ -92,6 +148,50 @@ func (msg MsgClaimRewards) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{claimer}
 }
 
+// MsgClaimAllRewards - withdraw settled payouts across many jobs in one
+// transfer. JobIds optionally restricts the claim to those jobs; Limit caps
+// the jobs withdrawn (0 = MaxClaimAllLimit).
+type MsgClaimAllRewards struct {
+	Claimer string   `protobuf:"bytes,1,opt,name=claimer,proto3" json:"claimer,omitempty"`
+	JobIds  []string `protobuf:"bytes,2,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	Limit   uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *MsgClaimAllRewards) Reset()                  { *m = MsgClaimAllRewards{} }
+func (m *MsgClaimAllRewards) String() string          { return "MsgClaimAllRewards" }
+func (m *MsgClaimAllRewards) ProtoMessage()           {}
+func (m *MsgClaimAllRewards) XXX_MessageName() string { return "nexus.mining.MsgClaimAllRewards" }
+
+func (msg MsgClaimAllRewards) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Claimer); err != nil {
+		return ErrUnauthorized
+	}
+	if len(msg.JobIds) > MaxClaimAllLimit || msg.Limit > MaxClaimAllLimit {
+		return ErrInvalidParams
+	}
+	return nil
+}
+
+func (msg MsgClaimAllRewards) GetSigners() []sdk.AccAddress {
+	claimer, _ := sdk.AccAddressFromBech32(msg.Claimer)
+	return []sdk.AccAddress{claimer}
+}
+
+// MsgClaimAllRewardsResponse carries the total paid and a per-job breakdown.
+// HasMore is set when the limit stopped the claim before the miner's
+// outstanding jobs were exhausted. Vesting is the part of Amount locked in a
+// vesting grant rather than transferred.
+type MsgClaimAllRewardsResponse struct {
+	Amount  sdk.Coins         `protobuf:"bytes,1,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	Claims  []ClaimableReward `protobuf:"bytes,2,rep,name=claims,proto3" json:"claims"`
+	HasMore bool              `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more"`
+	Vesting sdk.Coins         `protobuf:"bytes,4,rep,name=vesting,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"vesting"`
+}
+
+func (m *MsgClaimAllRewardsResponse) Reset()         { *m = MsgClaimAllRewardsResponse{} }
+func (m *MsgClaimAllRewardsResponse) String() string { return "MsgClaimAllRewardsResponse" }
+func (m *MsgClaimAllRewardsResponse) ProtoMessage()  {}
+
 // MsgCancelJob
 type MsgCancelJob struct {
 	Customer string `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
@@ -115,6 +215,228 @@ func (msg MsgCancelJob) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{customer}
 }
 
+// MsgExtendJob - customer tops up the reward and/or pushes out the deadline of a paid job
+type MsgExtendJob struct {
+	Customer         string    `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	JobId            string    `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	AdditionalReward sdk.Coins `protobuf:"bytes,3,rep,name=additional_reward,json=additionalReward,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"additional_reward"`
+	Extension        int64     `protobuf:"varint,4,opt,name=extension,proto3" json:"extension,omitempty"` // seconds added to the deadline
+}
+
+func (m *MsgExtendJob) Reset()                  { *m = MsgExtendJob{} }
+func (m *MsgExtendJob) String() string          { return "MsgExtendJob" }
+func (m *MsgExtendJob) ProtoMessage()           {}
+func (m *MsgExtendJob) XXX_MessageName() string { return "nexus.mining.MsgExtendJob" }
+
+func (msg MsgExtendJob) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Customer); err != nil {
+		return ErrUnauthorized
+	}
+	if len(msg.JobId) == 0 {
+		return ErrInvalidJob
+	}
+	if msg.Extension < 0 || !isNexusCoins(msg.AdditionalReward) {
+		return ErrInvalidExtension
+	}
+	if msg.Extension == 0 && msg.AdditionalReward.IsZero() {
+		return ErrInvalidExtension
+	}
+	return nil
+}
+
+func (msg MsgExtendJob) GetSigners() []sdk.AccAddress {
+	customer, _ := sdk.AccAddressFromBech32(msg.Customer)
+	return []sdk.AccAddress{customer}
+}
+
+type MsgExtendJobResponse struct {
+	NewReward   math.Int `protobuf:"bytes,1,opt,name=new_reward,json=newReward,proto3,customtype=cosmossdk.io/math.Int" json:"new_reward"`
+	NewDeadline int64    `protobuf:"varint,2,opt,name=new_deadline,json=newDeadline,proto3" json:"new_deadline,omitempty"`
+}
+
+func (m *MsgExtendJobResponse) Reset()         { *m = MsgExtendJobResponse{} }
+func (m *MsgExtendJobResponse) String() string { return "MsgExtendJobResponse" }
+func (m *MsgExtendJobResponse) ProtoMessage()  {}
+
+// MsgSetMinerGroup - create or replace a named miner group used by permissioned jobs
+type MsgSetMinerGroup struct {
+	Owner   string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Name    string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Members []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (m *MsgSetMinerGroup) Reset()                  { *m = MsgSetMinerGroup{} }
+func (m *MsgSetMinerGroup) String() string          { return "MsgSetMinerGroup" }
+func (m *MsgSetMinerGroup) ProtoMessage()           {}
+func (m *MsgSetMinerGroup) XXX_MessageName() string { return "nexus.mining.MsgSetMinerGroup" }
+
+func (msg MsgSetMinerGroup) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Owner); err != nil {
+		return ErrUnauthorized
+	}
+	if len(msg.Name) == 0 || len(msg.Name) > MaxMinerGroupNameLength {
+		return ErrInvalidJob
+	}
+	if len(msg.Members) > MaxAllowlistSize {
+		return ErrInvalidJob
+	}
+	for _, addr := range msg.Members {
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return ErrInvalidMiner
+		}
+	}
+	return nil
+}
+
+func (msg MsgSetMinerGroup) GetSigners() []sdk.AccAddress {
+	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
+	return []sdk.AccAddress{owner}
+}
+
+type MsgSetMinerGroupResponse struct {
+	MemberCount int64 `protobuf:"varint,1,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+}
+
+func (m *MsgSetMinerGroupResponse) Reset()         { *m = MsgSetMinerGroupResponse{} }
+func (m *MsgSetMinerGroupResponse) String() string { return "MsgSetMinerGroupResponse" }
+func (m *MsgSetMinerGroupResponse) ProtoMessage()  {}
+
+// MsgCommitRandomness - bonded validator commits to a beacon secret
+type MsgCommitRandomness struct {
+	Validator  string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Commitment string `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (m *MsgCommitRandomness) Reset()                  { *m = MsgCommitRandomness{} }
+func (m *MsgCommitRandomness) String() string          { return "MsgCommitRandomness" }
+func (m *MsgCommitRandomness) ProtoMessage()           {}
+func (m *MsgCommitRandomness) XXX_MessageName() string { return "nexus.mining.MsgCommitRandomness" }
+
+func (msg MsgCommitRandomness) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Validator); err != nil {
+		return ErrUnauthorized
+	}
+	if bz, err := hex.DecodeString(msg.Commitment); err != nil || len(bz) != 32 {
+		return ErrInvalidCommitment
+	}
+	return nil
+}
+
+func (msg MsgCommitRandomness) GetSigners() []sdk.AccAddress {
+	validator, _ := sdk.AccAddressFromBech32(msg.Validator)
+	return []sdk.AccAddress{validator}
+}
+
+type MsgCommitRandomnessResponse struct{}
+
+func (m *MsgCommitRandomnessResponse) Reset()         { *m = MsgCommitRandomnessResponse{} }
+func (m *MsgCommitRandomnessResponse) String() string { return "MsgCommitRandomnessResponse" }
+func (m *MsgCommitRandomnessResponse) ProtoMessage()  {}
+
+// MsgRevealRandomness - reveal a committed beacon secret, optionally committing the next one
+type MsgRevealRandomness struct {
+	Validator      string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Secret         string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	NextCommitment string `protobuf:"bytes,3,opt,name=next_commitment,json=nextCommitment,proto3" json:"next_commitment,omitempty"`
+}
+
+func (m *MsgRevealRandomness) Reset()                  { *m = MsgRevealRandomness{} }
+func (m *MsgRevealRandomness) String() string          { return "MsgRevealRandomness" }
+func (m *MsgRevealRandomness) ProtoMessage()           {}
+func (m *MsgRevealRandomness) XXX_MessageName() string { return "nexus.mining.MsgRevealRandomness" }
+
+func (msg MsgRevealRandomness) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Validator); err != nil {
+		return ErrUnauthorized
+	}
+	if bz, err := hex.DecodeString(msg.Secret); err != nil || len(bz) != 32 {
+		return ErrInvalidCommitment
+	}
+	if msg.NextCommitment != "" {
+		if bz, err := hex.DecodeString(msg.NextCommitment); err != nil || len(bz) != 32 {
+			return ErrInvalidCommitment
+		}
+	}
+	return nil
+}
+
+func (msg MsgRevealRandomness) GetSigners() []sdk.AccAddress {
+	validator, _ := sdk.AccAddressFromBech32(msg.Validator)
+	return []sdk.AccAddress{validator}
+}
+
+type MsgRevealRandomnessResponse struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *MsgRevealRandomnessResponse) Reset()         { *m = MsgRevealRandomnessResponse{} }
+func (m *MsgRevealRandomnessResponse) String() string { return "MsgRevealRandomnessResponse" }
+func (m *MsgRevealRandomnessResponse) ProtoMessage()  {}
+
+// MsgSetAlgorithm - governance registers or updates a collaborative mining algorithm
+type MsgSetAlgorithm struct {
+	Authority string    `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Algorithm Algorithm `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm"`
+}
+
+func (m *MsgSetAlgorithm) Reset()                  { *m = MsgSetAlgorithm{} }
+func (m *MsgSetAlgorithm) String() string          { return "MsgSetAlgorithm" }
+func (m *MsgSetAlgorithm) ProtoMessage()           {}
+func (m *MsgSetAlgorithm) XXX_MessageName() string { return "nexus.mining.MsgSetAlgorithm" }
+
+func (msg MsgSetAlgorithm) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return ErrUnauthorized
+	}
+	if len(msg.Algorithm.Id) == 0 || len(msg.Algorithm.Id) > MaxAlgorithmIdLength {
+		return ErrAlgorithmNotFound
+	}
+	if !msg.Algorithm.Status.IsValid() {
+		return ErrInvalidParams
+	}
+	return nil
+}
+
+func (msg MsgSetAlgorithm) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+type MsgSetAlgorithmResponse struct{}
+
+func (m *MsgSetAlgorithmResponse) Reset()         { *m = MsgSetAlgorithmResponse{} }
+func (m *MsgSetAlgorithmResponse) String() string { return "MsgSetAlgorithmResponse" }
+func (m *MsgSetAlgorithmResponse) ProtoMessage()  {}
+
+// MsgUpdateParams - governance replaces the module parameters
+type MsgUpdateParams struct {
+	Authority string `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Params    Params `protobuf:"bytes,2,opt,name=params,proto3" json:"params"`
+}
+
+func (m *MsgUpdateParams) Reset()                  { *m = MsgUpdateParams{} }
+func (m *MsgUpdateParams) String() string          { return "MsgUpdateParams" }
+func (m *MsgUpdateParams) ProtoMessage()           {}
+func (m *MsgUpdateParams) XXX_MessageName() string { return "nexus.mining.MsgUpdateParams" }
+
+func (msg MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return ErrUnauthorized
+	}
+	return msg.Params.Validate()
+}
+
+func (msg MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+type MsgUpdateParamsResponse struct{}
+
+func (m *MsgUpdateParamsResponse) Reset()         { *m = MsgUpdateParamsResponse{} }
+func (m *MsgUpdateParamsResponse) String() string { return "MsgUpdateParamsResponse" }
+func (m *MsgUpdateParamsResponse) ProtoMessage()  {}
+
 // MsgSubmitPublicJob - free background job for public benefit
 type MsgSubmitPublicJob struct {
 	Submitter   string `protobuf:"bytes,1,opt,name=submitter,proto3" json:"submitter,omitempty"`
@@ -165,6 +487,8 @@ type MsgSubmitWork struct {
 	BestConfigHash string `protobuf:"bytes,7,opt,name=best_config_hash,json=bestConfigHash,proto3" json:"best_config_hash,omitempty"`
 	Proof          []byte `protobuf:"bytes,8,opt,name=proof,proto3" json:"proof,omitempty"`
 	AlgorithmId    string `protobuf:"bytes,9,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
+	// Pool the miner submits on behalf of; empty mines solo
+	Pool string `protobuf:"bytes,10,opt,name=pool,proto3" json:"pool,omitempty"`
 }
 
 func (m *MsgSubmitWork) Reset()                  { *m = MsgSubmitWork{} }
@@ -172,6 +496,10 @@ func (m *MsgSubmitWork) String() string          { return "MsgSubmitWork" }
 func (m *MsgSubmitWork) ProtoMessage()           {}
 func (m *MsgSubmitWork) XXX_MessageName() string { return "nexus.mining.MsgSubmitWork" }
 
+// MaxNumSteps bounds the steps of one work submission; steps are counted
+// as int64 work shares
+const MaxNumSteps = uint64(1<<63 - 1)
+
 func (msg MsgSubmitWork) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
 		return ErrInvalidMiner
@@ -179,6 +507,9 @@ func (msg MsgSubmitWork) ValidateBasic() error {
 	if msg.NumSteps == 0 {
 		return ErrInvalidProof
 	}
+	if msg.NumSteps > MaxNumSteps {
+		return ErrInvalidStepCount
+	}
 	if len(msg.Proof) == 0 {
 		return ErrInvalidProof
 	}
@@ -192,9 +523,10 @@ func (msg MsgSubmitWork) GetSigners() []sdk.AccAddress {
 
 // Response types
 type MsgSubmitWorkResponse struct {
-	Accepted    bool  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	WorkShares  int64 `protobuf:"varint,2,opt,name=work_shares,json=workShares,proto3" json:"work_shares,omitempty"`
-	BonusShares int64 `protobuf:"varint,3,opt,name=bonus_shares,json=bonusShares,proto3" json:"bonus_shares,omitempty"`
+	Accepted     bool   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	WorkShares   int64  `protobuf:"varint,2,opt,name=work_shares,json=workShares,proto3" json:"work_shares,omitempty"`
+	BonusShares  int64  `protobuf:"varint,3,opt,name=bonus_shares,json=bonusShares,proto3" json:"bonus_shares,omitempty"`
+	SubmissionId string `protobuf:"bytes,4,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
 }
 
 func (m *MsgSubmitWorkResponse) Reset()         { *m = MsgSubmitWorkResponse{} }
@@ -243,6 +575,7 @@ type MsgSubmitDockingResultResponse struct {
 	Accepted bool  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
 	Reward   int64 `protobuf:"varint,2,opt,name=reward,proto3" json:"reward,omitempty"`
 	IsHit    bool  `protobuf:"varint,3,opt,name=is_hit,json=isHit,proto3" json:"is_hit,omitempty"`
+	Shares   int64 `protobuf:"varint,4,opt,name=shares,proto3" json:"shares,omitempty"`
 }
 
 func (m *MsgSubmitDockingResultResponse) Reset()         { *m = MsgSubmitDockingResultResponse{} }
@@ -331,3 +664,409 @@ type MsgCreateDockingJobResponse struct {
 func (m *MsgCreateDockingJobResponse) Reset()         { *m = MsgCreateDockingJobResponse{} }
 func (m *MsgCreateDockingJobResponse) String() string { return "MsgCreateDockingJobResponse" }
 func (m *MsgCreateDockingJobResponse) ProtoMessage()  {}
+
+// MsgWithdrawVested - release a miner's unlocked vesting rewards
+type MsgWithdrawVested struct {
+	Miner string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner,omitempty"`
+}
+
+func (m *MsgWithdrawVested) Reset()                  { *m = MsgWithdrawVested{} }
+func (m *MsgWithdrawVested) String() string          { return "MsgWithdrawVested" }
+func (m *MsgWithdrawVested) ProtoMessage()           {}
+func (m *MsgWithdrawVested) XXX_MessageName() string { return "nexus.mining.MsgWithdrawVested" }
+
+func (msg MsgWithdrawVested) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func (msg MsgWithdrawVested) GetSigners() []sdk.AccAddress {
+	miner, _ := sdk.AccAddressFromBech32(msg.Miner)
+	return []sdk.AccAddress{miner}
+}
+
+type MsgWithdrawVestedResponse struct {
+	Amount sdk.Coins `protobuf:"bytes,1,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+}
+
+func (m *MsgWithdrawVestedResponse) Reset()         { *m = MsgWithdrawVestedResponse{} }
+func (m *MsgWithdrawVestedResponse) String() string { return "MsgWithdrawVestedResponse" }
+func (m *MsgWithdrawVestedResponse) ProtoMessage()  {}
+
+// MsgSlashMiner - governance slashes a miner for fraud, forfeiting its
+// locked vesting rewards to the community pool
+type MsgSlashMiner struct {
+	Authority string `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Miner     string `protobuf:"bytes,2,opt,name=miner,proto3" json:"miner,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *MsgSlashMiner) Reset()                  { *m = MsgSlashMiner{} }
+func (m *MsgSlashMiner) String() string          { return "MsgSlashMiner" }
+func (m *MsgSlashMiner) ProtoMessage()           {}
+func (m *MsgSlashMiner) XXX_MessageName() string { return "nexus.mining.MsgSlashMiner" }
+
+func (msg MsgSlashMiner) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return ErrUnauthorized
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrInvalidMiner
+	}
+	return nil
+}
+
+func (msg MsgSlashMiner) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+type MsgSlashMinerResponse struct {
+	Forfeited math.Int `protobuf:"bytes,1,opt,name=forfeited,proto3,customtype=cosmossdk.io/math.Int" json:"forfeited"`
+}
+
+func (m *MsgSlashMinerResponse) Reset()         { *m = MsgSlashMinerResponse{} }
+func (m *MsgSlashMinerResponse) String() string { return "MsgSlashMinerResponse" }
+func (m *MsgSlashMinerResponse) ProtoMessage()  {}
+
+// MsgLinkValidator - a miner asks to attribute its work to a validator's
+// mining record; an empty validator removes the link. The link counts once
+// the validator accepts the miner with MsgAcceptMiner.
+type MsgLinkValidator struct {
+	Miner     string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner,omitempty"`
+	Validator string `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+}
+
+func (m *MsgLinkValidator) Reset()                  { *m = MsgLinkValidator{} }
+func (m *MsgLinkValidator) String() string          { return "MsgLinkValidator" }
+func (m *MsgLinkValidator) ProtoMessage()           {}
+func (m *MsgLinkValidator) XXX_MessageName() string { return "nexus.mining.MsgLinkValidator" }
+
+func (msg MsgLinkValidator) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrInvalidMiner
+	}
+	if msg.Validator != "" {
+		if _, err := sdk.ValAddressFromBech32(msg.Validator); err != nil {
+			return ErrValidatorNotFound
+		}
+	}
+	return nil
+}
+
+func (msg MsgLinkValidator) GetSigners() []sdk.AccAddress {
+	miner, _ := sdk.AccAddressFromBech32(msg.Miner)
+	return []sdk.AccAddress{miner}
+}
+
+type MsgLinkValidatorResponse struct{}
+
+func (m *MsgLinkValidatorResponse) Reset()         { *m = MsgLinkValidatorResponse{} }
+func (m *MsgLinkValidatorResponse) String() string { return "MsgLinkValidatorResponse" }
+func (m *MsgLinkValidatorResponse) ProtoMessage()  {}
+
+// MsgAcceptMiner - a validator operator accepts a miner's work onto its
+// mining record, before or after the miner links; Revoke withdraws it
+type MsgAcceptMiner struct {
+	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Miner     string `protobuf:"bytes,2,opt,name=miner,proto3" json:"miner,omitempty"`
+	Revoke    bool   `protobuf:"varint,3,opt,name=revoke,proto3" json:"revoke,omitempty"`
+}
+
+func (m *MsgAcceptMiner) Reset()                  { *m = MsgAcceptMiner{} }
+func (m *MsgAcceptMiner) String() string          { return "MsgAcceptMiner" }
+func (m *MsgAcceptMiner) ProtoMessage()           {}
+func (m *MsgAcceptMiner) XXX_MessageName() string { return "nexus.mining.MsgAcceptMiner" }
+
+func (msg MsgAcceptMiner) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Validator); err != nil {
+		return ErrValidatorNotFound
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrInvalidMiner
+	}
+	return nil
+}
+
+func (msg MsgAcceptMiner) GetSigners() []sdk.AccAddress {
+	validator, _ := sdk.AccAddressFromBech32(msg.Validator)
+	return []sdk.AccAddress{validator}
+}
+
+type MsgAcceptMinerResponse struct{}
+
+func (m *MsgAcceptMinerResponse) Reset()         { *m = MsgAcceptMinerResponse{} }
+func (m *MsgAcceptMinerResponse) String() string { return "MsgAcceptMinerResponse" }
+func (m *MsgAcceptMinerResponse) ProtoMessage()  {}
+
+// MsgCreatePool - create a mining pool. Closed pools accept only the listed
+// members; open pools also accept anyone through MsgJoinPool.
+type MsgCreatePool struct {
+	Operator   string   `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	PoolId     string   `protobuf:"bytes,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	FeePercent uint64   `protobuf:"varint,3,opt,name=fee_percent,json=feePercent,proto3" json:"fee_percent,omitempty"`
+	Members    []string `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	OpenJoin   bool     `protobuf:"varint,5,opt,name=open_join,json=openJoin,proto3" json:"open_join,omitempty"`
+}
+
+func (m *MsgCreatePool) Reset()                  { *m = MsgCreatePool{} }
+func (m *MsgCreatePool) String() string          { return "MsgCreatePool" }
+func (m *MsgCreatePool) ProtoMessage()           {}
+func (m *MsgCreatePool) XXX_MessageName() string { return "nexus.mining.MsgCreatePool" }
+
+func (msg MsgCreatePool) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Operator); err != nil {
+		return ErrUnauthorized
+	}
+	if !ValidPoolId(msg.PoolId) || msg.FeePercent > 100 {
+		return ErrInvalidParams
+	}
+	if len(msg.Members) > MaxAllowlistSize {
+		return ErrInvalidParams
+	}
+	for _, addr := range msg.Members {
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return ErrInvalidMiner
+		}
+	}
+	return nil
+}
+
+func (msg MsgCreatePool) GetSigners() []sdk.AccAddress {
+	operator, _ := sdk.AccAddressFromBech32(msg.Operator)
+	return []sdk.AccAddress{operator}
+}
+
+type MsgCreatePoolResponse struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *MsgCreatePoolResponse) Reset()         { *m = MsgCreatePoolResponse{} }
+func (m *MsgCreatePoolResponse) String() string { return "MsgCreatePoolResponse" }
+func (m *MsgCreatePoolResponse) ProtoMessage()  {}
+
+// MsgJoinPool - join an open mining pool
+type MsgJoinPool struct {
+	Member string `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	PoolId string `protobuf:"bytes,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+}
+
+func (m *MsgJoinPool) Reset()                  { *m = MsgJoinPool{} }
+func (m *MsgJoinPool) String() string          { return "MsgJoinPool" }
+func (m *MsgJoinPool) ProtoMessage()           {}
+func (m *MsgJoinPool) XXX_MessageName() string { return "nexus.mining.MsgJoinPool" }
+
+func (msg MsgJoinPool) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Member); err != nil {
+		return ErrInvalidMiner
+	}
+	if !ValidPoolId(msg.PoolId) {
+		return ErrPoolNotFound
+	}
+	return nil
+}
+
+func (msg MsgJoinPool) GetSigners() []sdk.AccAddress {
+	member, _ := sdk.AccAddressFromBech32(msg.Member)
+	return []sdk.AccAddress{member}
+}
+
+type MsgJoinPoolResponse struct{}
+
+func (m *MsgJoinPoolResponse) Reset()         { *m = MsgJoinPoolResponse{} }
+func (m *MsgJoinPoolResponse) String() string { return "MsgJoinPoolResponse" }
+func (m *MsgJoinPoolResponse) ProtoMessage()  {}
+
+// MsgLeavePool - leave a mining pool; contributions already recorded are
+// still paid when the pool claims those jobs
+type MsgLeavePool struct {
+	Member string `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	PoolId string `protobuf:"bytes,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+}
+
+func (m *MsgLeavePool) Reset()                  { *m = MsgLeavePool{} }
+func (m *MsgLeavePool) String() string          { return "MsgLeavePool" }
+func (m *MsgLeavePool) ProtoMessage()           {}
+func (m *MsgLeavePool) XXX_MessageName() string { return "nexus.mining.MsgLeavePool" }
+
+func (msg MsgLeavePool) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Member); err != nil {
+		return ErrInvalidMiner
+	}
+	if !ValidPoolId(msg.PoolId) {
+		return ErrPoolNotFound
+	}
+	return nil
+}
+
+func (msg MsgLeavePool) GetSigners() []sdk.AccAddress {
+	member, _ := sdk.AccAddressFromBech32(msg.Member)
+	return []sdk.AccAddress{member}
+}
+
+type MsgLeavePoolResponse struct{}
+
+func (m *MsgLeavePoolResponse) Reset()         { *m = MsgLeavePoolResponse{} }
+func (m *MsgLeavePoolResponse) String() string { return "MsgLeavePoolResponse" }
+func (m *MsgLeavePoolResponse) ProtoMessage()  {}
+
+// MsgClaimPoolRewards - withdraw a pool's settled payout on a job and split
+// it between the operator's fee and the contributing members. Any member or
+// the operator may trigger it.
+type MsgClaimPoolRewards struct {
+	Claimer string `protobuf:"bytes,1,opt,name=claimer,proto3" json:"claimer,omitempty"`
+	PoolId  string `protobuf:"bytes,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	JobId   string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (m *MsgClaimPoolRewards) Reset()                  { *m = MsgClaimPoolRewards{} }
+func (m *MsgClaimPoolRewards) String() string          { return "MsgClaimPoolRewards" }
+func (m *MsgClaimPoolRewards) ProtoMessage()           {}
+func (m *MsgClaimPoolRewards) XXX_MessageName() string { return "nexus.mining.MsgClaimPoolRewards" }
+
+func (msg MsgClaimPoolRewards) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Claimer); err != nil {
+		return ErrUnauthorized
+	}
+	if !ValidPoolId(msg.PoolId) {
+		return ErrPoolNotFound
+	}
+	if msg.JobId == "" {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (msg MsgClaimPoolRewards) GetSigners() []sdk.AccAddress {
+	claimer, _ := sdk.AccAddressFromBech32(msg.Claimer)
+	return []sdk.AccAddress{claimer}
+}
+
+type MsgClaimPoolRewardsResponse struct {
+	Total   math.Int             `protobuf:"bytes,1,opt,name=total,proto3,customtype=cosmossdk.io/math.Int" json:"total"`
+	Fee     math.Int             `protobuf:"bytes,2,opt,name=fee,proto3,customtype=cosmossdk.io/math.Int" json:"fee"`
+	Payouts []PoolMemberEarnings `protobuf:"bytes,3,rep,name=payouts,proto3" json:"payouts"`
+}
+
+func (m *MsgClaimPoolRewardsResponse) Reset()         { *m = MsgClaimPoolRewardsResponse{} }
+func (m *MsgClaimPoolRewardsResponse) String() string { return "MsgClaimPoolRewardsResponse" }
+func (m *MsgClaimPoolRewardsResponse) ProtoMessage()  {}
+
+// MsgTreasurySpend - governance pays a grant out of the mining treasury
+type MsgTreasurySpend struct {
+	Authority string    `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Recipient string    `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Amount    sdk.Coins `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	Purpose   string    `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+}
+
+func (m *MsgTreasurySpend) Reset()                  { *m = MsgTreasurySpend{} }
+func (m *MsgTreasurySpend) String() string          { return "MsgTreasurySpend" }
+func (m *MsgTreasurySpend) ProtoMessage()           {}
+func (m *MsgTreasurySpend) XXX_MessageName() string { return "nexus.mining.MsgTreasurySpend" }
+
+func (msg MsgTreasurySpend) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return ErrUnauthorized
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Recipient); err != nil {
+		return ErrInvalidSpend
+	}
+	if msg.Amount.Empty() || !msg.Amount.IsValid() || len(msg.Purpose) > MaxTreasuryPurposeLength {
+		return ErrInvalidSpend
+	}
+	return nil
+}
+
+func (msg MsgTreasurySpend) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+type MsgTreasurySpendResponse struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+}
+
+func (m *MsgTreasurySpendResponse) Reset()         { *m = MsgTreasurySpendResponse{} }
+func (m *MsgTreasurySpendResponse) String() string { return "MsgTreasurySpendResponse" }
+func (m *MsgTreasurySpendResponse) ProtoMessage()  {}
+
+// MsgRegisterMiner - a miner bonds stake to join the miner registry, or an
+// active miner adds bond and updates its details
+type MsgRegisterMiner struct {
+	Miner         string    `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner,omitempty"`
+	Bond          sdk.Coins `protobuf:"bytes,2,rep,name=bond,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"bond"`
+	Metadata      string    `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	HardwareClass string    `protobuf:"bytes,4,opt,name=hardware_class,json=hardwareClass,proto3" json:"hardware_class,omitempty"`
+	PayoutAddress string    `protobuf:"bytes,5,opt,name=payout_address,json=payoutAddress,proto3" json:"payout_address,omitempty"`
+}
+
+func (m *MsgRegisterMiner) Reset()                  { *m = MsgRegisterMiner{} }
+func (m *MsgRegisterMiner) String() string          { return "MsgRegisterMiner" }
+func (m *MsgRegisterMiner) ProtoMessage()           {}
+func (m *MsgRegisterMiner) XXX_MessageName() string { return "nexus.mining.MsgRegisterMiner" }
+
+func (msg MsgRegisterMiner) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrInvalidMiner
+	}
+	if !msg.Bond.IsValid() {
+		return ErrInsufficientBond
+	}
+	if len(msg.Metadata) > MaxMinerMetadataLength || !ValidHardwareClass(msg.HardwareClass) {
+		return ErrInvalidMiner
+	}
+	if msg.PayoutAddress != "" {
+		if _, err := sdk.AccAddressFromBech32(msg.PayoutAddress); err != nil {
+			return ErrInvalidMiner
+		}
+	}
+	return nil
+}
+
+func (msg MsgRegisterMiner) GetSigners() []sdk.AccAddress {
+	miner, _ := sdk.AccAddressFromBech32(msg.Miner)
+	return []sdk.AccAddress{miner}
+}
+
+type MsgRegisterMinerResponse struct {
+	Bond math.Int `protobuf:"bytes,1,opt,name=bond,proto3,customtype=cosmossdk.io/math.Int" json:"bond"`
+}
+
+func (m *MsgRegisterMinerResponse) Reset()         { *m = MsgRegisterMinerResponse{} }
+func (m *MsgRegisterMinerResponse) String() string { return "MsgRegisterMinerResponse" }
+func (m *MsgRegisterMinerResponse) ProtoMessage()  {}
+
+// MsgUnbondMiner - a miner leaves the registry; its bond is returned once the
+// unbonding period ends
+type MsgUnbondMiner struct {
+	Miner string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner,omitempty"`
+}
+
+func (m *MsgUnbondMiner) Reset()                  { *m = MsgUnbondMiner{} }
+func (m *MsgUnbondMiner) String() string          { return "MsgUnbondMiner" }
+func (m *MsgUnbondMiner) ProtoMessage()           {}
+func (m *MsgUnbondMiner) XXX_MessageName() string { return "nexus.mining.MsgUnbondMiner" }
+
+func (msg MsgUnbondMiner) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Miner); err != nil {
+		return ErrInvalidMiner
+	}
+	return nil
+}
+
+func (msg MsgUnbondMiner) GetSigners() []sdk.AccAddress {
+	miner, _ := sdk.AccAddressFromBech32(msg.Miner)
+	return []sdk.AccAddress{miner}
+}
+
+type MsgUnbondMinerResponse struct {
+	Bond            math.Int `protobuf:"bytes,1,opt,name=bond,proto3,customtype=cosmossdk.io/math.Int" json:"bond"`
+	UnbondingEndsAt int64    `protobuf:"varint,2,opt,name=unbonding_ends_at,json=unbondingEndsAt,proto3" json:"unbonding_ends_at"`
+}
+
+func (m *MsgUnbondMinerResponse) Reset()         { *m = MsgUnbondMinerResponse{} }
+func (m *MsgUnbondMinerResponse) String() string { return "MsgUnbondMinerResponse" }
+func (m *MsgUnbondMinerResponse) ProtoMessage()  {}
@@ -14,12 +14,83 @@ const (
 	DefaultMinProofPeriod        = 7 * 24 * time.Hour
 	DefaultJobFeeBurnPercent     = 2
 	DefaultTxFeeBurnPercent      = 50
+
+	// Collaborative jobs roll to a new epoch after whichever limit is hit first
+	DefaultCollabEpochBlocks   = 150
+	DefaultCollabEpochDuration = 5 * time.Minute
+
+	// Collaborative payout pools (COLLABORATIVE_MINING.md)
+	DefaultWorkPoolPercent        = 70
+	DefaultImprovementPoolPercent = 20
+	DefaultCollabValidatorPercent = 10
+
+	// Per-miner limits within one collaborative epoch (zero disables)
+	DefaultMaxSubmissionsPerEpoch = 10
+	DefaultMaxStepsPerEpoch       = 1000000
+
+	// Emission epochs last 2 years: 2 * 365.25 days * 24 hours * 60 minutes
+	DefaultEmissionEpochMinutes = 1051920
+	// Perpetual rate after the decay table runs out (1.5% of base = 539 NEX/min)
+	DefaultEmissionFloorPermille = 15
+
+	// Share of miner rewards paid into linear vesting (zero pays everything liquid)
+	DefaultVestingPercent  = 0
+	DefaultVestingDuration = 30 * 24 * time.Hour
+
+	// Relative weights splitting each minute of emission across workloads
+	DefaultSyntheticEmissionWeight = 40
+	DefaultPublicEmissionWeight    = 25
+	DefaultDockingEmissionWeight   = 25
+	DefaultProteinEmissionWeight   = 10
+
+	// Share of job rewards and emission paid to the mining treasury before
+	// the miner/validator split
+	DefaultTreasurySharePercent = 0
+
+	// EIP-1559 base fee: gas per block the base fee steers toward, and the
+	// inverse of the largest per-block change (1/8 = 12.5%)
+	DefaultBaseFeeTargetGas         = 10_000_000
+	DefaultBaseFeeChangeDenominator = 8
+
+	// Miners must bond and register before submitting work, and wait out the
+	// unbonding period to get the bond back
+	DefaultMinerRegistrationRequired = true
+	DefaultMinerUnbondingPeriod      = 21 * 24 * time.Hour
+
+	// EndBlocker asserts the invariants every interval blocks (zero disables)
+	// and reports a broken one rather than halting the chain
+	DefaultInvariantCheckInterval = 100
+	DefaultInvariantHalt          = false
 )
 
+// Workloads that share the per-minute emission budget
+const (
+	WorkloadSynthetic = "synthetic" // network-generated Ising background jobs
+	WorkloadPublic    = "public"    // public submissions and paid jobs
+	WorkloadDocking   = "docking"   // docking epochs
+	WorkloadProtein   = "protein"   // protein folding jobs
+)
+
+// EmissionWorkloads lists every workload in allocation order
+var EmissionWorkloads = []string{WorkloadSynthetic, WorkloadPublic, WorkloadDocking, WorkloadProtein}
+
 var (
 	DefaultBackgroundEmissionRate = math.NewInt(1000000)
 	DefaultMinJobReward           = sdk.NewCoins(sdk.NewCoin("unexus", math.NewInt(1000000)))
 	DefaultMaxJobDuration         = 24 * time.Hour
+
+	// Floor for the base fee in unexus per gas (the node default min gas price)
+	DefaultMinBaseFee = math.LegacyNewDecWithPrec(1, 4)
+
+	// 100 NEX bonded per registered miner
+	DefaultMinMinerBond = math.NewInt(100_000_000)
+
+	// 35,950 NEX per minute in epoch 1
+	DefaultEmissionBaseRate = math.NewInt(35_950_000_000)
+	// Per-epoch rate in permille of the base rate: years 1-2, 3-4, ... 11-12
+	DefaultEmissionDecayPermille = []uint64{1000, 500, 250, 125, 62, 31}
+	// 75B NEX of mining emissions
+	DefaultEmissionSupplyCap = math.NewInt(75_000_000_000_000_000)
 )
 
 type Params struct {
@@ -32,6 +103,48 @@ type Params struct {
 	BackgroundEmissionRate math.Int      `protobuf:"bytes,7,opt,name=background_emission_rate,proto3,customtype=cosmossdk.io/math.Int" json:"background_emission_rate"`
 	MinJobReward           sdk.Coins     `protobuf:"bytes,8,rep,name=min_job_reward,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"min_job_reward"`
 	MaxJobDuration         time.Duration `protobuf:"varint,9,opt,name=max_job_duration,proto3,casttype=time.Duration" json:"max_job_duration"`
+	CollabEpochBlocks      int64         `protobuf:"varint,10,opt,name=collab_epoch_blocks,proto3" json:"collab_epoch_blocks"`
+	CollabEpochDuration    time.Duration `protobuf:"varint,11,opt,name=collab_epoch_duration,proto3,casttype=time.Duration" json:"collab_epoch_duration"`
+	WorkPoolPercent        uint64        `protobuf:"varint,12,opt,name=work_pool_percent,proto3" json:"work_pool_percent"`
+	ImprovementPoolPercent uint64        `protobuf:"varint,13,opt,name=improvement_pool_percent,proto3" json:"improvement_pool_percent"`
+	CollabValidatorPercent uint64        `protobuf:"varint,14,opt,name=collab_validator_percent,proto3" json:"collab_validator_percent"`
+	MaxSubmissionsPerEpoch uint64        `protobuf:"varint,15,opt,name=max_submissions_per_epoch,proto3" json:"max_submissions_per_epoch"`
+	MaxStepsPerEpoch       uint64        `protobuf:"varint,16,opt,name=max_steps_per_epoch,proto3" json:"max_steps_per_epoch"`
+	EmissionBaseRate       math.Int      `protobuf:"bytes,17,opt,name=emission_base_rate,proto3,customtype=cosmossdk.io/math.Int" json:"emission_base_rate"`
+	EmissionEpochMinutes   int64         `protobuf:"varint,18,opt,name=emission_epoch_minutes,proto3" json:"emission_epoch_minutes"`
+	EmissionDecayPermille  []uint64      `protobuf:"varint,19,rep,packed,name=emission_decay_permille,proto3" json:"emission_decay_permille"`
+	EmissionFloorPermille  uint64        `protobuf:"varint,20,opt,name=emission_floor_permille,proto3" json:"emission_floor_permille"`
+	EmissionSupplyCap      math.Int      `protobuf:"bytes,21,opt,name=emission_supply_cap,proto3,customtype=cosmossdk.io/math.Int" json:"emission_supply_cap"`
+	VestingPercent         uint64        `protobuf:"varint,22,opt,name=vesting_percent,proto3" json:"vesting_percent"`
+	VestingDuration        time.Duration `protobuf:"varint,23,opt,name=vesting_duration,proto3,casttype=time.Duration" json:"vesting_duration"`
+
+	// Emission split across workloads, relative to the sum of all weights
+	SyntheticEmissionWeight uint64 `protobuf:"varint,24,opt,name=synthetic_emission_weight,proto3" json:"synthetic_emission_weight"`
+	PublicEmissionWeight    uint64 `protobuf:"varint,25,opt,name=public_emission_weight,proto3" json:"public_emission_weight"`
+	DockingEmissionWeight   uint64 `protobuf:"varint,26,opt,name=docking_emission_weight,proto3" json:"docking_emission_weight"`
+	ProteinEmissionWeight   uint64 `protobuf:"varint,27,opt,name=protein_emission_weight,proto3" json:"protein_emission_weight"`
+
+	// Percent of job rewards and emission paid to the mining treasury
+	TreasurySharePercent uint64 `protobuf:"varint,28,opt,name=treasury_share_percent,proto3" json:"treasury_share_percent"`
+
+	// Per-message-type replacements for tx_fee_burn_percent
+	MsgFeeBurnOverrides []MsgFeeBurnOverride `protobuf:"bytes,29,rep,name=msg_fee_burn_overrides,proto3" json:"msg_fee_burn_overrides"`
+
+	// EIP-1559 base fee market; a zero target or denominator freezes the base fee
+	MinBaseFee               math.LegacyDec `protobuf:"bytes,30,opt,name=min_base_fee,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"min_base_fee"`
+	BaseFeeTargetGas         uint64         `protobuf:"varint,31,opt,name=base_fee_target_gas,proto3" json:"base_fee_target_gas"`
+	BaseFeeChangeDenominator uint64         `protobuf:"varint,32,opt,name=base_fee_change_denominator,proto3" json:"base_fee_change_denominator"`
+
+	// Miner registry: whether SubmitProof, SubmitWork and ClaimDockingJob need
+	// an active registration, the bond it takes and how long unbonding lasts
+	MinerRegistrationRequired bool          `protobuf:"varint,33,opt,name=miner_registration_required,proto3" json:"miner_registration_required"`
+	MinMinerBond              math.Int      `protobuf:"bytes,34,opt,name=min_miner_bond,proto3,customtype=cosmossdk.io/math.Int" json:"min_miner_bond"`
+	MinerUnbondingPeriod      time.Duration `protobuf:"varint,35,opt,name=miner_unbonding_period,proto3,casttype=time.Duration" json:"miner_unbonding_period"`
+
+	// Invariant checks in EndBlocker: every interval blocks (zero disables),
+	// failing the block on a broken invariant only when halt is set
+	InvariantCheckInterval int64 `protobuf:"varint,36,opt,name=invariant_check_interval,proto3" json:"invariant_check_interval"`
+	InvariantHalt          bool  `protobuf:"varint,37,opt,name=invariant_halt,proto3" json:"invariant_halt"`
 }
 
 func (p *Params) Reset()         { *p = Params{} }
@@ -49,6 +162,39 @@ func DefaultParams() Params {
 		BackgroundEmissionRate: DefaultBackgroundEmissionRate,
 		MinJobReward:           DefaultMinJobReward,
 		MaxJobDuration:         DefaultMaxJobDuration,
+		CollabEpochBlocks:      DefaultCollabEpochBlocks,
+		CollabEpochDuration:    DefaultCollabEpochDuration,
+		WorkPoolPercent:        DefaultWorkPoolPercent,
+		ImprovementPoolPercent: DefaultImprovementPoolPercent,
+		CollabValidatorPercent: DefaultCollabValidatorPercent,
+		MaxSubmissionsPerEpoch: DefaultMaxSubmissionsPerEpoch,
+		MaxStepsPerEpoch:       DefaultMaxStepsPerEpoch,
+		EmissionBaseRate:       DefaultEmissionBaseRate,
+		EmissionEpochMinutes:   DefaultEmissionEpochMinutes,
+		EmissionDecayPermille:  append([]uint64(nil), DefaultEmissionDecayPermille...),
+		EmissionFloorPermille:  DefaultEmissionFloorPermille,
+		EmissionSupplyCap:      DefaultEmissionSupplyCap,
+		VestingPercent:         DefaultVestingPercent,
+		VestingDuration:        DefaultVestingDuration,
+
+		SyntheticEmissionWeight: DefaultSyntheticEmissionWeight,
+		PublicEmissionWeight:    DefaultPublicEmissionWeight,
+		DockingEmissionWeight:   DefaultDockingEmissionWeight,
+		ProteinEmissionWeight:   DefaultProteinEmissionWeight,
+
+		TreasurySharePercent: DefaultTreasurySharePercent,
+		MsgFeeBurnOverrides:  []MsgFeeBurnOverride{},
+
+		MinBaseFee:               DefaultMinBaseFee,
+		BaseFeeTargetGas:         DefaultBaseFeeTargetGas,
+		BaseFeeChangeDenominator: DefaultBaseFeeChangeDenominator,
+
+		MinerRegistrationRequired: DefaultMinerRegistrationRequired,
+		MinMinerBond:              DefaultMinMinerBond,
+		MinerUnbondingPeriod:      DefaultMinerUnbondingPeriod,
+
+		InvariantCheckInterval: DefaultInvariantCheckInterval,
+		InvariantHalt:          DefaultInvariantHalt,
 	}
 }
 
@@ -56,5 +202,139 @@ func (p Params) Validate() error {
 	if p.MinerSharePercent+p.ValidatorSharePercent != 100 {
 		return ErrInvalidParams
 	}
+	// Zero disables a limit; both zero disables epoch advancement
+	if p.CollabEpochBlocks < 0 || p.CollabEpochDuration < 0 {
+		return ErrInvalidParams
+	}
+	if p.WorkPoolPercent+p.ImprovementPoolPercent+p.CollabValidatorPercent != 100 {
+		return ErrInvalidParams
+	}
+	// Vesting needs a schedule length once any share of rewards vests
+	if p.VestingPercent > 100 || p.VestingDuration < 0 || (p.VestingPercent > 0 && p.VestingDuration == 0) {
+		return ErrInvalidParams
+	}
+	// Some workload must receive emission
+	if p.TotalEmissionWeight() == 0 {
+		return ErrInvalidParams
+	}
+	if p.TreasurySharePercent > 100 {
+		return ErrInvalidParams
+	}
+	if p.JobFeeBurnPercent > 100 || p.TxFeeBurnPercent > 100 {
+		return ErrInvalidParams
+	}
+	overridden := make(map[string]bool, len(p.MsgFeeBurnOverrides))
+	for _, override := range p.MsgFeeBurnOverrides {
+		if override.MsgTypeUrl == "" || overridden[override.MsgTypeUrl] || override.BurnPercent > 100 {
+			return ErrInvalidParams
+		}
+		overridden[override.MsgTypeUrl] = true
+	}
+	if !p.MinBaseFee.IsNil() && p.MinBaseFee.IsNegative() {
+		return ErrInvalidParams
+	}
+	if (!p.MinMinerBond.IsNil() && p.MinMinerBond.IsNegative()) || p.MinerUnbondingPeriod < 0 {
+		return ErrInvalidParams
+	}
+	if p.InvariantCheckInterval < 0 {
+		return ErrInvalidParams
+	}
+	return p.validateEmissionSchedule()
+}
+
+// EmissionWeight returns a workload's emission weight, zero if unknown
+func (p Params) EmissionWeight(workload string) uint64 {
+	switch workload {
+	case WorkloadSynthetic:
+		return p.SyntheticEmissionWeight
+	case WorkloadPublic:
+		return p.PublicEmissionWeight
+	case WorkloadDocking:
+		return p.DockingEmissionWeight
+	case WorkloadProtein:
+		return p.ProteinEmissionWeight
+	}
+	return 0
+}
+
+// TotalEmissionWeight returns the sum of all workload weights
+func (p Params) TotalEmissionWeight() uint64 {
+	var total uint64
+	for _, workload := range EmissionWorkloads {
+		total += p.EmissionWeight(workload)
+	}
+	return total
+}
+
+// BaseFeeFloor returns the lowest base fee, zero if unset
+func (p Params) BaseFeeFloor() math.LegacyDec {
+	if p.MinBaseFee.IsNil() {
+		return math.LegacyZeroDec()
+	}
+	return p.MinBaseFee
+}
+
+// MinimumMinerBond returns the bond a registration needs, zero if unset
+func (p Params) MinimumMinerBond() math.Int {
+	if p.MinMinerBond.IsNil() {
+		return math.ZeroInt()
+	}
+	return p.MinMinerBond
+}
+
+// TxFeeBurnAmount returns how much of fee a transaction carrying the given
+// message types burns. The fee is shared equally among the messages and each
+// share burns its message's override or tx_fee_burn_percent, so bundling
+// messages neither raises nor lowers the burn of any of them.
+func (p Params) TxFeeBurnAmount(fee math.Int, msgTypeUrls []string) math.Int {
+	if len(msgTypeUrls) == 0 {
+		return fee.MulRaw(int64(p.TxFeeBurnPercent)).QuoRaw(100)
+	}
+	var total uint64
+	for _, typeUrl := range msgTypeUrls {
+		msgPercent := p.TxFeeBurnPercent
+		for _, override := range p.MsgFeeBurnOverrides {
+			if override.MsgTypeUrl == typeUrl {
+				msgPercent = override.BurnPercent
+				break
+			}
+		}
+		total += msgPercent
+	}
+	return fee.Mul(math.NewIntFromUint64(total)).QuoRaw(100 * int64(len(msgTypeUrls)))
+}
+
+// IsEmissionWorkload reports whether workload is a known workload
+func IsEmissionWorkload(workload string) bool {
+	for _, w := range EmissionWorkloads {
+		if w == workload {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEmissionSchedule checks the rate table only ever decays and ends
+// at or above the floor. A zero supply cap disables the cap.
+func (p Params) validateEmissionSchedule() error {
+	if p.EmissionBaseRate.IsNil() || p.EmissionBaseRate.IsNegative() {
+		return ErrInvalidParams
+	}
+	if p.EmissionSupplyCap.IsNil() || p.EmissionSupplyCap.IsNegative() {
+		return ErrInvalidParams
+	}
+	if p.EmissionEpochMinutes <= 0 || p.EmissionFloorPermille > 1000 {
+		return ErrInvalidParams
+	}
+	prev := uint64(1000)
+	for _, rate := range p.EmissionDecayPermille {
+		if rate > prev {
+			return ErrInvalidParams
+		}
+		prev = rate
+	}
+	if p.EmissionFloorPermille > prev {
+		return ErrInvalidParams
+	}
 	return nil
 }
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"strings"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
+// MaxPoolIdLength bounds mining pool IDs
+const MaxPoolIdLength = 64
+
+// MiningPool is an on-chain pool. Members submit work on its behalf, the
+// pool's address earns the shares, and each claim is split between the
+// operator's fee and the members by recorded contribution.
+type MiningPool struct {
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Operator    string   `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator"`
+	FeePercent  uint64   `protobuf:"varint,3,opt,name=fee_percent,json=feePercent,proto3" json:"fee_percent"`
+	Members     []string `protobuf:"bytes,4,rep,name=members,proto3" json:"members"`
+	OpenJoin    bool     `protobuf:"varint,5,opt,name=open_join,json=openJoin,proto3" json:"open_join"`
+	CreatedAt   int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	TotalEarned math.Int `protobuf:"bytes,7,opt,name=total_earned,json=totalEarned,proto3,customtype=cosmossdk.io/math.Int" json:"total_earned"`
+	TotalFees   math.Int `protobuf:"bytes,8,opt,name=total_fees,json=totalFees,proto3,customtype=cosmossdk.io/math.Int" json:"total_fees"`
+}
+
+func (m *MiningPool) Reset()         { *m = MiningPool{} }
+func (m *MiningPool) String() string { return m.Id }
+func (m *MiningPool) ProtoMessage()  {}
+
+// HasMember reports whether addr is a pool member
+func (m MiningPool) HasMember(addr string) bool {
+	for _, member := range m.Members {
+		if member == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Address returns the account the pool's shares are credited to
+func (m MiningPool) Address() sdk.AccAddress {
+	return PoolAddress(m.Id)
+}
+
+// PoolAddress derives a pool's share-holding account from its ID
+func PoolAddress(id string) sdk.AccAddress {
+	return sdk.AccAddress(address.Module(ModuleName, []byte("pool/"+id)))
+}
+
+// ValidPoolId reports whether id can be used as a pool ID; IDs are part of
+// "/"-separated store keys
+func ValidPoolId(id string) bool {
+	return len(id) > 0 && len(id) <= MaxPoolIdLength && !strings.Contains(id, "/")
+}
+
+// PoolContribution is one member's recorded work for a pool on one job:
+// steps on collaborative jobs, accepted submissions on competitive jobs
+type PoolContribution struct {
+	PoolId      string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id"`
+	JobId       string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Member      string `protobuf:"bytes,3,opt,name=member,proto3" json:"member"`
+	Steps       uint64 `protobuf:"varint,4,opt,name=steps,proto3" json:"steps"`
+	Submissions uint64 `protobuf:"varint,5,opt,name=submissions,proto3" json:"submissions"`
+}
+
+func (m *PoolContribution) Reset()         { *m = PoolContribution{} }
+func (m *PoolContribution) String() string { return m.Member }
+func (m *PoolContribution) ProtoMessage()  {}
+
+// Weight returns the contribution counted for a job's mining mode
+func (m PoolContribution) Weight(mode MiningMode) uint64 {
+	if mode == MiningModeCollaborative {
+		return m.Steps
+	}
+	return m.Submissions
+}
+
+// PoolContributionKey returns pool ID + "/" + job ID + "/" + member
+func PoolContributionKey(poolId, jobId, member string) []byte {
+	return []byte(poolId + "/" + jobId + "/" + member)
+}
+
+// PoolMemberEarnings is a member's cumulative payout from a pool
+type PoolMemberEarnings struct {
+	Member string   `protobuf:"bytes,1,opt,name=member,proto3" json:"member"`
+	Amount math.Int `protobuf:"bytes,2,opt,name=amount,proto3,customtype=cosmossdk.io/math.Int" json:"amount"`
+}
+
+func (m *PoolMemberEarnings) Reset()         { *m = PoolMemberEarnings{} }
+func (m *PoolMemberEarnings) String() string { return m.Member }
+func (m *PoolMemberEarnings) ProtoMessage()  {}
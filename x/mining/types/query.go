@@ -3,13 +3,19 @@ package types
 import (
 	"context"
 
+	"cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"google.golang.org/grpc"
 )
 
 type QueryParamsRequest struct{}
 
+func (m *QueryParamsRequest) Reset()         { *m = QueryParamsRequest{} }
+func (m *QueryParamsRequest) String() string { return "QueryParamsRequest" }
+func (m *QueryParamsRequest) ProtoMessage()  {}
+
 type QueryParamsResponse struct {
 	Params Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
 }
@@ -22,6 +28,10 @@ type QueryJobRequest struct {
 	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
 }
 
+func (m *QueryJobRequest) Reset()         { *m = QueryJobRequest{} }
+func (m *QueryJobRequest) String() string { return "QueryJobRequest" }
+func (m *QueryJobRequest) ProtoMessage()  {}
+
 type QueryJobResponse struct {
 	Job Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job"`
 }
@@ -34,6 +44,10 @@ type QueryJobsRequest struct {
 	Status uint32 `protobuf:"varint,1,opt,name=status,proto3" json:"status"`
 }
 
+func (m *QueryJobsRequest) Reset()         { *m = QueryJobsRequest{} }
+func (m *QueryJobsRequest) String() string { return "QueryJobsRequest" }
+func (m *QueryJobsRequest) ProtoMessage()  {}
+
 type QueryJobsResponse struct {
 	Jobs []Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs"`
 }
@@ -47,6 +61,10 @@ type QueryMinerSharesRequest struct {
 	JobId string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id"`
 }
 
+func (m *QueryMinerSharesRequest) Reset()         { *m = QueryMinerSharesRequest{} }
+func (m *QueryMinerSharesRequest) String() string { return "QueryMinerSharesRequest" }
+func (m *QueryMinerSharesRequest) ProtoMessage()  {}
+
 type QueryMinerSharesResponse struct {
 	Miner  string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner"`
 	JobId  string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id"`
@@ -61,6 +79,10 @@ type QueryMinerStatsRequest struct {
 	MinerAddress string `protobuf:"bytes,1,opt,name=miner_address,json=minerAddress,proto3" json:"miner_address"`
 }
 
+func (m *QueryMinerStatsRequest) Reset()         { *m = QueryMinerStatsRequest{} }
+func (m *QueryMinerStatsRequest) String() string { return "QueryMinerStatsRequest" }
+func (m *QueryMinerStatsRequest) ProtoMessage()  {}
+
 type QueryMinerStatsResponse struct {
 	MinerAddress     string         `protobuf:"bytes,1,opt,name=miner_address,json=minerAddress,proto3" json:"miner_address"`
 	TotalShares      int64          `protobuf:"varint,2,opt,name=total_shares,json=totalShares,proto3" json:"total_shares"`
@@ -87,10 +109,15 @@ func (m *MinerJobInfo) ProtoMessage()  {}
 
 type QueryActiveJobRequest struct{}
 
+func (m *QueryActiveJobRequest) Reset()         { *m = QueryActiveJobRequest{} }
+func (m *QueryActiveJobRequest) String() string { return "QueryActiveJobRequest" }
+func (m *QueryActiveJobRequest) ProtoMessage()  {}
+
 type QueryActiveJobResponse struct {
 	Job           *Job  `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	TimeRemaining int64 `protobuf:"varint,2,opt,name=time_remaining,json=timeRemaining,proto3" json:"time_remaining"`
 	ProblemSize   int64 `protobuf:"varint,3,opt,name=problem_size,json=problemSize,proto3" json:"problem_size"`
+	IsOpen        bool  `protobuf:"varint,4,opt,name=is_open,json=isOpen,proto3" json:"is_open"`
 }
 
 func (m *QueryActiveJobResponse) Reset()         { *m = QueryActiveJobResponse{} }
@@ -99,6 +126,10 @@ func (m *QueryActiveJobResponse) ProtoMessage()  {}
 
 type QueryQueueStatusRequest struct{}
 
+func (m *QueryQueueStatusRequest) Reset()         { *m = QueryQueueStatusRequest{} }
+func (m *QueryQueueStatusRequest) String() string { return "QueryQueueStatusRequest" }
+func (m *QueryQueueStatusRequest) ProtoMessage()  {}
+
 type QueryQueueStatusResponse struct {
 	PaidQueueLength   int64           `protobuf:"varint,1,opt,name=paid_queue_length,json=paidQueueLength,proto3" json:"paid_queue_length"`
 	PublicQueueLength int64           `protobuf:"varint,2,opt,name=public_queue_length,json=publicQueueLength,proto3" json:"public_queue_length"`
@@ -111,10 +142,10 @@ func (m *QueryQueueStatusResponse) String() string { return "QueryQueueStatusRes
 func (m *QueryQueueStatusResponse) ProtoMessage()  {}
 
 type QueuedJobInfo struct {
-	JobId       string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
-	Customer    string `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer"`
-	PriorityFee int64  `protobuf:"varint,3,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee"`
-	Reward      int64  `protobuf:"varint,4,opt,name=reward,proto3" json:"reward"`
+	JobId       string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Customer    string   `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer"`
+	PriorityFee math.Int `protobuf:"bytes,3,opt,name=priority_fee,json=priorityFee,proto3,customtype=cosmossdk.io/math.Int" json:"priority_fee"`
+	Reward      math.Int `protobuf:"bytes,4,opt,name=reward,proto3,customtype=cosmossdk.io/math.Int" json:"reward"`
 }
 
 func (q *QueuedJobInfo) Reset()         { *q = QueuedJobInfo{} }
@@ -123,15 +154,19 @@ func (q *QueuedJobInfo) ProtoMessage()  {}
 
 type QueryEmissionInfoRequest struct{}
 
+func (m *QueryEmissionInfoRequest) Reset()         { *m = QueryEmissionInfoRequest{} }
+func (m *QueryEmissionInfoRequest) String() string { return "QueryEmissionInfoRequest" }
+func (m *QueryEmissionInfoRequest) ProtoMessage()  {}
+
 type QueryEmissionInfoResponse struct {
-	CurrentEpoch     int32 `protobuf:"varint,1,opt,name=current_epoch,json=currentEpoch,proto3" json:"current_epoch"`
-	EmissionRate     int64 `protobuf:"varint,2,opt,name=emission_rate,json=emissionRate,proto3" json:"emission_rate"`
-	EmissionEscrow   int64 `protobuf:"varint,3,opt,name=emission_escrow,json=emissionEscrow,proto3" json:"emission_escrow"`
-	GenesisTime      int64 `protobuf:"varint,4,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time"`
-	MinutesIntoEpoch int64 `protobuf:"varint,5,opt,name=minutes_into_epoch,json=minutesIntoEpoch,proto3" json:"minutes_into_epoch"`
-	MinutesUntilNext int64 `protobuf:"varint,6,opt,name=minutes_until_next,json=minutesUntilNext,proto3" json:"minutes_until_next"`
-	EpochDuration    int64 `protobuf:"varint,7,opt,name=epoch_duration,json=epochDuration,proto3" json:"epoch_duration"`
-	NextEpochRate    int64 `protobuf:"varint,8,opt,name=next_epoch_rate,json=nextEpochRate,proto3" json:"next_epoch_rate"`
+	CurrentEpoch     int32    `protobuf:"varint,1,opt,name=current_epoch,json=currentEpoch,proto3" json:"current_epoch"`
+	EmissionRate     math.Int `protobuf:"bytes,2,opt,name=emission_rate,json=emissionRate,proto3,customtype=cosmossdk.io/math.Int" json:"emission_rate"`
+	EmissionEscrow   math.Int `protobuf:"bytes,3,opt,name=emission_escrow,json=emissionEscrow,proto3,customtype=cosmossdk.io/math.Int" json:"emission_escrow"`
+	GenesisTime      int64    `protobuf:"varint,4,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time"`
+	MinutesIntoEpoch int64    `protobuf:"varint,5,opt,name=minutes_into_epoch,json=minutesIntoEpoch,proto3" json:"minutes_into_epoch"`
+	MinutesUntilNext int64    `protobuf:"varint,6,opt,name=minutes_until_next,json=minutesUntilNext,proto3" json:"minutes_until_next"`
+	EpochDuration    int64    `protobuf:"varint,7,opt,name=epoch_duration,json=epochDuration,proto3" json:"epoch_duration"`
+	NextEpochRate    math.Int `protobuf:"bytes,8,opt,name=next_epoch_rate,json=nextEpochRate,proto3,customtype=cosmossdk.io/math.Int" json:"next_epoch_rate"`
 }
 
 func (m *QueryEmissionInfoResponse) Reset()         { *m = QueryEmissionInfoResponse{} }
@@ -142,6 +177,10 @@ type QueryValidatorMiningRecordRequest struct {
 	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator"`
 }
 
+func (m *QueryValidatorMiningRecordRequest) Reset()         { *m = QueryValidatorMiningRecordRequest{} }
+func (m *QueryValidatorMiningRecordRequest) String() string { return "QueryValidatorMiningRecordRequest" }
+func (m *QueryValidatorMiningRecordRequest) ProtoMessage()  {}
+
 type QueryValidatorMiningRecordResponse struct {
 	Record ValidatorMiningRecord `protobuf:"bytes,1,opt,name=record,proto3" json:"record"`
 }
@@ -156,6 +195,10 @@ type QueryCheckpointRequest struct {
 	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
 }
 
+func (m *QueryCheckpointRequest) Reset()         { *m = QueryCheckpointRequest{} }
+func (m *QueryCheckpointRequest) String() string { return "QueryCheckpointRequest" }
+func (m *QueryCheckpointRequest) ProtoMessage()  {}
+
 type QueryCheckpointResponse struct {
 	Checkpoint Checkpoint `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint"`
 }
@@ -166,6 +209,10 @@ func (m *QueryCheckpointResponse) ProtoMessage()  {}
 
 type QueryLatestCheckpointRequest struct{}
 
+func (m *QueryLatestCheckpointRequest) Reset()         { *m = QueryLatestCheckpointRequest{} }
+func (m *QueryLatestCheckpointRequest) String() string { return "QueryLatestCheckpointRequest" }
+func (m *QueryLatestCheckpointRequest) ProtoMessage()  {}
+
 type QueryLatestCheckpointResponse struct {
 	Checkpoint Checkpoint `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint"`
 }
@@ -174,6 +221,376 @@ func (m *QueryLatestCheckpointResponse) Reset()         { *m = QueryLatestCheckp
 func (m *QueryLatestCheckpointResponse) String() string { return "QueryLatestCheckpointResponse" }
 func (m *QueryLatestCheckpointResponse) ProtoMessage()  {}
 
+type QueryMinerGroupRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+}
+
+func (m *QueryMinerGroupRequest) Reset()         { *m = QueryMinerGroupRequest{} }
+func (m *QueryMinerGroupRequest) String() string { return "QueryMinerGroupRequest" }
+func (m *QueryMinerGroupRequest) ProtoMessage()  {}
+
+type QueryMinerGroupResponse struct {
+	Group MinerGroup `protobuf:"bytes,1,opt,name=group,proto3" json:"group"`
+}
+
+func (m *QueryMinerGroupResponse) Reset()         { *m = QueryMinerGroupResponse{} }
+func (m *QueryMinerGroupResponse) String() string { return "QueryMinerGroupResponse" }
+func (m *QueryMinerGroupResponse) ProtoMessage()  {}
+
+type QueryRandomnessRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height"`
+}
+
+func (m *QueryRandomnessRequest) Reset()         { *m = QueryRandomnessRequest{} }
+func (m *QueryRandomnessRequest) String() string { return "QueryRandomnessRequest" }
+func (m *QueryRandomnessRequest) ProtoMessage()  {}
+
+type QueryRandomnessResponse struct {
+	Randomness BlockRandomness `protobuf:"bytes,1,opt,name=randomness,proto3" json:"randomness"`
+}
+
+func (m *QueryRandomnessResponse) Reset()         { *m = QueryRandomnessResponse{} }
+func (m *QueryRandomnessResponse) String() string { return "QueryRandomnessResponse" }
+func (m *QueryRandomnessResponse) ProtoMessage()  {}
+
+type QueryMinerRewardBreakdownRequest struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Miner string `protobuf:"bytes,2,opt,name=miner,proto3" json:"miner"`
+}
+
+func (m *QueryMinerRewardBreakdownRequest) Reset()         { *m = QueryMinerRewardBreakdownRequest{} }
+func (m *QueryMinerRewardBreakdownRequest) String() string { return "QueryMinerRewardBreakdownRequest" }
+func (m *QueryMinerRewardBreakdownRequest) ProtoMessage()  {}
+
+// QueryMinerRewardBreakdownResponse shows what a miner would receive from
+// each collaborative pool if it claimed now
+type QueryMinerRewardBreakdownResponse struct {
+	JobId             string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Miner             string   `protobuf:"bytes,2,opt,name=miner,proto3" json:"miner"`
+	MinerSteps        int64    `protobuf:"varint,3,opt,name=miner_steps,json=minerSteps,proto3" json:"miner_steps"`
+	TotalSteps        int64    `protobuf:"varint,4,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps"`
+	MinerBonus        int64    `protobuf:"varint,5,opt,name=miner_bonus,json=minerBonus,proto3" json:"miner_bonus"`
+	TotalBonus        int64    `protobuf:"varint,6,opt,name=total_bonus,json=totalBonus,proto3" json:"total_bonus"`
+	WorkReward        math.Int `protobuf:"bytes,7,opt,name=work_reward,json=workReward,proto3,customtype=cosmossdk.io/math.Int" json:"work_reward"`
+	ImprovementReward math.Int `protobuf:"bytes,8,opt,name=improvement_reward,json=improvementReward,proto3,customtype=cosmossdk.io/math.Int" json:"improvement_reward"`
+	ValidatorShare    math.Int `protobuf:"bytes,9,opt,name=validator_share,json=validatorShare,proto3,customtype=cosmossdk.io/math.Int" json:"validator_share"`
+	TotalMinerReward  math.Int `protobuf:"bytes,10,opt,name=total_miner_reward,json=totalMinerReward,proto3,customtype=cosmossdk.io/math.Int" json:"total_miner_reward"`
+}
+
+func (m *QueryMinerRewardBreakdownResponse) Reset()         { *m = QueryMinerRewardBreakdownResponse{} }
+func (m *QueryMinerRewardBreakdownResponse) String() string { return "QueryMinerRewardBreakdownResponse" }
+func (m *QueryMinerRewardBreakdownResponse) ProtoMessage()  {}
+
+type QueryAlgorithmRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryAlgorithmRequest) Reset()         { *m = QueryAlgorithmRequest{} }
+func (m *QueryAlgorithmRequest) String() string { return "QueryAlgorithmRequest" }
+func (m *QueryAlgorithmRequest) ProtoMessage()  {}
+
+type QueryAlgorithmResponse struct {
+	Algorithm Algorithm `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm"`
+}
+
+func (m *QueryAlgorithmResponse) Reset()         { *m = QueryAlgorithmResponse{} }
+func (m *QueryAlgorithmResponse) String() string { return "QueryAlgorithmResponse" }
+func (m *QueryAlgorithmResponse) ProtoMessage()  {}
+
+type QueryAlgorithmsRequest struct {
+	ActiveOnly bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only"`
+}
+
+func (m *QueryAlgorithmsRequest) Reset()         { *m = QueryAlgorithmsRequest{} }
+func (m *QueryAlgorithmsRequest) String() string { return "QueryAlgorithmsRequest" }
+func (m *QueryAlgorithmsRequest) ProtoMessage()  {}
+
+type QueryAlgorithmsResponse struct {
+	Algorithms []Algorithm `protobuf:"bytes,1,rep,name=algorithms,proto3" json:"algorithms"`
+}
+
+func (m *QueryAlgorithmsResponse) Reset()         { *m = QueryAlgorithmsResponse{} }
+func (m *QueryAlgorithmsResponse) String() string { return "QueryAlgorithmsResponse" }
+func (m *QueryAlgorithmsResponse) ProtoMessage()  {}
+
+type QueryJobLandscapeRequest struct {
+	JobId           string             `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Pagination      *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	EpochPagination *query.PageRequest `protobuf:"bytes,3,opt,name=epoch_pagination,json=epochPagination,proto3" json:"epoch_pagination,omitempty"`
+}
+
+func (m *QueryJobLandscapeRequest) Reset()         { *m = QueryJobLandscapeRequest{} }
+func (m *QueryJobLandscapeRequest) String() string { return "QueryJobLandscapeRequest" }
+func (m *QueryJobLandscapeRequest) ProtoMessage()  {}
+
+// QueryJobLandscapeResponse is a job's solution landscape: distinct minima
+// (paginated, ordered by config hash) plus per-epoch energy statistics
+// (paginated separately, ordered by epoch)
+type QueryJobLandscapeResponse struct {
+	JobId           string              `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	TotalSteps      uint64              `protobuf:"varint,2,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps"`
+	DistinctMinima  int64               `protobuf:"varint,3,opt,name=distinct_minima,json=distinctMinima,proto3" json:"distinct_minima"`
+	Minima          []LandscapeMinimum  `protobuf:"bytes,4,rep,name=minima,proto3" json:"minima"`
+	Epochs          []EpochLandscape    `protobuf:"bytes,5,rep,name=epochs,proto3" json:"epochs"`
+	Pagination      *query.PageResponse `protobuf:"bytes,6,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	EpochPagination *query.PageResponse `protobuf:"bytes,7,opt,name=epoch_pagination,json=epochPagination,proto3" json:"epoch_pagination,omitempty"`
+}
+
+func (m *QueryJobLandscapeResponse) Reset()         { *m = QueryJobLandscapeResponse{} }
+func (m *QueryJobLandscapeResponse) String() string { return "QueryJobLandscapeResponse" }
+func (m *QueryJobLandscapeResponse) ProtoMessage()  {}
+
+// QuerySupplyProjectionRequest projects emission supply to HorizonMinutes
+// after the last emission (zero projects to the end of the decay table)
+type QuerySupplyProjectionRequest struct {
+	HorizonMinutes int64 `protobuf:"varint,1,opt,name=horizon_minutes,json=horizonMinutes,proto3" json:"horizon_minutes"`
+}
+
+func (m *QuerySupplyProjectionRequest) Reset()         { *m = QuerySupplyProjectionRequest{} }
+func (m *QuerySupplyProjectionRequest) String() string { return "QuerySupplyProjectionRequest" }
+func (m *QuerySupplyProjectionRequest) ProtoMessage()  {}
+
+// QuerySupplyProjectionResponse reports minted emission against the cap.
+// Minutes are counted from genesis; CapReachedMinute is -1 if the cap is
+// disabled or never reached.
+type QuerySupplyProjectionResponse struct {
+	MintedToDate     math.Int `protobuf:"bytes,1,opt,name=minted_to_date,json=mintedToDate,proto3,customtype=cosmossdk.io/math.Int" json:"minted_to_date"`
+	SupplyCap        math.Int `protobuf:"bytes,2,opt,name=supply_cap,json=supplyCap,proto3,customtype=cosmossdk.io/math.Int" json:"supply_cap"`
+	RemainingSupply  math.Int `protobuf:"bytes,3,opt,name=remaining_supply,json=remainingSupply,proto3,customtype=cosmossdk.io/math.Int" json:"remaining_supply"`
+	ProjectedTotal   math.Int `protobuf:"bytes,4,opt,name=projected_total,json=projectedTotal,proto3,customtype=cosmossdk.io/math.Int" json:"projected_total"`
+	ProjectedMinute  int64    `protobuf:"varint,5,opt,name=projected_minute,json=projectedMinute,proto3" json:"projected_minute"`
+	CurrentMinute    int64    `protobuf:"varint,6,opt,name=current_minute,json=currentMinute,proto3" json:"current_minute"`
+	CapReachedMinute int64    `protobuf:"varint,7,opt,name=cap_reached_minute,json=capReachedMinute,proto3" json:"cap_reached_minute"`
+}
+
+func (m *QuerySupplyProjectionResponse) Reset()         { *m = QuerySupplyProjectionResponse{} }
+func (m *QuerySupplyProjectionResponse) String() string { return "QuerySupplyProjectionResponse" }
+func (m *QuerySupplyProjectionResponse) ProtoMessage()  {}
+
+// QueryInvariantsRequest runs the module invariants against current state
+type QueryInvariantsRequest struct{}
+
+func (m *QueryInvariantsRequest) Reset()         { *m = QueryInvariantsRequest{} }
+func (m *QueryInvariantsRequest) String() string { return "QueryInvariantsRequest" }
+func (m *QueryInvariantsRequest) ProtoMessage()  {}
+
+// InvariantResult is the outcome of one registered invariant
+type InvariantResult struct {
+	Route   string `protobuf:"bytes,1,opt,name=route,proto3" json:"route"`
+	Broken  bool   `protobuf:"varint,2,opt,name=broken,proto3" json:"broken"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message"`
+}
+
+func (m *InvariantResult) Reset()         { *m = InvariantResult{} }
+func (m *InvariantResult) String() string { return m.Route }
+func (m *InvariantResult) ProtoMessage()  {}
+
+// QueryInvariantsResponse reports every invariant; Broken is set if any is
+type QueryInvariantsResponse struct {
+	Results []InvariantResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results"`
+	Broken  bool              `protobuf:"varint,2,opt,name=broken,proto3" json:"broken"`
+}
+
+func (m *QueryInvariantsResponse) Reset()         { *m = QueryInvariantsResponse{} }
+func (m *QueryInvariantsResponse) String() string { return "QueryInvariantsResponse" }
+func (m *QueryInvariantsResponse) ProtoMessage()  {}
+
+// QueryVestingGrantsRequest lists a miner's vesting grants
+type QueryVestingGrantsRequest struct {
+	Miner string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner"`
+}
+
+func (m *QueryVestingGrantsRequest) Reset()         { *m = QueryVestingGrantsRequest{} }
+func (m *QueryVestingGrantsRequest) String() string { return "QueryVestingGrantsRequest" }
+func (m *QueryVestingGrantsRequest) ProtoMessage()  {}
+
+// QueryVestingGrantsResponse carries the grants plus what is withdrawable
+// now and what is still locked
+type QueryVestingGrantsResponse struct {
+	Grants       []VestingGrant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants"`
+	Withdrawable math.Int       `protobuf:"bytes,2,opt,name=withdrawable,proto3,customtype=cosmossdk.io/math.Int" json:"withdrawable"`
+	Locked       math.Int       `protobuf:"bytes,3,opt,name=locked,proto3,customtype=cosmossdk.io/math.Int" json:"locked"`
+}
+
+func (m *QueryVestingGrantsResponse) Reset()         { *m = QueryVestingGrantsResponse{} }
+func (m *QueryVestingGrantsResponse) String() string { return "QueryVestingGrantsResponse" }
+func (m *QueryVestingGrantsResponse) ProtoMessage()  {}
+
+// QueryEmissionAllocationRequest reports how emission is split across workloads
+type QueryEmissionAllocationRequest struct{}
+
+func (m *QueryEmissionAllocationRequest) Reset()         { *m = QueryEmissionAllocationRequest{} }
+func (m *QueryEmissionAllocationRequest) String() string { return "QueryEmissionAllocationRequest" }
+func (m *QueryEmissionAllocationRequest) ProtoMessage()  {}
+
+// WorkloadAllocation is one workload's weight, per-minute budget at the
+// current emission rate and escrowed balance
+type WorkloadAllocation struct {
+	Workload string   `protobuf:"bytes,1,opt,name=workload,proto3" json:"workload"`
+	Weight   uint64   `protobuf:"varint,2,opt,name=weight,proto3" json:"weight"`
+	Rate     math.Int `protobuf:"bytes,3,opt,name=rate,proto3,customtype=cosmossdk.io/math.Int" json:"rate"`
+	Escrow   math.Int `protobuf:"bytes,4,opt,name=escrow,proto3,customtype=cosmossdk.io/math.Int" json:"escrow"`
+}
+
+func (m *WorkloadAllocation) Reset()         { *m = WorkloadAllocation{} }
+func (m *WorkloadAllocation) String() string { return m.Workload }
+func (m *WorkloadAllocation) ProtoMessage()  {}
+
+// QueryEmissionAllocationResponse lists every workload in allocation order.
+// Unallocated is emission escrowed before the split that is not yet assigned.
+type QueryEmissionAllocationResponse struct {
+	EmissionRate math.Int             `protobuf:"bytes,1,opt,name=emission_rate,json=emissionRate,proto3,customtype=cosmossdk.io/math.Int" json:"emission_rate"`
+	TotalWeight  uint64               `protobuf:"varint,2,opt,name=total_weight,json=totalWeight,proto3" json:"total_weight"`
+	Workloads    []WorkloadAllocation `protobuf:"bytes,3,rep,name=workloads,proto3" json:"workloads"`
+	Unallocated  math.Int             `protobuf:"bytes,4,opt,name=unallocated,proto3,customtype=cosmossdk.io/math.Int" json:"unallocated"`
+}
+
+func (m *QueryEmissionAllocationResponse) Reset() { *m = QueryEmissionAllocationResponse{} }
+func (m *QueryEmissionAllocationResponse) String() string {
+	return "QueryEmissionAllocationResponse"
+}
+func (m *QueryEmissionAllocationResponse) ProtoMessage() {}
+
+// QueryPoolRequest looks up a mining pool and its members
+type QueryPoolRequest struct {
+	PoolId string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id"`
+}
+
+func (m *QueryPoolRequest) Reset()         { *m = QueryPoolRequest{} }
+func (m *QueryPoolRequest) String() string { return "QueryPoolRequest" }
+func (m *QueryPoolRequest) ProtoMessage()  {}
+
+type QueryPoolResponse struct {
+	Pool    MiningPool `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool"`
+	Address string     `protobuf:"bytes,2,opt,name=address,proto3" json:"address"`
+}
+
+func (m *QueryPoolResponse) Reset()         { *m = QueryPoolResponse{} }
+func (m *QueryPoolResponse) String() string { return "QueryPoolResponse" }
+func (m *QueryPoolResponse) ProtoMessage()  {}
+
+// QueryPoolEarningsRequest reports a pool's claimed and outstanding earnings
+type QueryPoolEarningsRequest struct {
+	PoolId string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id"`
+}
+
+func (m *QueryPoolEarningsRequest) Reset()         { *m = QueryPoolEarningsRequest{} }
+func (m *QueryPoolEarningsRequest) String() string { return "QueryPoolEarningsRequest" }
+func (m *QueryPoolEarningsRequest) ProtoMessage()  {}
+
+// QueryPoolEarningsResponse reports what the pool has claimed in total, the
+// operator's fees, each member's cumulative payout, and settled payouts the
+// pool has not claimed yet
+type QueryPoolEarningsResponse struct {
+	TotalEarned math.Int             `protobuf:"bytes,1,opt,name=total_earned,json=totalEarned,proto3,customtype=cosmossdk.io/math.Int" json:"total_earned"`
+	TotalFees   math.Int             `protobuf:"bytes,2,opt,name=total_fees,json=totalFees,proto3,customtype=cosmossdk.io/math.Int" json:"total_fees"`
+	Members     []PoolMemberEarnings `protobuf:"bytes,3,rep,name=members,proto3" json:"members"`
+	Unclaimed   math.Int             `protobuf:"bytes,4,opt,name=unclaimed,proto3,customtype=cosmossdk.io/math.Int" json:"unclaimed"`
+}
+
+func (m *QueryPoolEarningsResponse) Reset()         { *m = QueryPoolEarningsResponse{} }
+func (m *QueryPoolEarningsResponse) String() string { return "QueryPoolEarningsResponse" }
+func (m *QueryPoolEarningsResponse) ProtoMessage()  {}
+
+type QueryTreasuryRequest struct{}
+
+func (m *QueryTreasuryRequest) Reset()         { *m = QueryTreasuryRequest{} }
+func (m *QueryTreasuryRequest) String() string { return "QueryTreasuryRequest" }
+func (m *QueryTreasuryRequest) ProtoMessage()  {}
+
+type QueryTreasuryResponse struct {
+	Address        string    `protobuf:"bytes,1,opt,name=address,proto3" json:"address"`
+	Balance        sdk.Coins `protobuf:"bytes,2,rep,name=balance,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"balance"`
+	SharePercent   uint64    `protobuf:"varint,3,opt,name=share_percent,json=sharePercent,proto3" json:"share_percent"`
+	TotalDeposited math.Int  `protobuf:"bytes,4,opt,name=total_deposited,json=totalDeposited,proto3,customtype=cosmossdk.io/math.Int" json:"total_deposited"`
+	TotalSpent     math.Int  `protobuf:"bytes,5,opt,name=total_spent,json=totalSpent,proto3,customtype=cosmossdk.io/math.Int" json:"total_spent"`
+}
+
+func (m *QueryTreasuryResponse) Reset()         { *m = QueryTreasuryResponse{} }
+func (m *QueryTreasuryResponse) String() string { return "QueryTreasuryResponse" }
+func (m *QueryTreasuryResponse) ProtoMessage()  {}
+
+type QueryTreasurySpendsRequest struct{}
+
+func (m *QueryTreasurySpendsRequest) Reset()         { *m = QueryTreasurySpendsRequest{} }
+func (m *QueryTreasurySpendsRequest) String() string { return "QueryTreasurySpendsRequest" }
+func (m *QueryTreasurySpendsRequest) ProtoMessage()  {}
+
+type QueryTreasurySpendsResponse struct {
+	Spends []TreasurySpend `protobuf:"bytes,1,rep,name=spends,proto3" json:"spends"`
+}
+
+func (m *QueryTreasurySpendsResponse) Reset()         { *m = QueryTreasurySpendsResponse{} }
+func (m *QueryTreasurySpendsResponse) String() string { return "QueryTreasurySpendsResponse" }
+func (m *QueryTreasurySpendsResponse) ProtoMessage()  {}
+
+type QueryBurnStatsRequest struct{}
+
+func (m *QueryBurnStatsRequest) Reset()         { *m = QueryBurnStatsRequest{} }
+func (m *QueryBurnStatsRequest) String() string { return "QueryBurnStatsRequest" }
+func (m *QueryBurnStatsRequest) ProtoMessage()  {}
+
+type QueryBurnStatsResponse struct {
+	Sources []BurnedAmount `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources"`
+	Total   math.Int       `protobuf:"bytes,2,opt,name=total,proto3,customtype=cosmossdk.io/math.Int" json:"total"`
+}
+
+func (m *QueryBurnStatsResponse) Reset()         { *m = QueryBurnStatsResponse{} }
+func (m *QueryBurnStatsResponse) String() string { return "QueryBurnStatsResponse" }
+func (m *QueryBurnStatsResponse) ProtoMessage()  {}
+
+type QueryBaseFeeRequest struct{}
+
+func (m *QueryBaseFeeRequest) Reset()         { *m = QueryBaseFeeRequest{} }
+func (m *QueryBaseFeeRequest) String() string { return "QueryBaseFeeRequest" }
+func (m *QueryBaseFeeRequest) ProtoMessage()  {}
+
+type QueryBaseFeeResponse struct {
+	BaseFee           math.LegacyDec `protobuf:"bytes,1,opt,name=base_fee,json=baseFee,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"base_fee"`
+	MinBaseFee        math.LegacyDec `protobuf:"bytes,2,opt,name=min_base_fee,json=minBaseFee,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"min_base_fee"`
+	TargetGas         uint64         `protobuf:"varint,3,opt,name=target_gas,json=targetGas,proto3" json:"target_gas"`
+	ChangeDenominator uint64         `protobuf:"varint,4,opt,name=change_denominator,json=changeDenominator,proto3" json:"change_denominator"`
+}
+
+func (m *QueryBaseFeeResponse) Reset()         { *m = QueryBaseFeeResponse{} }
+func (m *QueryBaseFeeResponse) String() string { return "QueryBaseFeeResponse" }
+func (m *QueryBaseFeeResponse) ProtoMessage()  {}
+
+type QueryMinerRegistrationRequest struct {
+	Miner string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner"`
+}
+
+func (m *QueryMinerRegistrationRequest) Reset()         { *m = QueryMinerRegistrationRequest{} }
+func (m *QueryMinerRegistrationRequest) String() string { return "QueryMinerRegistrationRequest" }
+func (m *QueryMinerRegistrationRequest) ProtoMessage()  {}
+
+type QueryMinerRegistrationResponse struct {
+	Registration MinerRegistration `protobuf:"bytes,1,opt,name=registration,proto3" json:"registration"`
+}
+
+func (m *QueryMinerRegistrationResponse) Reset()         { *m = QueryMinerRegistrationResponse{} }
+func (m *QueryMinerRegistrationResponse) String() string { return "QueryMinerRegistrationResponse" }
+func (m *QueryMinerRegistrationResponse) ProtoMessage()  {}
+
+// QueryMinerRegistryRequest lists registered miners, optionally only active ones
+type QueryMinerRegistryRequest struct {
+	ActiveOnly bool               `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryMinerRegistryRequest) Reset()         { *m = QueryMinerRegistryRequest{} }
+func (m *QueryMinerRegistryRequest) String() string { return "QueryMinerRegistryRequest" }
+func (m *QueryMinerRegistryRequest) ProtoMessage()  {}
+
+type QueryMinerRegistryResponse struct {
+	Miners     []MinerRegistration `protobuf:"bytes,1,rep,name=miners,proto3" json:"miners"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryMinerRegistryResponse) Reset()         { *m = QueryMinerRegistryResponse{} }
+func (m *QueryMinerRegistryResponse) String() string { return "QueryMinerRegistryResponse" }
+func (m *QueryMinerRegistryResponse) ProtoMessage()  {}
+
 type MsgPostJobResponse struct {
 	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	QueuePosition int64  `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
@@ -192,8 +609,11 @@ func (m *MsgSubmitProofResponse) Reset()         { *m = MsgSubmitProofResponse{}
 func (m *MsgSubmitProofResponse) String() string { return "MsgSubmitProofResponse" }
 func (m *MsgSubmitProofResponse) ProtoMessage()  {}
 
+// MsgClaimRewardsResponse: Amount is the full payout, Vesting the part of
+// it locked in a vesting grant rather than transferred
 type MsgClaimRewardsResponse struct {
-	Amount sdk.Coins `protobuf:"bytes,1,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	Amount  sdk.Coins `protobuf:"bytes,1,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	Vesting sdk.Coins `protobuf:"bytes,2,rep,name=vesting,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"vesting"`
 }
 
 func (m *MsgClaimRewardsResponse) Reset()         { *m = MsgClaimRewardsResponse{} }
@@ -240,6 +660,24 @@ var QueryServiceDesc = grpc.ServiceDesc{
 		{MethodName: "ValidatorMiningRecord", Handler: _Query_ValidatorMiningRecord_Handler},
 		{MethodName: "Checkpoint", Handler: _Query_Checkpoint_Handler},
 		{MethodName: "LatestCheckpoint", Handler: _Query_LatestCheckpoint_Handler},
+		{MethodName: "MinerGroup", Handler: _Query_MinerGroup_Handler},
+		{MethodName: "Randomness", Handler: _Query_Randomness_Handler},
+		{MethodName: "MinerRewardBreakdown", Handler: _Query_MinerRewardBreakdown_Handler},
+		{MethodName: "Algorithm", Handler: _Query_Algorithm_Handler},
+		{MethodName: "Algorithms", Handler: _Query_Algorithms_Handler},
+		{MethodName: "JobLandscape", Handler: _Query_JobLandscape_Handler},
+		{MethodName: "SupplyProjection", Handler: _Query_SupplyProjection_Handler},
+		{MethodName: "Invariants", Handler: _Query_Invariants_Handler},
+		{MethodName: "VestingGrants", Handler: _Query_VestingGrants_Handler},
+		{MethodName: "EmissionAllocation", Handler: _Query_EmissionAllocation_Handler},
+		{MethodName: "Pool", Handler: _Query_Pool_Handler},
+		{MethodName: "PoolEarnings", Handler: _Query_PoolEarnings_Handler},
+		{MethodName: "Treasury", Handler: _Query_Treasury_Handler},
+		{MethodName: "TreasurySpends", Handler: _Query_TreasurySpends_Handler},
+		{MethodName: "BurnStats", Handler: _Query_BurnStats_Handler},
+		{MethodName: "BaseFee", Handler: _Query_BaseFee_Handler},
+		{MethodName: "MinerRegistration", Handler: _Query_MinerRegistration_Handler},
+		{MethodName: "MinerRegistry", Handler: _Query_MinerRegistry_Handler},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nexus/mining/v1/query.proto",
@@ -255,6 +693,24 @@ var MsgServiceDesc = grpc.ServiceDesc{
 		{MethodName: "ClaimRewards", Handler: _Msg_ClaimRewards_Handler},
 		{MethodName: "CancelJob", Handler: _Msg_CancelJob_Handler},
 		{MethodName: "SubmitPublicJob", Handler: _Msg_SubmitPublicJob_Handler},
+		{MethodName: "ExtendJob", Handler: _Msg_ExtendJob_Handler},
+		{MethodName: "SetMinerGroup", Handler: _Msg_SetMinerGroup_Handler},
+		{MethodName: "CommitRandomness", Handler: _Msg_CommitRandomness_Handler},
+		{MethodName: "RevealRandomness", Handler: _Msg_RevealRandomness_Handler},
+		{MethodName: "SetAlgorithm", Handler: _Msg_SetAlgorithm_Handler},
+		{MethodName: "UpdateParams", Handler: _Msg_UpdateParams_Handler},
+		{MethodName: "ClaimAllRewards", Handler: _Msg_ClaimAllRewards_Handler},
+		{MethodName: "WithdrawVested", Handler: _Msg_WithdrawVested_Handler},
+		{MethodName: "SlashMiner", Handler: _Msg_SlashMiner_Handler},
+		{MethodName: "LinkValidator", Handler: _Msg_LinkValidator_Handler},
+		{MethodName: "AcceptMiner", Handler: _Msg_AcceptMiner_Handler},
+		{MethodName: "CreatePool", Handler: _Msg_CreatePool_Handler},
+		{MethodName: "JoinPool", Handler: _Msg_JoinPool_Handler},
+		{MethodName: "LeavePool", Handler: _Msg_LeavePool_Handler},
+		{MethodName: "ClaimPoolRewards", Handler: _Msg_ClaimPoolRewards_Handler},
+		{MethodName: "TreasurySpend", Handler: _Msg_TreasurySpend_Handler},
+		{MethodName: "RegisterMiner", Handler: _Msg_RegisterMiner_Handler},
+		{MethodName: "UnbondMiner", Handler: _Msg_UnbondMiner_Handler},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nexus/mining/v1/tx.proto",
@@ -414,191 +870,749 @@ func _Query_LatestCheckpoint_Handler(srv interface{}, ctx context.Context, dec f
 	})
 }
 
-func _Msg_SubmitWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgSubmitWork)
+func _Query_MinerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMinerGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).SubmitWork(ctx, in)
+		return srv.(QueryServer).MinerGroup(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitWork"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/MinerGroup"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).SubmitWork(ctx, req.(*MsgSubmitWork))
+		return srv.(QueryServer).MinerGroup(ctx, req.(*QueryMinerGroupRequest))
 	})
 }
 
-func _Msg_PostJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgPostJob)
+func _Query_Randomness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRandomnessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).PostJob(ctx, in)
+		return srv.(QueryServer).Randomness(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/PostJob"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Randomness"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).PostJob(ctx, req.(*MsgPostJob))
+		return srv.(QueryServer).Randomness(ctx, req.(*QueryRandomnessRequest))
 	})
 }
 
-func _Msg_SubmitProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgSubmitProof)
+func _Query_MinerRewardBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMinerRewardBreakdownRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).SubmitProof(ctx, in)
+		return srv.(QueryServer).MinerRewardBreakdown(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitProof"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/MinerRewardBreakdown"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).SubmitProof(ctx, req.(*MsgSubmitProof))
+		return srv.(QueryServer).MinerRewardBreakdown(ctx, req.(*QueryMinerRewardBreakdownRequest))
 	})
 }
 
-func _Msg_ClaimRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgClaimRewards)
+func _Query_Algorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAlgorithmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).ClaimRewards(ctx, in)
+		return srv.(QueryServer).Algorithm(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/ClaimRewards"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Algorithm"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).ClaimRewards(ctx, req.(*MsgClaimRewards))
+		return srv.(QueryServer).Algorithm(ctx, req.(*QueryAlgorithmRequest))
 	})
 }
 
-func _Msg_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgCancelJob)
+func _Query_Algorithms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAlgorithmsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).CancelJob(ctx, in)
+		return srv.(QueryServer).Algorithms(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/CancelJob"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Algorithms"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).CancelJob(ctx, req.(*MsgCancelJob))
+		return srv.(QueryServer).Algorithms(ctx, req.(*QueryAlgorithmsRequest))
 	})
 }
 
-func _Msg_SubmitPublicJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgSubmitPublicJob)
+func _Query_JobLandscape_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryJobLandscapeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).SubmitPublicJob(ctx, in)
+		return srv.(QueryServer).JobLandscape(ctx, in)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitPublicJob"}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/JobLandscape"}
 	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).SubmitPublicJob(ctx, req.(*MsgSubmitPublicJob))
+		return srv.(QueryServer).JobLandscape(ctx, req.(*QueryJobLandscapeRequest))
 	})
 }
 
-type MsgServer interface {
-	SubmitWork(context.Context, *MsgSubmitWork) (*MsgSubmitWorkResponse, error)
-	PostJob(context.Context, *MsgPostJob) (*MsgPostJobResponse, error)
-	SubmitProof(context.Context, *MsgSubmitProof) (*MsgSubmitProofResponse, error)
-	ClaimRewards(context.Context, *MsgClaimRewards) (*MsgClaimRewardsResponse, error)
-	CancelJob(context.Context, *MsgCancelJob) (*MsgCancelJobResponse, error)
-	SubmitPublicJob(context.Context, *MsgSubmitPublicJob) (*MsgSubmitPublicJobResponse, error)
-}
-
-type QueryServer interface {
-	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
-	Job(context.Context, *QueryJobRequest) (*QueryJobResponse, error)
-	Jobs(context.Context, *QueryJobsRequest) (*QueryJobsResponse, error)
-	MinerShares(context.Context, *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error)
-	MinerStats(context.Context, *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error)
-	ActiveJob(context.Context, *QueryActiveJobRequest) (*QueryActiveJobResponse, error)
-	QueueStatus(context.Context, *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error)
-	EmissionInfo(context.Context, *QueryEmissionInfoRequest) (*QueryEmissionInfoResponse, error)
-	ValidatorMiningRecord(context.Context, *QueryValidatorMiningRecordRequest) (*QueryValidatorMiningRecordResponse, error)
-	Checkpoint(context.Context, *QueryCheckpointRequest) (*QueryCheckpointResponse, error)
-	LatestCheckpoint(context.Context, *QueryLatestCheckpointRequest) (*QueryLatestCheckpointResponse, error)
-}
-
-func NewQueryClient(clientCtx client.Context) QueryClient {
-	return &queryClient{clientCtx: clientCtx}
-}
-
-type QueryClient interface {
-	Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error)
-	Job(ctx context.Context, req *QueryJobRequest) (*QueryJobResponse, error)
-	Jobs(ctx context.Context, req *QueryJobsRequest) (*QueryJobsResponse, error)
-	MinerShares(ctx context.Context, req *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error)
-	MinerStats(ctx context.Context, req *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error)
-	ActiveJob(ctx context.Context, req *QueryActiveJobRequest) (*QueryActiveJobResponse, error)
-	QueueStatus(ctx context.Context, req *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error)
-	EmissionInfo(ctx context.Context, req *QueryEmissionInfoRequest) (*QueryEmissionInfoResponse, error)
-	ValidatorMiningRecord(ctx context.Context, req *QueryValidatorMiningRecordRequest) (*QueryValidatorMiningRecordResponse, error)
-	Checkpoint(ctx context.Context, req *QueryCheckpointRequest) (*QueryCheckpointResponse, error)
-	LatestCheckpoint(ctx context.Context, req *QueryLatestCheckpointRequest) (*QueryLatestCheckpointResponse, error)
-}
-
-type queryClient struct {
-	clientCtx client.Context
-}
-
-func (q *queryClient) Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error) {
-	out := new(QueryParamsResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Params", req, out)
-	if err != nil {
+func _Query_SupplyProjection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySupplyProjectionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).SupplyProjection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/SupplyProjection"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SupplyProjection(ctx, req.(*QuerySupplyProjectionRequest))
+	})
 }
 
-func (q *queryClient) Job(ctx context.Context, req *QueryJobRequest) (*QueryJobResponse, error) {
-	out := new(QueryJobResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Job", req, out)
-	if err != nil {
+func _Query_Invariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryInvariantsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).Invariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Invariants"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Invariants(ctx, req.(*QueryInvariantsRequest))
+	})
 }
 
-func (q *queryClient) Jobs(ctx context.Context, req *QueryJobsRequest) (*QueryJobsResponse, error) {
-	out := new(QueryJobsResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Jobs", req, out)
-	if err != nil {
+func _Query_VestingGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVestingGrantsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).VestingGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/VestingGrants"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).VestingGrants(ctx, req.(*QueryVestingGrantsRequest))
+	})
 }
 
-func (q *queryClient) MinerShares(ctx context.Context, req *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error) {
-	out := new(QueryMinerSharesResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerShares", req, out)
-	if err != nil {
+func _Query_EmissionAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryEmissionAllocationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).EmissionAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/EmissionAllocation"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).EmissionAllocation(ctx, req.(*QueryEmissionAllocationRequest))
+	})
 }
 
-func (q *queryClient) MinerStats(ctx context.Context, req *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error) {
-	out := new(QueryMinerStatsResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerStats", req, out)
-	if err != nil {
+func _Query_Pool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPoolRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).Pool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Pool"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Pool(ctx, req.(*QueryPoolRequest))
+	})
 }
 
-func (q *queryClient) ActiveJob(ctx context.Context, req *QueryActiveJobRequest) (*QueryActiveJobResponse, error) {
-	out := new(QueryActiveJobResponse)
-	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/ActiveJob", req, out)
-	if err != nil {
+func _Query_PoolEarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPoolEarningsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(QueryServer).PoolEarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/PoolEarnings"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PoolEarnings(ctx, req.(*QueryPoolEarningsRequest))
+	})
 }
 
-func (q *queryClient) QueueStatus(ctx context.Context, req *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error) {
-	out := new(QueryQueueStatusResponse)
+func _Query_Treasury_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTreasuryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Treasury(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/Treasury"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Treasury(ctx, req.(*QueryTreasuryRequest))
+	})
+}
+
+func _Query_TreasurySpends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTreasurySpendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).TreasurySpends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/TreasurySpends"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).TreasurySpends(ctx, req.(*QueryTreasurySpendsRequest))
+	})
+}
+
+func _Query_BurnStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryBurnStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).BurnStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/BurnStats"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).BurnStats(ctx, req.(*QueryBurnStatsRequest))
+	})
+}
+
+func _Query_BaseFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryBaseFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).BaseFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/BaseFee"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).BaseFee(ctx, req.(*QueryBaseFeeRequest))
+	})
+}
+
+func _Query_MinerRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMinerRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).MinerRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/MinerRegistration"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).MinerRegistration(ctx, req.(*QueryMinerRegistrationRequest))
+	})
+}
+
+func _Query_MinerRegistry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMinerRegistryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).MinerRegistry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Query/MinerRegistry"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).MinerRegistry(ctx, req.(*QueryMinerRegistryRequest))
+	})
+}
+
+func _Msg_SubmitWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSubmitWork)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SubmitWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitWork"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SubmitWork(ctx, req.(*MsgSubmitWork))
+	})
+}
+
+func _Msg_PostJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgPostJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).PostJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/PostJob"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).PostJob(ctx, req.(*MsgPostJob))
+	})
+}
+
+func _Msg_SubmitProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSubmitProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SubmitProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitProof"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SubmitProof(ctx, req.(*MsgSubmitProof))
+	})
+}
+
+func _Msg_ClaimRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgClaimRewards)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ClaimRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/ClaimRewards"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ClaimRewards(ctx, req.(*MsgClaimRewards))
+	})
+}
+
+func _Msg_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCancelJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/CancelJob"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CancelJob(ctx, req.(*MsgCancelJob))
+	})
+}
+
+func _Msg_SubmitPublicJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSubmitPublicJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SubmitPublicJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SubmitPublicJob"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SubmitPublicJob(ctx, req.(*MsgSubmitPublicJob))
+	})
+}
+
+func _Msg_ExtendJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgExtendJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ExtendJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/ExtendJob"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ExtendJob(ctx, req.(*MsgExtendJob))
+	})
+}
+
+func _Msg_SetMinerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetMinerGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetMinerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SetMinerGroup"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetMinerGroup(ctx, req.(*MsgSetMinerGroup))
+	})
+}
+
+func _Msg_CommitRandomness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCommitRandomness)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CommitRandomness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/CommitRandomness"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CommitRandomness(ctx, req.(*MsgCommitRandomness))
+	})
+}
+
+func _Msg_RevealRandomness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevealRandomness)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevealRandomness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/RevealRandomness"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevealRandomness(ctx, req.(*MsgRevealRandomness))
+	})
+}
+
+func _Msg_SetAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetAlgorithm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SetAlgorithm"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetAlgorithm(ctx, req.(*MsgSetAlgorithm))
+	})
+}
+
+func _Msg_UpdateParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUpdateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UpdateParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/UpdateParams"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UpdateParams(ctx, req.(*MsgUpdateParams))
+	})
+}
+
+func _Msg_ClaimAllRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgClaimAllRewards)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ClaimAllRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/ClaimAllRewards"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ClaimAllRewards(ctx, req.(*MsgClaimAllRewards))
+	})
+}
+
+func _Msg_WithdrawVested_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgWithdrawVested)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).WithdrawVested(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/WithdrawVested"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).WithdrawVested(ctx, req.(*MsgWithdrawVested))
+	})
+}
+
+func _Msg_SlashMiner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSlashMiner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SlashMiner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/SlashMiner"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SlashMiner(ctx, req.(*MsgSlashMiner))
+	})
+}
+
+func _Msg_LinkValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgLinkValidator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).LinkValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/LinkValidator"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).LinkValidator(ctx, req.(*MsgLinkValidator))
+	})
+}
+
+func _Msg_AcceptMiner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgAcceptMiner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).AcceptMiner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/AcceptMiner"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).AcceptMiner(ctx, req.(*MsgAcceptMiner))
+	})
+}
+
+func _Msg_CreatePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreatePool)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreatePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/CreatePool"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreatePool(ctx, req.(*MsgCreatePool))
+	})
+}
+
+func _Msg_JoinPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgJoinPool)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).JoinPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/JoinPool"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).JoinPool(ctx, req.(*MsgJoinPool))
+	})
+}
+
+func _Msg_LeavePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgLeavePool)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).LeavePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/LeavePool"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).LeavePool(ctx, req.(*MsgLeavePool))
+	})
+}
+
+func _Msg_ClaimPoolRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgClaimPoolRewards)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ClaimPoolRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/ClaimPoolRewards"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ClaimPoolRewards(ctx, req.(*MsgClaimPoolRewards))
+	})
+}
+
+func _Msg_TreasurySpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgTreasurySpend)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).TreasurySpend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/TreasurySpend"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).TreasurySpend(ctx, req.(*MsgTreasurySpend))
+	})
+}
+
+func _Msg_RegisterMiner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRegisterMiner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RegisterMiner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/RegisterMiner"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterMiner(ctx, req.(*MsgRegisterMiner))
+	})
+}
+
+func _Msg_UnbondMiner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUnbondMiner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UnbondMiner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/nexus.mining.v1.Msg/UnbondMiner"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UnbondMiner(ctx, req.(*MsgUnbondMiner))
+	})
+}
+
+type MsgServer interface {
+	SubmitWork(context.Context, *MsgSubmitWork) (*MsgSubmitWorkResponse, error)
+	PostJob(context.Context, *MsgPostJob) (*MsgPostJobResponse, error)
+	SubmitProof(context.Context, *MsgSubmitProof) (*MsgSubmitProofResponse, error)
+	ClaimRewards(context.Context, *MsgClaimRewards) (*MsgClaimRewardsResponse, error)
+	CancelJob(context.Context, *MsgCancelJob) (*MsgCancelJobResponse, error)
+	SubmitPublicJob(context.Context, *MsgSubmitPublicJob) (*MsgSubmitPublicJobResponse, error)
+	ExtendJob(context.Context, *MsgExtendJob) (*MsgExtendJobResponse, error)
+	SetMinerGroup(context.Context, *MsgSetMinerGroup) (*MsgSetMinerGroupResponse, error)
+	CommitRandomness(context.Context, *MsgCommitRandomness) (*MsgCommitRandomnessResponse, error)
+	RevealRandomness(context.Context, *MsgRevealRandomness) (*MsgRevealRandomnessResponse, error)
+	SetAlgorithm(context.Context, *MsgSetAlgorithm) (*MsgSetAlgorithmResponse, error)
+	UpdateParams(context.Context, *MsgUpdateParams) (*MsgUpdateParamsResponse, error)
+	ClaimAllRewards(context.Context, *MsgClaimAllRewards) (*MsgClaimAllRewardsResponse, error)
+	WithdrawVested(context.Context, *MsgWithdrawVested) (*MsgWithdrawVestedResponse, error)
+	SlashMiner(context.Context, *MsgSlashMiner) (*MsgSlashMinerResponse, error)
+	LinkValidator(context.Context, *MsgLinkValidator) (*MsgLinkValidatorResponse, error)
+	AcceptMiner(context.Context, *MsgAcceptMiner) (*MsgAcceptMinerResponse, error)
+	CreatePool(context.Context, *MsgCreatePool) (*MsgCreatePoolResponse, error)
+	JoinPool(context.Context, *MsgJoinPool) (*MsgJoinPoolResponse, error)
+	LeavePool(context.Context, *MsgLeavePool) (*MsgLeavePoolResponse, error)
+	ClaimPoolRewards(context.Context, *MsgClaimPoolRewards) (*MsgClaimPoolRewardsResponse, error)
+	TreasurySpend(context.Context, *MsgTreasurySpend) (*MsgTreasurySpendResponse, error)
+	RegisterMiner(context.Context, *MsgRegisterMiner) (*MsgRegisterMinerResponse, error)
+	UnbondMiner(context.Context, *MsgUnbondMiner) (*MsgUnbondMinerResponse, error)
+}
+
+type QueryServer interface {
+	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
+	Job(context.Context, *QueryJobRequest) (*QueryJobResponse, error)
+	Jobs(context.Context, *QueryJobsRequest) (*QueryJobsResponse, error)
+	MinerShares(context.Context, *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error)
+	MinerStats(context.Context, *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error)
+	ActiveJob(context.Context, *QueryActiveJobRequest) (*QueryActiveJobResponse, error)
+	QueueStatus(context.Context, *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error)
+	EmissionInfo(context.Context, *QueryEmissionInfoRequest) (*QueryEmissionInfoResponse, error)
+	ValidatorMiningRecord(context.Context, *QueryValidatorMiningRecordRequest) (*QueryValidatorMiningRecordResponse, error)
+	Checkpoint(context.Context, *QueryCheckpointRequest) (*QueryCheckpointResponse, error)
+	LatestCheckpoint(context.Context, *QueryLatestCheckpointRequest) (*QueryLatestCheckpointResponse, error)
+	MinerGroup(context.Context, *QueryMinerGroupRequest) (*QueryMinerGroupResponse, error)
+	Randomness(context.Context, *QueryRandomnessRequest) (*QueryRandomnessResponse, error)
+	MinerRewardBreakdown(context.Context, *QueryMinerRewardBreakdownRequest) (*QueryMinerRewardBreakdownResponse, error)
+	Algorithm(context.Context, *QueryAlgorithmRequest) (*QueryAlgorithmResponse, error)
+	Algorithms(context.Context, *QueryAlgorithmsRequest) (*QueryAlgorithmsResponse, error)
+	JobLandscape(context.Context, *QueryJobLandscapeRequest) (*QueryJobLandscapeResponse, error)
+	SupplyProjection(context.Context, *QuerySupplyProjectionRequest) (*QuerySupplyProjectionResponse, error)
+	Invariants(context.Context, *QueryInvariantsRequest) (*QueryInvariantsResponse, error)
+	VestingGrants(context.Context, *QueryVestingGrantsRequest) (*QueryVestingGrantsResponse, error)
+	EmissionAllocation(context.Context, *QueryEmissionAllocationRequest) (*QueryEmissionAllocationResponse, error)
+	Pool(context.Context, *QueryPoolRequest) (*QueryPoolResponse, error)
+	PoolEarnings(context.Context, *QueryPoolEarningsRequest) (*QueryPoolEarningsResponse, error)
+	Treasury(context.Context, *QueryTreasuryRequest) (*QueryTreasuryResponse, error)
+	TreasurySpends(context.Context, *QueryTreasurySpendsRequest) (*QueryTreasurySpendsResponse, error)
+	BurnStats(context.Context, *QueryBurnStatsRequest) (*QueryBurnStatsResponse, error)
+	BaseFee(context.Context, *QueryBaseFeeRequest) (*QueryBaseFeeResponse, error)
+	MinerRegistration(context.Context, *QueryMinerRegistrationRequest) (*QueryMinerRegistrationResponse, error)
+	MinerRegistry(context.Context, *QueryMinerRegistryRequest) (*QueryMinerRegistryResponse, error)
+}
+
+func NewQueryClient(clientCtx client.Context) QueryClient {
+	return &queryClient{clientCtx: clientCtx}
+}
+
+type QueryClient interface {
+	Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error)
+	Job(ctx context.Context, req *QueryJobRequest) (*QueryJobResponse, error)
+	Jobs(ctx context.Context, req *QueryJobsRequest) (*QueryJobsResponse, error)
+	MinerShares(ctx context.Context, req *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error)
+	MinerStats(ctx context.Context, req *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error)
+	ActiveJob(ctx context.Context, req *QueryActiveJobRequest) (*QueryActiveJobResponse, error)
+	QueueStatus(ctx context.Context, req *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error)
+	EmissionInfo(ctx context.Context, req *QueryEmissionInfoRequest) (*QueryEmissionInfoResponse, error)
+	ValidatorMiningRecord(ctx context.Context, req *QueryValidatorMiningRecordRequest) (*QueryValidatorMiningRecordResponse, error)
+	Checkpoint(ctx context.Context, req *QueryCheckpointRequest) (*QueryCheckpointResponse, error)
+	LatestCheckpoint(ctx context.Context, req *QueryLatestCheckpointRequest) (*QueryLatestCheckpointResponse, error)
+	MinerGroup(ctx context.Context, req *QueryMinerGroupRequest) (*QueryMinerGroupResponse, error)
+	Randomness(ctx context.Context, req *QueryRandomnessRequest) (*QueryRandomnessResponse, error)
+	MinerRewardBreakdown(ctx context.Context, req *QueryMinerRewardBreakdownRequest) (*QueryMinerRewardBreakdownResponse, error)
+	Algorithm(ctx context.Context, req *QueryAlgorithmRequest) (*QueryAlgorithmResponse, error)
+	Algorithms(ctx context.Context, req *QueryAlgorithmsRequest) (*QueryAlgorithmsResponse, error)
+	JobLandscape(ctx context.Context, req *QueryJobLandscapeRequest) (*QueryJobLandscapeResponse, error)
+	SupplyProjection(ctx context.Context, req *QuerySupplyProjectionRequest) (*QuerySupplyProjectionResponse, error)
+	Invariants(ctx context.Context, req *QueryInvariantsRequest) (*QueryInvariantsResponse, error)
+	VestingGrants(ctx context.Context, req *QueryVestingGrantsRequest) (*QueryVestingGrantsResponse, error)
+	EmissionAllocation(ctx context.Context, req *QueryEmissionAllocationRequest) (*QueryEmissionAllocationResponse, error)
+	Pool(ctx context.Context, req *QueryPoolRequest) (*QueryPoolResponse, error)
+	PoolEarnings(ctx context.Context, req *QueryPoolEarningsRequest) (*QueryPoolEarningsResponse, error)
+	Treasury(ctx context.Context, req *QueryTreasuryRequest) (*QueryTreasuryResponse, error)
+	TreasurySpends(ctx context.Context, req *QueryTreasurySpendsRequest) (*QueryTreasurySpendsResponse, error)
+	BurnStats(ctx context.Context, req *QueryBurnStatsRequest) (*QueryBurnStatsResponse, error)
+	BaseFee(ctx context.Context, req *QueryBaseFeeRequest) (*QueryBaseFeeResponse, error)
+	MinerRegistration(ctx context.Context, req *QueryMinerRegistrationRequest) (*QueryMinerRegistrationResponse, error)
+	MinerRegistry(ctx context.Context, req *QueryMinerRegistryRequest) (*QueryMinerRegistryResponse, error)
+}
+
+type queryClient struct {
+	clientCtx client.Context
+}
+
+func (q *queryClient) Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error) {
+	out := new(QueryParamsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Params", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Job(ctx context.Context, req *QueryJobRequest) (*QueryJobResponse, error) {
+	out := new(QueryJobResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Job", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Jobs(ctx context.Context, req *QueryJobsRequest) (*QueryJobsResponse, error) {
+	out := new(QueryJobsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Jobs", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) MinerShares(ctx context.Context, req *QueryMinerSharesRequest) (*QueryMinerSharesResponse, error) {
+	out := new(QueryMinerSharesResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerShares", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) MinerStats(ctx context.Context, req *QueryMinerStatsRequest) (*QueryMinerStatsResponse, error) {
+	out := new(QueryMinerStatsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerStats", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) ActiveJob(ctx context.Context, req *QueryActiveJobRequest) (*QueryActiveJobResponse, error) {
+	out := new(QueryActiveJobResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/ActiveJob", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) QueueStatus(ctx context.Context, req *QueryQueueStatusRequest) (*QueryQueueStatusResponse, error) {
+	out := new(QueryQueueStatusResponse)
 	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/QueueStatus", req, out)
 	if err != nil {
 		return nil, err
@@ -640,4 +1654,166 @@ func (q *queryClient) LatestCheckpoint(ctx context.Context, req *QueryLatestChec
 		return nil, err
 	}
 	return out, nil
+}
+
+func (q *queryClient) MinerGroup(ctx context.Context, req *QueryMinerGroupRequest) (*QueryMinerGroupResponse, error) {
+	out := new(QueryMinerGroupResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerGroup", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Randomness(ctx context.Context, req *QueryRandomnessRequest) (*QueryRandomnessResponse, error) {
+	out := new(QueryRandomnessResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Randomness", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) MinerRewardBreakdown(ctx context.Context, req *QueryMinerRewardBreakdownRequest) (*QueryMinerRewardBreakdownResponse, error) {
+	out := new(QueryMinerRewardBreakdownResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerRewardBreakdown", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Algorithm(ctx context.Context, req *QueryAlgorithmRequest) (*QueryAlgorithmResponse, error) {
+	out := new(QueryAlgorithmResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Algorithm", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Algorithms(ctx context.Context, req *QueryAlgorithmsRequest) (*QueryAlgorithmsResponse, error) {
+	out := new(QueryAlgorithmsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Algorithms", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) JobLandscape(ctx context.Context, req *QueryJobLandscapeRequest) (*QueryJobLandscapeResponse, error) {
+	out := new(QueryJobLandscapeResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/JobLandscape", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) SupplyProjection(ctx context.Context, req *QuerySupplyProjectionRequest) (*QuerySupplyProjectionResponse, error) {
+	out := new(QuerySupplyProjectionResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/SupplyProjection", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Invariants(ctx context.Context, req *QueryInvariantsRequest) (*QueryInvariantsResponse, error) {
+	out := new(QueryInvariantsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Invariants", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) VestingGrants(ctx context.Context, req *QueryVestingGrantsRequest) (*QueryVestingGrantsResponse, error) {
+	out := new(QueryVestingGrantsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/VestingGrants", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) EmissionAllocation(ctx context.Context, req *QueryEmissionAllocationRequest) (*QueryEmissionAllocationResponse, error) {
+	out := new(QueryEmissionAllocationResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/EmissionAllocation", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Pool(ctx context.Context, req *QueryPoolRequest) (*QueryPoolResponse, error) {
+	out := new(QueryPoolResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Pool", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) PoolEarnings(ctx context.Context, req *QueryPoolEarningsRequest) (*QueryPoolEarningsResponse, error) {
+	out := new(QueryPoolEarningsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/PoolEarnings", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) Treasury(ctx context.Context, req *QueryTreasuryRequest) (*QueryTreasuryResponse, error) {
+	out := new(QueryTreasuryResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/Treasury", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) TreasurySpends(ctx context.Context, req *QueryTreasurySpendsRequest) (*QueryTreasurySpendsResponse, error) {
+	out := new(QueryTreasurySpendsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/TreasurySpends", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) BurnStats(ctx context.Context, req *QueryBurnStatsRequest) (*QueryBurnStatsResponse, error) {
+	out := new(QueryBurnStatsResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/BurnStats", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) BaseFee(ctx context.Context, req *QueryBaseFeeRequest) (*QueryBaseFeeResponse, error) {
+	out := new(QueryBaseFeeResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/BaseFee", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) MinerRegistration(ctx context.Context, req *QueryMinerRegistrationRequest) (*QueryMinerRegistrationResponse, error) {
+	out := new(QueryMinerRegistrationResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerRegistration", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *queryClient) MinerRegistry(ctx context.Context, req *QueryMinerRegistryRequest) (*QueryMinerRegistryResponse, error) {
+	out := new(QueryMinerRegistryResponse)
+	err := q.clientCtx.Invoke(ctx, "/nexus.mining.v1.Query/MinerRegistry", req, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package types
+
+import "cosmossdk.io/math"
+
+// ClaimableReward is a miner's payout on a settled job. Settlement records
+// it once per miner; MsgClaimRewards withdraws and deletes it.
+type ClaimableReward struct {
+	JobId          string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id"`
+	Miner          string   `protobuf:"bytes,2,opt,name=miner,proto3" json:"miner"`
+	CustomerReward math.Int `protobuf:"bytes,3,opt,name=customer_reward,json=customerReward,proto3,customtype=cosmossdk.io/math.Int" json:"customer_reward"`
+	EmissionReward math.Int `protobuf:"bytes,4,opt,name=emission_reward,json=emissionReward,proto3,customtype=cosmossdk.io/math.Int" json:"emission_reward"`
+
+	// Collaborative jobs: the same total split by pool
+	WorkReward        math.Int `protobuf:"bytes,5,opt,name=work_reward,json=workReward,proto3,customtype=cosmossdk.io/math.Int" json:"work_reward"`
+	ImprovementReward math.Int `protobuf:"bytes,6,opt,name=improvement_reward,json=improvementReward,proto3,customtype=cosmossdk.io/math.Int" json:"improvement_reward"`
+}
+
+func (m *ClaimableReward) Reset()         { *m = ClaimableReward{} }
+func (m *ClaimableReward) String() string { return m.JobId + "/" + m.Miner }
+func (m *ClaimableReward) ProtoMessage()  {}
+
+// Total returns the amount the miner withdraws
+func (m ClaimableReward) Total() math.Int {
+	return m.CustomerReward.Add(m.EmissionReward)
+}
+
+// JobMinerKey builds the per-job key suffix used by settlement prefixes
+func JobMinerKey(jobId, miner string) []byte {
+	key := append([]byte(jobId), '/')
+	return append(key, miner...)
+}
+
+// MinerJobKey builds the per-miner key suffix of the outstanding job index
+func MinerJobKey(miner, jobId string) []byte {
+	key := append([]byte(miner), '/')
+	return append(key, jobId...)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TreasuryModuleName is the module account holding the mining treasury
+const TreasuryModuleName = "mining_treasury"
+
+// MaxTreasuryPurposeLength bounds the purpose recorded with a spend
+const MaxTreasuryPurposeLength = 256
+
+// TreasurySpend records one governance payout from the mining treasury
+type TreasurySpend struct {
+	Id        uint64    `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Recipient string    `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient"`
+	Amount    sdk.Coins `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	Purpose   string    `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose"`
+	Height    int64     `protobuf:"varint,5,opt,name=height,proto3" json:"height"`
+	Time      int64     `protobuf:"varint,6,opt,name=time,proto3" json:"time"`
+}
+
+func (m *TreasurySpend) Reset()         { *m = TreasurySpend{} }
+func (m *TreasurySpend) String() string { return m.Recipient }
+func (m *TreasurySpend) ProtoMessage()  {}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+
+	"cosmossdk.io/math"
+)
+
 // Job Status
 type JobStatus uint32
 
@@ -27,6 +33,14 @@ const (
 	MiningModeCollaborative MiningMode = 1 // New: share work, combine results
 )
 
+// DefaultCollaborativeAlgorithm is used when a collaborative job does not name an algorithm
+const DefaultCollaborativeAlgorithm = "nexus_sa_v1"
+
+// IsValid reports whether the mode is a known mining mode
+func (m MiningMode) IsValid() bool {
+	return m == MiningModeCompetitive || m == MiningModeCollaborative
+}
+
 type Job struct {
 	Id           string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Customer     string    `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
@@ -34,7 +48,7 @@ type Job struct {
 	ProblemData  []byte    `protobuf:"bytes,4,opt,name=problem_data,json=problemData,proto3" json:"problem_data,omitempty"`
 	ProblemHash  string    `protobuf:"bytes,5,opt,name=problem_hash,json=problemHash,proto3" json:"problem_hash,omitempty"`
 	Threshold    int64     `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	Reward       int64     `protobuf:"varint,7,opt,name=reward,proto3" json:"reward,omitempty"`
+	Reward       math.Int  `protobuf:"bytes,7,opt,name=reward,proto3,customtype=cosmossdk.io/math.Int" json:"reward"`
 	Status       JobStatus `protobuf:"varint,8,opt,name=status,proto3,casttype=JobStatus" json:"status,omitempty"`
 	BestEnergy   int64     `protobuf:"varint,9,opt,name=best_energy,json=bestEnergy,proto3" json:"best_energy,omitempty"`
 	BestSolver   string    `protobuf:"bytes,10,opt,name=best_solver,json=bestSolver,proto3" json:"best_solver,omitempty"`
@@ -42,25 +56,74 @@ type Job struct {
 	CreatedAt    int64     `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	Deadline     int64     `protobuf:"varint,13,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	IsBackground bool      `protobuf:"varint,14,opt,name=is_background,json=isBackground,proto3" json:"is_background,omitempty"`
-	PriorityFee  int64     `protobuf:"varint,15,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"`
+	PriorityFee  math.Int  `protobuf:"bytes,15,opt,name=priority_fee,json=priorityFee,proto3,customtype=cosmossdk.io/math.Int" json:"priority_fee"`
 	Title        string    `protobuf:"bytes,16,opt,name=title,proto3" json:"title,omitempty"`
 	IpfsCid      string    `protobuf:"bytes,17,opt,name=ipfs_cid,json=ipfsCid,proto3" json:"ipfs_cid,omitempty"`
 
 	// Collaborative mining fields
-	MiningMode       MiningMode `protobuf:"varint,18,opt,name=mining_mode,json=miningMode,proto3,casttype=MiningMode" json:"mining_mode,omitempty"`
-	CurrentEpoch     uint64     `protobuf:"varint,19,opt,name=current_epoch,json=currentEpoch,proto3" json:"current_epoch,omitempty"`
-	TotalSteps       uint64     `protobuf:"varint,20,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
-	WorkPoolShares   int64      `protobuf:"varint,21,opt,name=work_pool_shares,json=workPoolShares,proto3" json:"work_pool_shares,omitempty"`
-	BonusPoolShares  int64      `protobuf:"varint,22,opt,name=bonus_pool_shares,json=bonusPoolShares,proto3" json:"bonus_pool_shares,omitempty"`
-	VrfRandomness    string     `protobuf:"bytes,23,opt,name=vrf_randomness,json=vrfRandomness,proto3" json:"vrf_randomness,omitempty"`
-	AlgorithmId      string     `protobuf:"bytes,24,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
-	SubmissionCount  int64      `protobuf:"varint,25,opt,name=submission_count,json=submissionCount,proto3" json:"submission_count,omitempty"`
+	MiningMode      MiningMode `protobuf:"varint,18,opt,name=mining_mode,json=miningMode,proto3,casttype=MiningMode" json:"mining_mode,omitempty"`
+	CurrentEpoch    uint64     `protobuf:"varint,19,opt,name=current_epoch,json=currentEpoch,proto3" json:"current_epoch,omitempty"`
+	TotalSteps      uint64     `protobuf:"varint,20,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	WorkPoolShares  int64      `protobuf:"varint,21,opt,name=work_pool_shares,json=workPoolShares,proto3" json:"work_pool_shares,omitempty"`
+	BonusPoolShares int64      `protobuf:"varint,22,opt,name=bonus_pool_shares,json=bonusPoolShares,proto3" json:"bonus_pool_shares,omitempty"`
+	VrfRandomness   string     `protobuf:"bytes,23,opt,name=vrf_randomness,json=vrfRandomness,proto3" json:"vrf_randomness,omitempty"`
+	AlgorithmId     string     `protobuf:"bytes,24,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
+	SubmissionCount int64      `protobuf:"varint,25,opt,name=submission_count,json=submissionCount,proto3" json:"submission_count,omitempty"`
+
+	// Permissioned jobs: empty allowlist and group means open to all miners
+	Allowlist  []string `protobuf:"bytes,26,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	MinerGroup string   `protobuf:"bytes,27,opt,name=miner_group,json=minerGroup,proto3" json:"miner_group,omitempty"`
+
+	// Start of the current collaborative epoch
+	EpochStartHeight int64 `protobuf:"varint,28,opt,name=epoch_start_height,json=epochStartHeight,proto3" json:"epoch_start_height,omitempty"`
+	EpochStartTime   int64 `protobuf:"varint,29,opt,name=epoch_start_time,json=epochStartTime,proto3" json:"epoch_start_time,omitempty"`
+
+	// Number of distinct local minima (by BestConfigHash) in the solution landscape
+	DistinctMinima int64 `protobuf:"varint,30,opt,name=distinct_minima,json=distinctMinima,proto3" json:"distinct_minima,omitempty"`
+
+	// Settlement: payouts are fixed once when the job completes or expires
+	SettledAt       int64    `protobuf:"varint,31,opt,name=settled_at,json=settledAt,proto3" json:"settled_at,omitempty"`
+	SettledEmission math.Int `protobuf:"bytes,32,opt,name=settled_emission,json=settledEmission,proto3,customtype=cosmossdk.io/math.Int" json:"settled_emission"`
+
+	// Members of MinerGroup copied when the job was posted, so later group
+	// edits do not change who may mine the job
+	GroupMembers []string `protobuf:"bytes,33,rep,name=group_members,json=groupMembers,proto3" json:"group_members,omitempty"`
+
+	// When the job was solved or expired; its emission window ends here
+	FinishedAt int64 `protobuf:"varint,34,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
 }
 
 func (j *Job) Reset()         { *j = Job{} }
 func (j *Job) String() string { return j.Id }
 func (j *Job) ProtoMessage()  {}
 
+// IsSettled reports whether the job's payouts have been recorded
+func (j Job) IsSettled() bool {
+	return j.SettledAt != 0
+}
+
+// IsFinished reports whether the job can no longer accept work
+func (j Job) IsFinished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusExpired
+}
+
+// IsOpen reports whether any miner may submit work for the job
+func (j Job) IsOpen() bool {
+	return len(j.Allowlist) == 0 && j.MinerGroup == ""
+}
+
+// MinerGroup is a named, owner-managed set of vetted miners that
+// permissioned jobs can reference instead of listing addresses
+type MinerGroup struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner   string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Members []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (g *MinerGroup) Reset()         { *g = MinerGroup{} }
+func (g *MinerGroup) String() string { return g.Name }
+func (g *MinerGroup) ProtoMessage()  {}
+
 // WorkSubmission tracks a single work submission for collaborative mining
 type WorkSubmission struct {
 	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -76,18 +139,35 @@ type WorkSubmission struct {
 	WorkShares     int64  `protobuf:"varint,11,opt,name=work_shares,json=workShares,proto3" json:"work_shares,omitempty"`
 	BonusShares    int64  `protobuf:"varint,12,opt,name=bonus_shares,json=bonusShares,proto3" json:"bonus_shares,omitempty"`
 	SubmittedAt    int64  `protobuf:"varint,13,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	Sequence       uint64 `protobuf:"varint,14,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (w *WorkSubmission) Reset()         { *w = WorkSubmission{} }
 func (w *WorkSubmission) String() string { return w.Id }
 func (w *WorkSubmission) ProtoMessage()  {}
 
+// WorkSubmissionID returns the ID of a miner's seq-th submission in an epoch
+func WorkSubmissionID(jobId, miner string, epoch, seq uint64) string {
+	return fmt.Sprintf("%s_%s_%d_%d", jobId, miner, epoch, seq)
+}
+
+// MinerEpochUsage counts a miner's submissions and steps in one epoch of a
+// collaborative job; it drives the submission sequence and per-epoch limits
+type MinerEpochUsage struct {
+	Submissions uint64 `protobuf:"varint,1,opt,name=submissions,proto3" json:"submissions"`
+	Steps       uint64 `protobuf:"varint,2,opt,name=steps,proto3" json:"steps"`
+}
+
+func (u *MinerEpochUsage) Reset()         { *u = MinerEpochUsage{} }
+func (u *MinerEpochUsage) String() string { return "MinerEpochUsage" }
+func (u *MinerEpochUsage) ProtoMessage()  {}
+
 type Checkpoint struct {
-	Id               uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	StartHeight      int64  `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
-	EndHeight        int64  `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
-	ValidatorRewards int64  `protobuf:"varint,4,opt,name=validator_rewards,json=validatorRewards,proto3" json:"validator_rewards,omitempty"`
-	Timestamp        int64  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id               uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartHeight      int64    `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EndHeight        int64    `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	ValidatorRewards math.Int `protobuf:"bytes,4,opt,name=validator_rewards,json=validatorRewards,proto3,customtype=cosmossdk.io/math.Int" json:"validator_rewards"`
+	Timestamp        int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (c *Checkpoint) Reset()         { *c = Checkpoint{} }
@@ -96,13 +176,33 @@ func (c *Checkpoint) ProtoMessage()  {}
 
 // ValidatorMiningRecord tracks a validator's mining activity
 type ValidatorMiningRecord struct {
-	ValidatorAddr  string `protobuf:"bytes,1,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr,omitempty"`
-	TotalShares    int64  `protobuf:"varint,2,opt,name=total_shares,json=totalShares,proto3" json:"total_shares,omitempty"`
-	TotalRewards   int64  `protobuf:"varint,3,opt,name=total_rewards,json=totalRewards,proto3" json:"total_rewards,omitempty"`
-	JobsCompleted  int64  `protobuf:"varint,4,opt,name=jobs_completed,json=jobsCompleted,proto3" json:"jobs_completed,omitempty"`
-	LastActiveTime int64  `protobuf:"varint,5,opt,name=last_active_time,json=lastActiveTime,proto3" json:"last_active_time,omitempty"`
+	ValidatorAddr  string   `protobuf:"bytes,1,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr,omitempty"`
+	TotalShares    int64    `protobuf:"varint,2,opt,name=total_shares,json=totalShares,proto3" json:"total_shares,omitempty"`
+	TotalRewards   math.Int `protobuf:"bytes,3,opt,name=total_rewards,json=totalRewards,proto3,customtype=cosmossdk.io/math.Int" json:"total_rewards"`
+	JobsCompleted  int64    `protobuf:"varint,4,opt,name=jobs_completed,json=jobsCompleted,proto3" json:"jobs_completed,omitempty"`
+	LastActiveTime int64    `protobuf:"varint,5,opt,name=last_active_time,json=lastActiveTime,proto3" json:"last_active_time,omitempty"`
 }
 
 func (v *ValidatorMiningRecord) Reset()         { *v = ValidatorMiningRecord{} }
 func (v *ValidatorMiningRecord) String() string { return v.ValidatorAddr }
 func (v *ValidatorMiningRecord) ProtoMessage()  {}
+
+// MinerValidatorLink attributes a miner's work to a validator's mining record
+type MinerValidatorLink struct {
+	Miner     string `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner,omitempty"`
+	Validator string `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+}
+
+func (l *MinerValidatorLink) Reset()         { *l = MinerValidatorLink{} }
+func (l *MinerValidatorLink) String() string { return l.Miner }
+func (l *MinerValidatorLink) ProtoMessage()  {}
+
+// WorkloadEscrow is the minted emission held for one workload
+type WorkloadEscrow struct {
+	Workload string   `protobuf:"bytes,1,opt,name=workload,proto3" json:"workload,omitempty"`
+	Amount   math.Int `protobuf:"bytes,2,opt,name=amount,proto3,customtype=cosmossdk.io/math.Int" json:"amount"`
+}
+
+func (e *WorkloadEscrow) Reset()         { *e = WorkloadEscrow{} }
+func (e *WorkloadEscrow) String() string { return e.Workload }
+func (e *WorkloadEscrow) ProtoMessage()  {}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"cosmossdk.io/math"
+)
+
+// VestingGrant is the vesting part of one miner payout. It unlocks linearly
+// from StartTime to EndTime; MsgWithdrawVested releases what has unlocked
+// and a fraud slash forfeits what has not.
+type VestingGrant struct {
+	Miner     string   `protobuf:"bytes,1,opt,name=miner,proto3" json:"miner"`
+	Id        uint64   `protobuf:"varint,2,opt,name=id,proto3" json:"id"`
+	Source    string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source"`
+	Total     math.Int `protobuf:"bytes,4,opt,name=total,proto3,customtype=cosmossdk.io/math.Int" json:"total"`
+	Withdrawn math.Int `protobuf:"bytes,5,opt,name=withdrawn,proto3,customtype=cosmossdk.io/math.Int" json:"withdrawn"`
+	StartTime int64    `protobuf:"varint,6,opt,name=start_time,json=startTime,proto3" json:"start_time"`
+	EndTime   int64    `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time"`
+}
+
+func (m *VestingGrant) Reset()         { *m = VestingGrant{} }
+func (m *VestingGrant) String() string { return m.Miner }
+func (m *VestingGrant) ProtoMessage()  {}
+
+// Vested returns the part of the grant unlocked at unix time now
+func (m VestingGrant) Vested(now int64) math.Int {
+	if now >= m.EndTime {
+		return m.Total
+	}
+	if now <= m.StartTime {
+		return math.ZeroInt()
+	}
+	return m.Total.MulRaw(now - m.StartTime).QuoRaw(m.EndTime - m.StartTime)
+}
+
+// Withdrawable returns the unlocked part not yet withdrawn
+func (m VestingGrant) Withdrawable(now int64) math.Int {
+	return m.Vested(now).Sub(m.Withdrawn)
+}
+
+// Outstanding returns what the module still owes on the grant
+func (m VestingGrant) Outstanding() math.Int {
+	return m.Total.Sub(m.Withdrawn)
+}
+
+// VestingGrantKey builds the per-miner key suffix of a vesting grant
+func VestingGrantKey(miner string, id uint64) []byte {
+	key := append([]byte(miner), '/')
+	return binary.BigEndian.AppendUint64(key, id)
+}